@@ -0,0 +1,182 @@
+/*
+ * Copyright (c) 2022, HabanaLabs Ltd.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the Lic
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gohlml
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// AccelSysfs holds the habanalabs attributes of an accel device, read from
+// class/accel/accelN/device. Attributes the driver does not expose are
+// left zero
+type AccelSysfs struct {
+	// Accel is the accel class entry, e.g. accel0
+	Accel string
+
+	Status       string
+	DeviceType   string
+	PCIAddr      string
+	ParentDevice string
+	PMMngProfile string
+
+	HardResetCount uint64
+	SoftResetCount uint64
+
+	// MaxPower is the maximum power consumption in milliwatts
+	MaxPower        uint64
+	ClkCurFreqMHz   uint64
+	ClkMaxFreqMHz   uint64
+	HighPLL         uint64
+	ICClk           uint64
+	ICClkCurr       uint64
+	MMEClk          uint64
+	MMEClkCurr      uint64
+	TPCClk          uint64
+	TPCClkCurr      uint64
+	ModuleID        uint64
+	SecurityEnabled bool
+
+	// Errors maps the attributes that exist but could not be read or
+	// parsed to the error. It is nil when there were none
+	Errors map[string]error
+}
+
+// accelDir returns the accel class entry of the device with the given
+// minor number. It matches the minor of the dev attribute of each entry,
+// and falls back to accelN for a minor number N
+func accelDir(minor uint) (string, error) {
+	class := sysfsPath("class", "accel")
+	entries, err := os.ReadDir(class)
+	if err != nil {
+		return "", err
+	}
+	for _, e := range entries {
+		if !accelDevice.MatchString(e.Name()) {
+			continue
+		}
+		b, err := os.ReadFile(filepath.Join(class, e.Name(), "dev"))
+		if err != nil {
+			continue
+		}
+		_, m, ok := strings.Cut(strings.TrimSpace(string(b)), ":")
+		if ok && m == strconv.FormatUint(uint64(minor), 10) {
+			return filepath.Join(class, e.Name()), nil
+		}
+	}
+
+	dir := filepath.Join(class, fmt.Sprintf("accel%d", minor))
+	if _, err := os.Stat(dir); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+// readAttr reads a sysfs attribute, trimming trailing white space. It
+// reports false if the attribute does not exist
+func readAttr(dir, name string) (string, bool, error) {
+	b, err := os.ReadFile(filepath.Join(dir, name))
+	if errors.Is(err, os.ErrNotExist) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", true, err
+	}
+	return strings.TrimRight(string(b), " \t\n\x00"), true, nil
+}
+
+// parseUint parses a decimal or 0x prefixed hexadecimal sysfs value
+func parseUint(s string) (uint64, error) {
+	if hex, ok := strings.CutPrefix(s, "0x"); ok {
+		return strconv.ParseUint(hex, 16, 64)
+	}
+	return strconv.ParseUint(s, 10, 64)
+}
+
+// ReadAccelSysfs reads the habanalabs sysfs attributes of the device with
+// the given minor number, as returned by Device.MinorNumber. It fails only
+// if the device has no accel class entry
+func ReadAccelSysfs(minor uint) (AccelSysfs, error) {
+	dir, err := accelDir(minor)
+	if err != nil {
+		return AccelSysfs{}, err
+	}
+	a := AccelSysfs{Accel: filepath.Base(dir)}
+	dev := filepath.Join(dir, "device")
+
+	fail := func(name string, err error) {
+		if a.Errors == nil {
+			a.Errors = map[string]error{}
+		}
+		a.Errors[name] = err
+	}
+	for name, p := range map[string]*string{
+		"status":         &a.Status,
+		"device_type":    &a.DeviceType,
+		"pci_addr":       &a.PCIAddr,
+		"parent_device":  &a.ParentDevice,
+		"pm_mng_profile": &a.PMMngProfile,
+	} {
+		v, ok, err := readAttr(dev, name)
+		if err != nil {
+			fail(name, err)
+		} else if ok {
+			*p = v
+		}
+	}
+	for name, p := range map[string]*uint64{
+		"hard_reset_cnt":   &a.HardResetCount,
+		"soft_reset_cnt":   &a.SoftResetCount,
+		"max_power":        &a.MaxPower,
+		"clk_cur_freq_mhz": &a.ClkCurFreqMHz,
+		"clk_max_freq_mhz": &a.ClkMaxFreqMHz,
+		"high_pll":         &a.HighPLL,
+		"ic_clk":           &a.ICClk,
+		"ic_clk_curr":      &a.ICClkCurr,
+		"mme_clk":          &a.MMEClk,
+		"mme_clk_curr":     &a.MMEClkCurr,
+		"tpc_clk":          &a.TPCClk,
+		"tpc_clk_curr":     &a.TPCClkCurr,
+		"module_id":        &a.ModuleID,
+	} {
+		v, ok, err := readAttr(dev, name)
+		if err != nil {
+			fail(name, err)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if *p, err = parseUint(v); err != nil {
+			fail(name, err)
+		}
+	}
+
+	v, ok, err := readAttr(dev, "security_enabled")
+	if err != nil {
+		fail("security_enabled", err)
+	} else if ok {
+		if a.SecurityEnabled, err = strconv.ParseBool(v); err != nil {
+			fail("security_enabled", err)
+		}
+	}
+	return a, nil
+}
@@ -0,0 +1,86 @@
+/*
+ * Copyright (c) 2022, HabanaLabs Ltd.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the Lic
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package gohlml
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// writeAccel lays out class/accel/<name> under root, with the given dev
+// attribute and device attributes
+func writeAccel(t *testing.T, root, name, dev string, attrs map[string]string) {
+	t.Helper()
+
+	pci := filepath.Join(root, "devices", "pci0000:00", name)
+	assert.Nil(t, os.MkdirAll(pci, 0o755))
+	for attr, v := range attrs {
+		assert.Nil(t, os.WriteFile(filepath.Join(pci, attr), []byte(v), 0o644))
+	}
+	class := filepath.Join(root, "class", "accel", name)
+	assert.Nil(t, os.MkdirAll(class, 0o755))
+	assert.Nil(t, os.WriteFile(filepath.Join(class, "dev"), []byte(dev+"\n"), 0o644))
+	assert.Nil(t, os.Symlink(pci, filepath.Join(class, "device")))
+}
+
+func TestReadAccelSysfs(t *testing.T) {
+	root := t.TempDir()
+	useSysfsRoot(t, root)
+
+	writeAccel(t, root, "accel0", "261:0", map[string]string{
+		"status":           "operational\n",
+		"device_type":      "GAUDI2\n",
+		"pci_addr":         "0000:19:00.0\n",
+		"hard_reset_cnt":   "2\n",
+		"soft_reset_cnt":   "0\n",
+		"max_power":        "600000\n",
+		"clk_max_freq_mhz": "1650\n",
+		"high_pll":         "0x640\n",
+		"module_id":        "3\n",
+		"security_enabled": "1\n",
+		"clk_cur_freq_mhz": "n/a\n",
+	})
+	// the device at minor 1 lives at accel2, e.g. after a hotplug
+	writeAccel(t, root, "accel2", "261:1", map[string]string{"status": "in reset\n"})
+
+	a, err := ReadAccelSysfs(0)
+	assert.Nil(t, err, err)
+	assert.Equal(t, "accel0", a.Accel)
+	assert.Equal(t, "operational", a.Status)
+	assert.Equal(t, "GAUDI2", a.DeviceType)
+	assert.Equal(t, "0000:19:00.0", a.PCIAddr)
+	assert.Equal(t, uint64(2), a.HardResetCount)
+	assert.Equal(t, uint64(600000), a.MaxPower)
+	assert.Equal(t, uint64(1650), a.ClkMaxFreqMHz)
+	assert.Equal(t, uint64(0x640), a.HighPLL)
+	assert.Equal(t, uint64(3), a.ModuleID)
+	assert.True(t, a.SecurityEnabled)
+	assert.Empty(t, a.ParentDevice, "Missing attributes should be tolerated")
+	assert.Len(t, a.Errors, 1)
+	assert.Contains(t, a.Errors, "clk_cur_freq_mhz")
+
+	a, err = ReadAccelSysfs(1)
+	assert.Nil(t, err, err)
+	assert.Equal(t, "accel2", a.Accel, "The device should be found by its minor number")
+	assert.Equal(t, "in reset", a.Status)
+	assert.Nil(t, a.Errors)
+
+	_, err = ReadAccelSysfs(5)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
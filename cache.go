@@ -0,0 +1,357 @@
+/*
+ * Copyright (c) 2022, HabanaLabs Ltd.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the Lic
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gohlml
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Cache holds the static properties of every device, which do not change
+// while the driver stays loaded. It is filled on first use and again after
+// HLML is reinitialized, as reported by Interface.Generation. Call
+// Invalidate when devices come or go, e.g. on a WatchDevices event.
+//
+// A property that fails with ErrNotSupported or ErrInvalidArgument is cached
+// with its error, which is then returned for that property of that device
+// only. Other errors, such as ErrAipIsLost, ErrTimeout or a done context,
+// are returned without being cached and the property is read again on its
+// next use. A lookup that finds no device reads the failed properties again
+// before giving up
+type Cache struct {
+	lib Interface
+
+	mu         sync.RWMutex
+	loaded     bool
+	gen        uint64
+	devices    []*CachedDevice
+	bySerial   map[string]*CachedDevice
+	byUUID     map[string]*CachedDevice
+	byModuleID map[uint]*CachedDevice
+}
+
+// NewCache returns an empty Cache on top of lib
+func NewCache(lib Interface) *Cache {
+	return &Cache{lib: lib}
+}
+
+// Invalidate drops the cached properties, so that the next lookup queries
+// every device again
+func (c *Cache) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.loaded = false
+}
+
+// Devices returns the cached devices, ordered by index
+func (c *Cache) Devices(ctx context.Context) ([]*CachedDevice, error) {
+	if err := c.load(ctx); err != nil {
+		return nil, err
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return append([]*CachedDevice(nil), c.devices...), nil
+}
+
+// DeviceBySerial returns the cached device with the given serial number
+func (c *Cache) DeviceBySerial(ctx context.Context, serial string) (*CachedDevice, error) {
+	d, err := lookup(ctx, c, func() map[string]*CachedDevice { return c.bySerial }, serial)
+	if err == nil && d == nil {
+		err = fmt.Errorf("%s: %w", serial, ErrNotFound)
+	}
+	return d, err
+}
+
+// DeviceByUUID returns the cached device with the given UUID
+func (c *Cache) DeviceByUUID(ctx context.Context, uuid string) (*CachedDevice, error) {
+	d, err := lookup(ctx, c, func() map[string]*CachedDevice { return c.byUUID }, uuid)
+	if err == nil && d == nil {
+		err = fmt.Errorf("%s: %w", uuid, ErrNotFound)
+	}
+	return d, err
+}
+
+// DeviceByModuleID returns the cached device with the given module id
+func (c *Cache) DeviceByModuleID(ctx context.Context, id uint) (*CachedDevice, error) {
+	d, err := lookup(ctx, c, func() map[uint]*CachedDevice { return c.byModuleID }, id)
+	if err == nil && d == nil {
+		err = fmt.Errorf("module id %d: %w", id, ErrNotFound)
+	}
+	return d, err
+}
+
+// lookup finds key in the index returned by index, which is called with
+// the lock held since a reload replaces the index maps. On a miss the
+// devices are indexed again, in case key belongs to a device whose
+// property failed with a transient error. It returns nil if key is not
+// found
+func lookup[K comparable](ctx context.Context, c *Cache, index func() map[K]*CachedDevice, key K) (*CachedDevice, error) {
+	if err := c.load(ctx); err != nil {
+		return nil, err
+	}
+	c.mu.RLock()
+	d, ok := index()[key]
+	c.mu.RUnlock()
+	if ok {
+		return d, nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.index(ctx)
+	return index()[key], ctx.Err()
+}
+
+// load fills the cache unless it holds the properties of the current
+// generation
+func (c *Cache) load(ctx context.Context) error {
+	gen := c.lib.Generation()
+
+	c.mu.RLock()
+	fresh := c.loaded && c.gen == gen
+	c.mu.RUnlock()
+	if fresh {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.loaded && c.gen == gen {
+		return nil
+	}
+
+	count, err := c.lib.DeviceCountContext(ctx)
+	if err != nil {
+		return err
+	}
+	devices := make([]*CachedDevice, 0, count)
+	for i := uint(0); i < count; i++ {
+		dev, err := c.lib.DeviceHandleByIndexContext(ctx, i)
+		if err != nil {
+			return err
+		}
+		d := newCachedDevice(ctx, dev)
+		// properties failing because ctx is done say nothing of the device
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		devices = append(devices, d)
+	}
+
+	c.devices = devices
+	c.bySerial = make(map[string]*CachedDevice, count)
+	c.byUUID = make(map[string]*CachedDevice, count)
+	c.byModuleID = make(map[uint]*CachedDevice, count)
+	c.index(ctx)
+	c.loaded, c.gen = true, gen
+	return nil
+}
+
+// index adds the devices to the lookup maps by the properties they answer.
+// The properties that failed with a transient error are read again. It is
+// called with the lock held
+func (c *Cache) index(ctx context.Context) {
+	for _, d := range c.devices {
+		if serial, err := d.SerialNumberContext(ctx); err == nil {
+			c.bySerial[serial] = d
+		}
+		if uuid, err := d.UUIDContext(ctx); err == nil {
+			c.byUUID[uuid] = d
+		}
+		if id, err := d.ModuleIDContext(ctx); err == nil {
+			c.byModuleID[id] = d
+		}
+	}
+}
+
+// cached is a property value, or the permanent error reading it returned
+type cached[T any] struct {
+	mu   sync.Mutex
+	done bool
+	v    T
+	err  error
+}
+
+// get returns the cached property, reading it with fn if it is not cached
+// yet. The result is kept unless fn failed with a transient error
+func (p *cached[T]) get(ctx context.Context, fn func(context.Context) (T, error)) (T, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.done {
+		return p.v, p.err
+	}
+	v, err := fn(ctx)
+	if err == nil || permanent(err) {
+		p.done, p.v, p.err = true, v, err
+	}
+	return v, err
+}
+
+// permanent reports whether err will be returned again for the same call
+// as long as the driver stays loaded
+func permanent(err error) bool {
+	return errors.Is(err, ErrNotSupported) || errors.Is(err, ErrInvalidArgument)
+}
+
+// CachedDevice is a DeviceInterface that answers the static property
+// queries from the Cache and forwards all others to the device
+type CachedDevice struct {
+	DeviceInterface
+
+	name, uuid, serial, busID      cached[string]
+	pcbVersion, pcbAssemblyVersion cached[string]
+	moduleID, boardID              cached[uint]
+	hlRevision                     cached[int]
+	tempShutdown, tempSlowdown     cached[uint]
+	tempMemory, tempGPU            cached[uint]
+}
+
+func newCachedDevice(ctx context.Context, dev DeviceInterface) *CachedDevice {
+	d := &CachedDevice{DeviceInterface: dev}
+	// read the properties once, the results are kept by the getters. The
+	// serial, UUID and module id are read when the device is indexed
+	d.NameContext(ctx)
+	d.PCIBusIDContext(ctx)
+	d.PCBVersionContext(ctx)
+	d.PCBAssemblyVersionContext(ctx)
+	d.BoardIDContext(ctx)
+	d.HLRevisionContext(ctx)
+	d.TemperatureThresholdShutdownContext(ctx)
+	d.TemperatureThresholdSlowdownContext(ctx)
+	d.TemperatureThresholdMemoryContext(ctx)
+	d.TemperatureThresholdGPUContext(ctx)
+	return d
+}
+
+func (d *CachedDevice) Name() (string, error) {
+	return d.NameContext(context.Background())
+}
+
+func (d *CachedDevice) NameContext(ctx context.Context) (string, error) {
+	return d.name.get(ctx, d.DeviceInterface.NameContext)
+}
+
+func (d *CachedDevice) UUID() (string, error) {
+	return d.UUIDContext(context.Background())
+}
+
+func (d *CachedDevice) UUIDContext(ctx context.Context) (string, error) {
+	return d.uuid.get(ctx, d.DeviceInterface.UUIDContext)
+}
+
+func (d *CachedDevice) SerialNumber() (string, error) {
+	return d.SerialNumberContext(context.Background())
+}
+
+func (d *CachedDevice) SerialNumberContext(ctx context.Context) (string, error) {
+	return d.serial.get(ctx, d.DeviceInterface.SerialNumberContext)
+}
+
+func (d *CachedDevice) PCIBusID() (string, error) {
+	return d.PCIBusIDContext(context.Background())
+}
+
+func (d *CachedDevice) PCIBusIDContext(ctx context.Context) (string, error) {
+	return d.busID.get(ctx, d.DeviceInterface.PCIBusIDContext)
+}
+
+func (d *CachedDevice) PCBVersion() (string, error) {
+	return d.PCBVersionContext(context.Background())
+}
+
+func (d *CachedDevice) PCBVersionContext(ctx context.Context) (string, error) {
+	return d.pcbVersion.get(ctx, d.DeviceInterface.PCBVersionContext)
+}
+
+func (d *CachedDevice) PCBAssemblyVersion() (string, error) {
+	return d.PCBAssemblyVersionContext(context.Background())
+}
+
+func (d *CachedDevice) PCBAssemblyVersionContext(ctx context.Context) (string, error) {
+	return d.pcbAssemblyVersion.get(ctx, d.DeviceInterface.PCBAssemblyVersionContext)
+}
+
+func (d *CachedDevice) ModuleID() (uint, error) {
+	return d.ModuleIDContext(context.Background())
+}
+
+func (d *CachedDevice) ModuleIDContext(ctx context.Context) (uint, error) {
+	return d.moduleID.get(ctx, d.DeviceInterface.ModuleIDContext)
+}
+
+func (d *CachedDevice) BoardID() (uint, error) {
+	return d.BoardIDContext(context.Background())
+}
+
+func (d *CachedDevice) BoardIDContext(ctx context.Context) (uint, error) {
+	return d.boardID.get(ctx, d.DeviceInterface.BoardIDContext)
+}
+
+func (d *CachedDevice) HLRevision() (int, error) {
+	return d.HLRevisionContext(context.Background())
+}
+
+func (d *CachedDevice) HLRevisionContext(ctx context.Context) (int, error) {
+	return d.hlRevision.get(ctx, d.DeviceInterface.HLRevisionContext)
+}
+
+func (d *CachedDevice) TemperatureThresholdShutdown() (uint, error) {
+	return d.TemperatureThresholdShutdownContext(context.Background())
+}
+
+func (d *CachedDevice) TemperatureThresholdShutdownContext(ctx context.Context) (uint, error) {
+	return d.tempShutdown.get(ctx, d.DeviceInterface.TemperatureThresholdShutdownContext)
+}
+
+func (d *CachedDevice) TemperatureThresholdSlowdown() (uint, error) {
+	return d.TemperatureThresholdSlowdownContext(context.Background())
+}
+
+func (d *CachedDevice) TemperatureThresholdSlowdownContext(ctx context.Context) (uint, error) {
+	return d.tempSlowdown.get(ctx, d.DeviceInterface.TemperatureThresholdSlowdownContext)
+}
+
+func (d *CachedDevice) TemperatureThresholdMemory() (uint, error) {
+	return d.TemperatureThresholdMemoryContext(context.Background())
+}
+
+func (d *CachedDevice) TemperatureThresholdMemoryContext(ctx context.Context) (uint, error) {
+	return d.tempMemory.get(ctx, d.DeviceInterface.TemperatureThresholdMemoryContext)
+}
+
+func (d *CachedDevice) TemperatureThresholdGPU() (uint, error) {
+	return d.TemperatureThresholdGPUContext(context.Background())
+}
+
+func (d *CachedDevice) TemperatureThresholdGPUContext(ctx context.Context) (uint, error) {
+	return d.tempGPU.get(ctx, d.DeviceInterface.TemperatureThresholdGPUContext)
+}
+
+func (d *CachedDevice) Snapshot() Snapshot {
+	return d.SnapshotContext(context.Background())
+}
+
+func (d *CachedDevice) SnapshotContext(ctx context.Context) Snapshot {
+	return snapshot(ctx, d)
+}
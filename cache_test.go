@@ -0,0 +1,159 @@
+/*
+ * Copyright (c) 2022, HabanaLabs Ltd.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the Lic
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package gohlml_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/HabanaAI/gohlml"
+	"github.com/HabanaAI/gohlml/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCache(t *testing.T) {
+	ctx := context.Background()
+
+	var devices []*mock.Device
+	for i := uint(0); i < 2; i++ {
+		i := i
+		devices = append(devices, &mock.Device{
+			SerialNumberContextFunc: func(context.Context) (string, error) {
+				return fmt.Sprintf("AM2490000%d", i+1), nil
+			},
+			UUIDContextFunc: func(context.Context) (string, error) {
+				return fmt.Sprintf("uuid%d", i), nil
+			},
+			ModuleIDContextFunc: func(context.Context) (uint, error) { return 7 - i, nil },
+			BoardIDContextFunc: func(context.Context) (uint, error) {
+				return 0, gohlml.ErrNotSupported
+			},
+			PowerUsageContextFunc: func(context.Context) (uint, error) { return 100 * (i + 1), nil },
+		})
+	}
+	gen := uint64(1)
+	lib := &mock.Interface{
+		GenerationFunc:         func() uint64 { return gen },
+		DeviceCountContextFunc: func(context.Context) (uint, error) { return uint(len(devices)), nil },
+		DeviceHandleByIndexContextFunc: func(ctx context.Context, idx uint) (gohlml.DeviceInterface, error) {
+			return devices[idx], nil
+		},
+	}
+	c := gohlml.NewCache(lib)
+
+	d, err := c.DeviceBySerial(ctx, "AM24900002")
+	assert.Nil(t, err, err)
+	uuid, err := d.UUID()
+	assert.Nil(t, err, err)
+	assert.Equal(t, "uuid1", uuid)
+
+	_, err = d.BoardID()
+	assert.ErrorIs(t, err, gohlml.ErrNotSupported, "Unsupported properties should be cached")
+
+	power, err := d.PowerUsageContext(ctx)
+	assert.Nil(t, err, err)
+	assert.Equal(t, uint(200), power, "Dynamic properties should reach the device")
+
+	d, err = c.DeviceByModuleID(ctx, 7)
+	assert.Nil(t, err, err)
+	serial, _ := d.SerialNumber()
+	assert.Equal(t, "AM24900001", serial)
+
+	_, err = c.DeviceByUUID(ctx, "uuid0")
+	assert.Nil(t, err, err)
+	_, err = c.DeviceByUUID(ctx, "uuid9")
+	assert.ErrorIs(t, err, gohlml.ErrNotFound)
+	assert.Len(t, devices[0].SerialNumberContextCalls(), 1, "Lookups should be served from the cache")
+
+	gen++
+	_, err = c.DeviceBySerial(ctx, "AM24900001")
+	assert.Nil(t, err, err)
+	assert.Len(t, devices[0].SerialNumberContextCalls(), 2, "A new generation should reload the cache")
+
+	c.Invalidate()
+	all, err := c.Devices(ctx)
+	assert.Nil(t, err, err)
+	assert.Len(t, all, 2)
+	assert.Len(t, devices[0].SerialNumberContextCalls(), 3, "Invalidate should reload the cache")
+}
+
+func TestCacheDeviceError(t *testing.T) {
+	ctx := context.Background()
+
+	healthy := &mock.Device{
+		SerialNumberContextFunc: func(context.Context) (string, error) { return "AM24900001", nil },
+		UUIDContextFunc:         func(context.Context) (string, error) { return "uuid0", nil },
+	}
+	lostErr := gohlml.ErrAipIsLost
+	lost := &mock.Device{
+		SerialNumberContextFunc: func(context.Context) (string, error) {
+			if lostErr != nil {
+				return "", lostErr
+			}
+			return "AM24900002", nil
+		},
+		UUIDContextFunc: func(context.Context) (string, error) { return "uuid1", nil },
+		BoardIDContextFunc: func(context.Context) (uint, error) {
+			return 0, gohlml.ErrNotSupported
+		},
+	}
+	devices := []gohlml.DeviceInterface{healthy, lost}
+	lib := &mock.Interface{
+		DeviceCountContextFunc: func(context.Context) (uint, error) { return uint(len(devices)), nil },
+		DeviceHandleByIndexContextFunc: func(ctx context.Context, idx uint) (gohlml.DeviceInterface, error) {
+			return devices[idx], nil
+		},
+	}
+	c := gohlml.NewCache(lib)
+
+	all, err := c.Devices(ctx)
+	assert.Nil(t, err, err, "A failing device should not fail the load")
+	assert.Len(t, all, 2)
+
+	d, err := c.DeviceBySerial(ctx, "AM24900001")
+	assert.Nil(t, err, err)
+	serial, err := d.SerialNumber()
+	assert.Nil(t, err, err)
+	assert.Equal(t, "AM24900001", serial)
+
+	d, err = c.DeviceByUUID(ctx, "uuid1")
+	assert.Nil(t, err, err)
+	_, err = d.SerialNumber()
+	assert.ErrorIs(t, err, gohlml.ErrAipIsLost, "The error should be returned for the failing device")
+	assert.Len(t, lost.SerialNumberContextCalls(), 2, "Transient errors should not be cached")
+
+	_, err = c.DeviceBySerial(ctx, "AM24900002")
+	assert.ErrorIs(t, err, gohlml.ErrNotFound)
+
+	lostErr = nil
+	found, err := c.DeviceBySerial(ctx, "AM24900002")
+	assert.Nil(t, err, err, "A lookup should read the failed property again")
+	assert.Same(t, d, found)
+	serial, err = d.SerialNumber()
+	assert.Nil(t, err, err)
+	assert.Equal(t, "AM24900002", serial)
+	calls := len(lost.SerialNumberContextCalls())
+	_, err = d.SerialNumber()
+	assert.Nil(t, err, err)
+	assert.Len(t, lost.SerialNumberContextCalls(), calls, "The value should be cached once read")
+
+	_, err = d.BoardID()
+	assert.ErrorIs(t, err, gohlml.ErrNotSupported)
+	_, err = d.BoardID()
+	assert.ErrorIs(t, err, gohlml.ErrNotSupported)
+	assert.Len(t, lost.BoardIDContextCalls(), 1, "Permanent errors should be cached")
+}
@@ -0,0 +1,138 @@
+/*
+ * Copyright (c) 2022, HabanaLabs Ltd.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the Lic
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gohlml
+
+import (
+	"fmt"
+	"sort"
+)
+
+// FormFactor is the mechanical form of a device
+type FormFactor int
+
+const (
+	// FormFactorOAM devices are OCP Accelerator Modules on a baseboard
+	FormFactorOAM FormFactor = iota + 1
+	// FormFactorPCIe devices are PCIe add-in cards
+	FormFactorPCIe
+)
+
+var formFactorNames = map[FormFactor]string{
+	FormFactorOAM:  "OAM",
+	FormFactorPCIe: "PCIe",
+}
+
+func (f FormFactor) String() string {
+	if name, ok := formFactorNames[f]; ok {
+		return name
+	}
+	return fmt.Sprintf("FormFactor(%d)", int(f))
+}
+
+// DeviceModel describes the devices with a given PCI device id
+type DeviceModel struct {
+	DeviceID uint16
+	// Family is goya, greco or gaudi. Gaudi generations share the family
+	Family string
+	// Generation counts from 1 within the family
+	Generation int
+	// Name is the marketing name, e.g. Gaudi2 HL-225
+	Name       string
+	FormFactor FormFactor
+	// Secured is set for the secured boot variant of the device
+	Secured bool
+	// NICPorts is the number of integrated Ethernet ports
+	NICPorts int
+	// HBMGiB is the HBM capacity, 0 for devices with DDR memory
+	HBMGiB uint
+}
+
+var catalog = map[uint16]DeviceModel{}
+
+// The device ids of Goya and of the first two Gaudi generations, secured
+// variants included, are the hl_pci_ids enum of the habanalabs driver,
+// drivers/accel/habanalabs/common/habanalabs.h in Linux, and are listed
+// under vendor 1da3 in the PCI ID database. The Greco and Gaudi3 ids are
+// not in the upstream driver, they come from the habanalabs driver
+// releases that support those devices.
+//
+// The NIC port counts and HBM capacities are the integrated RoCE ports and
+// on-package memory of the Gaudi, Gaudi2 and Gaudi3 product briefs: 10x100
+// GbE and 32 GiB HBM2, 24x100 GbE and 96 GiB HBM2E, and 24x200 GbE and 128
+// GiB HBM2E. Goya and Greco have no NIC and use DDR4
+func init() {
+	for _, m := range []DeviceModel{
+		// PCI_IDS_GOYA
+		{DeviceID: 0x0001, Family: "goya", Generation: 1, Name: "Goya HL-100", FormFactor: FormFactorPCIe},
+		// Greco, with its secured variant
+		{DeviceID: 0x0020, Family: "greco", Generation: 1, Name: "Greco HL-100B", FormFactor: FormFactorPCIe},
+		{DeviceID: 0x0030, Family: "greco", Generation: 1, Name: "Greco HL-100B", FormFactor: FormFactorPCIe, Secured: true},
+
+		// PCI_IDS_GAUDI, PCI_IDS_GAUDI_HL2000M, PCI_IDS_GAUDI_SEC and
+		// PCI_IDS_GAUDI_HL2000M_SEC
+		{DeviceID: 0x1000, Family: "gaudi", Generation: 1, Name: "Gaudi HL-205", FormFactor: FormFactorOAM, NICPorts: 10, HBMGiB: 32},
+		{DeviceID: 0x1001, Family: "gaudi", Generation: 1, Name: "Gaudi HL-200", FormFactor: FormFactorPCIe, NICPorts: 10, HBMGiB: 32},
+		{DeviceID: 0x1010, Family: "gaudi", Generation: 1, Name: "Gaudi HL-205", FormFactor: FormFactorOAM, Secured: true, NICPorts: 10, HBMGiB: 32},
+		{DeviceID: 0x1011, Family: "gaudi", Generation: 1, Name: "Gaudi HL-200", FormFactor: FormFactorPCIe, Secured: true, NICPorts: 10, HBMGiB: 32},
+
+		// PCI_IDS_GAUDI2, the secured variant is not in the upstream driver
+		{DeviceID: 0x1020, Family: "gaudi", Generation: 2, Name: "Gaudi2 HL-225", FormFactor: FormFactorOAM, NICPorts: 24, HBMGiB: 96},
+		{DeviceID: 0x1021, Family: "gaudi", Generation: 2, Name: "Gaudi2 HL-225", FormFactor: FormFactorOAM, Secured: true, NICPorts: 24, HBMGiB: 96},
+
+		// Gaudi3 PCIe card and OAM modules, each OAM id with its secured
+		// variant
+		{DeviceID: 0x1030, Family: "gaudi", Generation: 3, Name: "Gaudi3 HL-338", FormFactor: FormFactorPCIe, NICPorts: 24, HBMGiB: 128},
+		{DeviceID: 0x1060, Family: "gaudi", Generation: 3, Name: "Gaudi3 HL-325L", FormFactor: FormFactorOAM, NICPorts: 24, HBMGiB: 128},
+		{DeviceID: 0x1061, Family: "gaudi", Generation: 3, Name: "Gaudi3 HL-325L", FormFactor: FormFactorOAM, Secured: true, NICPorts: 24, HBMGiB: 128},
+		{DeviceID: 0x1062, Family: "gaudi", Generation: 3, Name: "Gaudi3 HL-328", FormFactor: FormFactorOAM, NICPorts: 24, HBMGiB: 128},
+		{DeviceID: 0x1063, Family: "gaudi", Generation: 3, Name: "Gaudi3 HL-328", FormFactor: FormFactorOAM, Secured: true, NICPorts: 24, HBMGiB: 128},
+	} {
+		catalog[m.DeviceID] = m
+	}
+}
+
+// DeviceModels lists every known device model, ordered by device id
+func DeviceModels() []DeviceModel {
+	models := make([]DeviceModel, 0, len(catalog))
+	for _, m := range catalog {
+		models = append(models, m)
+	}
+	sort.Slice(models, func(i, j int) bool { return models[i].DeviceID < models[j].DeviceID })
+	return models
+}
+
+// LookupDeviceModel returns the model of the given PCI device id
+func LookupDeviceModel(deviceID uint16) (DeviceModel, bool) {
+	m, ok := catalog[deviceID]
+	return m, ok
+}
+
+// LookupPCIID returns the model of a device from the combined device and
+// vendor id of Device.PCIID. An id without the Habana vendor id in either
+// half is taken to be a bare device id
+func LookupPCIID(id uint) (DeviceModel, bool) {
+	switch {
+	case id&0xffff == HabanaVendorID:
+		id >>= 16
+	case id>>16 == HabanaVendorID:
+		id &= 0xffff
+	}
+	if id > 0xffff {
+		return DeviceModel{}, false
+	}
+	return LookupDeviceModel(uint16(id))
+}
@@ -0,0 +1,79 @@
+/*
+ * Copyright (c) 2022, HabanaLabs Ltd.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the Lic
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gohlml
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetDeviceFamily(t *testing.T) {
+	tests := []struct {
+		name          string
+		deviceName    string
+		generation    int
+		deviceID      uint16
+		errorExpected bool
+	}{
+		{name: "Get Guadi 1 device", errorExpected: false, deviceName: "gaudi", generation: 1, deviceID: 0x1000},
+		{name: "Get Guadi 2 device", errorExpected: false, deviceName: "gaudi", generation: 2, deviceID: 0x1020},
+		{name: "Get Guadi 3 device", errorExpected: false, deviceName: "gaudi", generation: 3, deviceID: 0x1060},
+		{name: "Get Greco device", errorExpected: false, deviceName: "greco", generation: 1, deviceID: 0x0020},
+		{name: "Get Goya device", errorExpected: false, deviceName: "goya", generation: 1, deviceID: 0x0001},
+		{name: "No device matched", errorExpected: true, deviceName: "", deviceID: 0x9999},
+		{name: "Suffix of a known id", errorExpected: true, deviceName: "", deviceID: 0x2000},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m, ok := LookupDeviceModel(tc.deviceID)
+			if !tc.errorExpected && !ok {
+				t.Fatalf("expected a model for %#04x", tc.deviceID)
+			}
+
+			if tc.errorExpected && ok {
+				t.Errorf("expected no model, got %+v", m)
+			}
+
+			if m.Family != tc.deviceName {
+				t.Errorf("expected device %q, got %q", tc.deviceName, m.Family)
+			}
+			if m.Generation != tc.generation {
+				t.Errorf("expected generation %d, got %d", tc.generation, m.Generation)
+			}
+		})
+	}
+}
+
+func TestLookupPCIID(t *testing.T) {
+	for _, id := range []uint{0x10201da3, 0x1da31020, 0x1020} {
+		m, ok := LookupPCIID(id)
+		assert.True(t, ok, "%#x", id)
+		assert.Equal(t, "Gaudi2 HL-225", m.Name, "%#x", id)
+		assert.Equal(t, 24, m.NICPorts, "%#x", id)
+	}
+	_, ok := LookupPCIID(0x12348086)
+	assert.False(t, ok)
+
+	models := DeviceModels()
+	assert.Equal(t, uint16(0x0001), models[0].DeviceID)
+	for _, m := range models {
+		assert.NotZero(t, m.Generation, m.Name)
+		assert.NotZero(t, m.FormFactor, m.Name)
+	}
+}
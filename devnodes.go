@@ -0,0 +1,191 @@
+/*
+ * Copyright (c) 2022, HabanaLabs Ltd.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the Lic
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gohlml
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// DevRootEnv names the environment variable that sets the default device
+// node root, e.g. /host/dev
+const DevRootEnv = "HLML_DEV_ROOT"
+
+var devRoot = rootOverride{env: DevRootEnv, def: "/dev"}
+
+// SetDevRoot sets where the device nodes are looked up. An empty root
+// restores the default, which is $HLML_DEV_ROOT if set and /dev otherwise
+func SetDevRoot(root string) {
+	devRoot.set(root)
+}
+
+// DevRoot returns where the package expects the device nodes
+func DevRoot() string {
+	return devRoot.get()
+}
+
+// DeviceNode is a character device node of a device
+type DeviceNode struct {
+	// Path is e.g. /dev/accel/accel0
+	Path  string
+	Major uint32
+	Minor uint32
+	UID   uint32
+	GID   uint32
+	// Owner and Group are the names of UID and GID, empty if they cannot
+	// be resolved, e.g. inside a container
+	Owner string
+	Group string
+	Mode  os.FileMode
+}
+
+// DeviceNodes returns the compute node accelN and the control node
+// accel_controlDN of the device, where N is its minor number. The device
+// numbers of each node are checked against sysfs, and the minor number of
+// the compute node against MinorNumber
+func (d Device) DeviceNodes() ([]DeviceNode, error) {
+	return d.DeviceNodesContext(context.Background())
+}
+
+// DeviceNodesContext is like DeviceNodes but returns ctx.Err() if ctx is
+// done before the minor number is resolved
+func (d Device) DeviceNodesContext(ctx context.Context) ([]DeviceNode, error) {
+	minor, err := d.MinorNumberContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return deviceNodes(minor)
+}
+
+func deviceNodes(minor uint) ([]DeviceNode, error) {
+	compute := fmt.Sprintf("accel%d", minor)
+	control := fmt.Sprintf("accel_controlD%d", minor)
+
+	var nodes []DeviceNode
+	for _, name := range []string{compute, control} {
+		path := filepath.Join(DevRoot(), "accel", name)
+		n, err := statDeviceNode(path)
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("device node %s: %w", path, ErrNotFound)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if name == compute && n.Minor != uint32(minor) {
+			return nil, fmt.Errorf("%w: %s has minor %d, the device has %d", ErrDeviceNodeMismatch, path, n.Minor, minor)
+		}
+		if major, minor, ok := sysfsDevNumber(name); ok && (n.Major != major || n.Minor != minor) {
+			return nil, fmt.Errorf("%w: %s is %d:%d, sysfs has %d:%d", ErrDeviceNodeMismatch, path, n.Major, n.Minor, major, minor)
+		}
+		if u, err := user.LookupId(strconv.FormatUint(uint64(n.UID), 10)); err == nil {
+			n.Owner = u.Username
+		}
+		if g, err := user.LookupGroupId(strconv.FormatUint(uint64(n.GID), 10)); err == nil {
+			n.Group = g.Name
+		}
+		nodes = append(nodes, n)
+	}
+	return nodes, nil
+}
+
+// sysfsDevNumber reads the dev attribute of class/accel/name, reporting
+// false if sysfs does not have it
+func sysfsDevNumber(name string) (major, minor uint32, ok bool) {
+	v, ok, err := readAttr(sysfsPath("class", "accel", name), "dev")
+	if err != nil || !ok {
+		return 0, 0, false
+	}
+	maj, min, found := strings.Cut(v, ":")
+	a, err1 := strconv.ParseUint(maj, 10, 32)
+	b, err2 := strconv.ParseUint(min, 10, 32)
+	if !found || err1 != nil || err2 != nil {
+		return 0, 0, false
+	}
+	return uint32(a), uint32(b), true
+}
+
+// DeviceNodeProblem is a device node the current user cannot open
+type DeviceNodeProblem struct {
+	Node DeviceNode
+	Err  error
+	// Reason explains the failure from the node ownership and mode
+	Reason string
+}
+
+// AuditDeviceNodes returns the nodes the current user cannot open for
+// reading and writing. Access is checked with access(2) rather than by
+// opening the nodes, since the driver lets a single process at a time
+// open the compute node
+func AuditDeviceNodes(nodes []DeviceNode) []DeviceNodeProblem {
+	var problems []DeviceNodeProblem
+	uid := os.Getuid()
+	gids, _ := os.Getgroups()
+	gids = append(gids, os.Getgid())
+	for _, n := range nodes {
+		if err := accessReadWrite(n.Path); err != nil {
+			problems = append(problems, DeviceNodeProblem{Node: n, Err: err, Reason: explainAccess(n, uid, gids)})
+		}
+	}
+	return problems
+}
+
+// explainAccess describes which permission bits of n apply to a user with
+// the given uid and groups
+func explainAccess(n DeviceNode, uid int, gids []int) string {
+	owner, group := n.Owner, n.Group
+	if owner == "" {
+		owner = strconv.FormatUint(uint64(n.UID), 10)
+	}
+	if group == "" {
+		group = strconv.FormatUint(uint64(n.GID), 10)
+	}
+	desc := fmt.Sprintf("%s is owned by %s:%s with mode %04o", n.Path, owner, group, n.Mode.Perm())
+
+	const rw = 0o6
+	switch {
+	case uid == 0:
+		return desc + ", denied to root"
+	case uint32(uid) == n.UID:
+		if n.Mode.Perm()>>6&rw != rw {
+			return desc + ", the owner lacks read and write permission"
+		}
+	case inGroups(n.GID, gids):
+		if n.Mode.Perm()>>3&rw != rw {
+			return desc + ", the group lacks read and write permission"
+		}
+	default:
+		if n.Mode.Perm()&rw != rw {
+			return fmt.Sprintf("%s, uid %d is not in group %s and others lack read and write permission", desc, uid, group)
+		}
+	}
+	return desc
+}
+
+func inGroups(gid uint32, gids []int) bool {
+	for _, g := range gids {
+		if uint32(g) == gid {
+			return true
+		}
+	}
+	return false
+}
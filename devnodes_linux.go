@@ -0,0 +1,59 @@
+//go:build linux
+
+/*
+ * Copyright (c) 2022, HabanaLabs Ltd.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the Lic
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gohlml
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// statDeviceNode reads the device numbers, ownership and mode of a
+// character device node
+func statDeviceNode(path string) (DeviceNode, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return DeviceNode{}, err
+	}
+	st, ok := fi.Sys().(*syscall.Stat_t)
+	if !ok || fi.Mode()&os.ModeCharDevice == 0 {
+		return DeviceNode{}, fmt.Errorf("%w: %s is not a character device", ErrDeviceNodeMismatch, path)
+	}
+	major, minor := splitDev(uint64(st.Rdev))
+	return DeviceNode{
+		Path:  path,
+		Major: major,
+		Minor: minor,
+		UID:   st.Uid,
+		GID:   st.Gid,
+		Mode:  fi.Mode(),
+	}, nil
+}
+
+// splitDev decodes a dev_t the way glibc major and minor do
+func splitDev(dev uint64) (major, minor uint32) {
+	major = uint32((dev>>8)&0xfff | (dev>>32)&0xfffff000)
+	minor = uint32(dev&0xff | (dev>>12)&0xffffff00)
+	return major, minor
+}
+
+// accessReadWrite checks that the real user may read and write path
+func accessReadWrite(path string) error {
+	return syscall.Access(path, 0x4|0x2)
+}
@@ -0,0 +1,33 @@
+//go:build !linux
+
+/*
+ * Copyright (c) 2022, HabanaLabs Ltd.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the Lic
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gohlml
+
+import "errors"
+
+var errNoDeviceNodes = errors.New("device nodes are only available on linux")
+
+// statDeviceNode is unsupported off Linux
+func statDeviceNode(path string) (DeviceNode, error) {
+	return DeviceNode{}, errNoDeviceNodes
+}
+
+// accessReadWrite is unsupported off Linux
+func accessReadWrite(path string) error {
+	return errNoDeviceNodes
+}
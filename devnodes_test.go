@@ -0,0 +1,143 @@
+//go:build linux
+
+/*
+ * Copyright (c) 2022, HabanaLabs Ltd.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the Lic
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gohlml
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// mknod creates a character device node, skipping the test without the
+// privilege to do so
+func mknod(t *testing.T, path string, major, minor uint32) {
+	err := syscall.Mknod(path, syscall.S_IFCHR|0o660, int(makeDev(major, minor)))
+	if err != nil {
+		t.Skipf("cannot create device nodes: %v", err)
+	}
+	assert.Nil(t, os.Chmod(path, 0o660))
+}
+
+// makeDev encodes a dev_t the way glibc makedev does
+func makeDev(major, minor uint32) uint64 {
+	return uint64(major&0xfff)<<8 | uint64(major&0xfffff000)<<32 | uint64(minor&0xff) | uint64(minor&0xffffff00)<<12
+}
+
+func TestSplitDev(t *testing.T) {
+	for _, n := range [][2]uint32{{261, 2}, {511, 300}, {4095, 0xfffff}, {0x12345, 0x6789a}, {0xffffffff, 0xffffffff}} {
+		major, minor := splitDev(makeDev(n[0], n[1]))
+		assert.Equal(t, n, [2]uint32{major, minor})
+	}
+	// 0x12345:0x6789a as encoded by glibc
+	major, minor := splitDev(0x120006783459a)
+	assert.Equal(t, [2]uint32{0x12345, 0x6789a}, [2]uint32{major, minor})
+}
+
+// useDevRoot points the package at a fake /dev for the duration of the test
+func useDevRoot(t *testing.T, root string) {
+	saved := devRoot.override.Load()
+	t.Cleanup(func() { devRoot.override.Store(saved) })
+	SetDevRoot(root)
+}
+
+func TestDeviceNodes(t *testing.T) {
+	dev := t.TempDir()
+	assert.Nil(t, os.MkdirAll(filepath.Join(dev, "accel"), 0o755))
+	useDevRoot(t, dev)
+
+	sys := t.TempDir()
+	for name, number := range map[string]string{"accel2": "261:2", "accel_controlD2": "510:2"} {
+		dir := filepath.Join(sys, "class", "accel", name)
+		assert.Nil(t, os.MkdirAll(dir, 0o755))
+		assert.Nil(t, os.WriteFile(filepath.Join(dir, "dev"), []byte(number+"\n"), 0o644))
+	}
+	useSysfsRoot(t, sys)
+
+	_, err := deviceNodes(2)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	mknod(t, filepath.Join(dev, "accel", "accel2"), 261, 2)
+	mknod(t, filepath.Join(dev, "accel", "accel_controlD2"), 510, 2)
+
+	nodes, err := deviceNodes(2)
+	assert.Nil(t, err, err)
+	if assert.Len(t, nodes, 2) {
+		assert.Equal(t, filepath.Join(dev, "accel", "accel2"), nodes[0].Path)
+		assert.Equal(t, [2]uint32{261, 2}, [2]uint32{nodes[0].Major, nodes[0].Minor})
+		assert.Equal(t, [2]uint32{510, 2}, [2]uint32{nodes[1].Major, nodes[1].Minor})
+		assert.Equal(t, os.FileMode(0o660), nodes[0].Mode.Perm())
+		assert.Equal(t, uint32(os.Getuid()), nodes[0].UID)
+	}
+
+	// device numbers that do not fit in 8 bits
+	mknod(t, filepath.Join(dev, "accel", "accel300"), 511, 300)
+	mknod(t, filepath.Join(dev, "accel", "accel_controlD300"), 4095, 0x12345)
+	for name, number := range map[string]string{"accel300": "511:300", "accel_controlD300": "4095:74565"} {
+		dir := filepath.Join(sys, "class", "accel", name)
+		assert.Nil(t, os.MkdirAll(dir, 0o755))
+		assert.Nil(t, os.WriteFile(filepath.Join(dir, "dev"), []byte(number+"\n"), 0o644))
+	}
+	nodes, err = deviceNodes(300)
+	assert.Nil(t, err, err)
+	if assert.Len(t, nodes, 2) {
+		assert.Equal(t, [2]uint32{511, 300}, [2]uint32{nodes[0].Major, nodes[0].Minor})
+		assert.Equal(t, [2]uint32{4095, 0x12345}, [2]uint32{nodes[1].Major, nodes[1].Minor})
+	}
+
+	// sysfs disagrees with the node
+	assert.Nil(t, os.WriteFile(filepath.Join(sys, "class", "accel", "accel_controlD2", "dev"), []byte("510:3\n"), 0o644))
+	_, err = deviceNodes(2)
+	assert.ErrorIs(t, err, ErrDeviceNodeMismatch)
+
+	// a regular file in place of the node
+	assert.Nil(t, os.WriteFile(filepath.Join(dev, "accel", "accel3"), nil, 0o666))
+	_, err = deviceNodes(3)
+	assert.ErrorIs(t, err, ErrDeviceNodeMismatch)
+}
+
+func TestExplainAccess(t *testing.T) {
+	n := DeviceNode{Path: "/dev/accel/accel0", UID: 0, GID: 44, Group: "render", Mode: os.ModeCharDevice | 0o660}
+
+	assert.Equal(t, "/dev/accel/accel0 is owned by 0:render with mode 0660, uid 1000 is not in group render and others lack read and write permission",
+		explainAccess(n, 1000, []int{1000}))
+
+	n.Mode = os.ModeCharDevice | 0o600
+	assert.Equal(t, "/dev/accel/accel0 is owned by 0:render with mode 0600, the group lacks read and write permission",
+		explainAccess(n, 1000, []int{1000, 44}))
+
+	n.UID, n.Mode = 1000, os.ModeCharDevice|0o060
+	assert.Equal(t, "/dev/accel/accel0 is owned by 1000:render with mode 0060, the owner lacks read and write permission",
+		explainAccess(n, 1000, []int{44}))
+}
+
+func TestAuditDeviceNodes(t *testing.T) {
+	dir := t.TempDir()
+	open := filepath.Join(dir, "accel0")
+	missing := filepath.Join(dir, "accel1")
+	assert.Nil(t, os.WriteFile(open, nil, 0o666))
+
+	problems := AuditDeviceNodes([]DeviceNode{{Path: open}, {Path: missing}})
+	if assert.Len(t, problems, 1) {
+		assert.Equal(t, missing, problems[0].Node.Path)
+		assert.ErrorIs(t, problems[0].Err, os.ErrNotExist)
+	}
+}
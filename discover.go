@@ -0,0 +1,158 @@
+/*
+ * Copyright (c) 2022, HabanaLabs Ltd.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the Lic
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gohlml
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// HabanaVendorID is the PCI vendor id of Habana Labs
+const HabanaVendorID = 0x1da3
+
+// PCIDevice is a Habana PCI function found in sysfs
+type PCIDevice struct {
+	// Address is the PCI address, e.g. 0000:19:00.0
+	Address  string
+	VendorID uint16
+	DeviceID uint16
+	// Family is e.g. gaudi, empty for an unknown device id
+	Family string
+	// Model is nil for a device id missing from the catalog
+	Model *DeviceModel
+	// NumaNode is nil if the device has no NUMA affinity
+	NumaNode *uint
+	// Driver is the bound driver, empty if the function is unbound
+	Driver string
+	// Accel is the index N of the accel device accelN, nil if the driver
+	// has not created one
+	Accel *uint
+}
+
+// Discover lists every Habana PCI function in sysfs, ordered by address.
+// It reads sysfs only and works without libhlml or a bound driver.
+// Functions whose attributes cannot be read are skipped
+func Discover() ([]PCIDevice, error) {
+	base := sysfsPath("bus", "pci", "devices")
+	entries, err := os.ReadDir(base)
+	if err != nil {
+		return nil, err
+	}
+
+	var devices []PCIDevice
+	for _, e := range entries {
+		dir := filepath.Join(base, e.Name())
+		vendor, err := readHexAttr(dir, "vendor")
+		if err != nil || vendor != HabanaVendorID {
+			continue
+		}
+		device, err := readHexAttr(dir, "device")
+		if err != nil {
+			logger().Debug("skipping pci device", "address", e.Name(), "err", err)
+			continue
+		}
+
+		d := PCIDevice{
+			Address:  e.Name(),
+			VendorID: vendor,
+			DeviceID: device,
+			NumaNode: readNumaNode(dir),
+			Accel:    accelIndex(dir),
+		}
+		if m, ok := LookupDeviceModel(device); ok {
+			d.Family, d.Model = m.Family, &m
+		}
+		if target, err := os.Readlink(filepath.Join(dir, "driver")); err == nil {
+			d.Driver = filepath.Base(target)
+		}
+		devices = append(devices, d)
+	}
+	sort.Slice(devices, func(i, j int) bool { return devices[i].Address < devices[j].Address })
+	return devices, nil
+}
+
+// readHexAttr reads a 0x prefixed 16 bit id attribute
+func readHexAttr(dir, name string) (uint16, error) {
+	b, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		return 0, err
+	}
+	v, err := strconv.ParseUint(strings.TrimPrefix(strings.TrimSpace(string(b)), "0x"), 16, 16)
+	return uint16(v), err
+}
+
+// readNumaNode returns the numa_node attribute, nil if it is missing or
+// negative
+func readNumaNode(dir string) *uint {
+	v, ok, err := readAttr(dir, "numa_node")
+	if err != nil || !ok {
+		return nil
+	}
+	node, err := strconv.ParseInt(v, 10, 32)
+	if err != nil || node < 0 {
+		return nil
+	}
+	n := uint(node)
+	return &n
+}
+
+// accelIndex returns N for the accelN device of the PCI device in dir. The
+// driver creates it under dir/accel, older sysfs layouts only link to dir
+// from class/accel
+func accelIndex(dir string) *uint {
+	parse := func(name string) *uint {
+		if !accelDevice.MatchString(name) {
+			return nil
+		}
+		n, err := strconv.ParseUint(strings.TrimPrefix(name, "accel"), 10, 32)
+		if err != nil {
+			return nil
+		}
+		idx := uint(n)
+		return &idx
+	}
+
+	if entries, err := os.ReadDir(filepath.Join(dir, "accel")); err == nil {
+		for _, e := range entries {
+			if idx := parse(e.Name()); idx != nil {
+				return idx
+			}
+		}
+	}
+
+	real, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return nil
+	}
+	class := sysfsPath("class", "accel")
+	entries, err := os.ReadDir(class)
+	if err != nil {
+		return nil
+	}
+	for _, e := range entries {
+		target, err := filepath.EvalSymlinks(filepath.Join(class, e.Name(), "device"))
+		if err == nil && target == real {
+			if idx := parse(e.Name()); idx != nil {
+				return idx
+			}
+		}
+	}
+	return nil
+}
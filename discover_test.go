@@ -0,0 +1,70 @@
+/*
+ * Copyright (c) 2022, HabanaLabs Ltd.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the Lic
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gohlml
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiscover(t *testing.T) {
+	root := t.TempDir()
+	devices := filepath.Join(root, "bus", "pci", "devices")
+	assert.Nil(t, os.MkdirAll(devices, 0o755))
+	assert.Nil(t, os.MkdirAll(filepath.Join(root, "bus", "pci", "drivers", "habanalabs"), 0o755))
+
+	for addr, attrs := range map[string]map[string]string{
+		"0000:00:01.0": {"vendor": "0x8086", "device": "0x1234"},
+		"0000:4d:00.0": {"vendor": "0x1da3", "device": "0x1020", "numa_node": "1"},
+		"0000:33:00.0": {"vendor": "0x1da3", "device": "0x1000", "numa_node": "-1"},
+		"0000:9a:00.0": {"vendor": "0x1da3", "device": "0xbeef"},
+	} {
+		dir := filepath.Join(root, "devices", "pci0000:00", addr)
+		assert.Nil(t, os.MkdirAll(dir, 0o755))
+		for name, v := range attrs {
+			assert.Nil(t, os.WriteFile(filepath.Join(dir, name), []byte(v+"\n"), 0o644))
+		}
+		assert.Nil(t, os.Symlink(dir, filepath.Join(devices, addr)))
+	}
+
+	// a bound device with its accel device created under it
+	gaudi2 := filepath.Join(root, "devices", "pci0000:00", "0000:4d:00.0")
+	assert.Nil(t, os.Symlink(filepath.Join(root, "bus", "pci", "drivers", "habanalabs"), filepath.Join(gaudi2, "driver")))
+	assert.Nil(t, os.MkdirAll(filepath.Join(gaudi2, "accel", "accel3"), 0o755))
+	// an accel device only linked from its class
+	gaudi := filepath.Join(root, "devices", "pci0000:00", "0000:33:00.0")
+	assert.Nil(t, os.MkdirAll(filepath.Join(root, "class", "accel", "accel1"), 0o755))
+	assert.Nil(t, os.Symlink(gaudi, filepath.Join(root, "class", "accel", "accel1", "device")))
+
+	useSysfsRoot(t, root)
+
+	u := func(v uint) *uint { return &v }
+	model := func(id uint16) *DeviceModel {
+		m, _ := LookupDeviceModel(id)
+		return &m
+	}
+	found, err := Discover()
+	assert.Nil(t, err)
+	assert.Equal(t, []PCIDevice{
+		{Address: "0000:33:00.0", VendorID: 0x1da3, DeviceID: 0x1000, Family: "gaudi", Model: model(0x1000), Accel: u(1)},
+		{Address: "0000:4d:00.0", VendorID: 0x1da3, DeviceID: 0x1020, Family: "gaudi", Model: model(0x1020), NumaNode: u(1), Driver: "habanalabs", Accel: u(3)},
+		{Address: "0000:9a:00.0", VendorID: 0x1da3, DeviceID: 0xbeef},
+	}, found)
+}
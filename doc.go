@@ -0,0 +1,25 @@
+/*
+ * Copyright (c) 2022, HabanaLabs Ltd.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the Lic
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package gohlml is a Go package serves as a bridge to work
+// with hlml C library. It allows access to native Habana device
+// commands and information.
+//
+// The bindings require cgo and libhlml on Linux. When built with
+// CGO_ENABLED=0 or for another OS, the package compiles against a stub
+// whose HLML calls return ErrLibraryUnavailable, while the sysfs helpers
+// keep working.
+package gohlml
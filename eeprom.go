@@ -0,0 +1,258 @@
+/*
+ * Copyright (c) 2022, HabanaLabs Ltd.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the Lic
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gohlml
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// EEPROM holds the fields decoded from the eeprom attribute of a device.
+//
+// The driver does not document the layout of the blob. The parser assumes
+// the IPMI Platform Management FRU Information Storage format, version 1:
+// an 8 byte common header pointing at a board info area and a product info
+// area, each made of type/length encoded fields and a zero-sum checksum.
+// A blob in any other layout fails with ErrInvalidEEPROM
+type EEPROM struct {
+	// ManufacturingDate is the board manufacturing time, zero if unset
+	ManufacturingDate time.Time
+
+	BoardManufacturer string
+	BoardProductName  string
+	BoardSerial       string
+	BoardPartNumber   string
+	BoardFRUFileID    string
+	BoardCustom       []string
+
+	ProductManufacturer string
+	ProductName         string
+	ProductPartNumber   string
+	// ProductVersion is taken to be the PCB assembly revision of the board
+	ProductVersion string
+	ProductSerial  string
+	AssetTag       string
+	ProductCustom  []string
+}
+
+// fruEpoch is the origin of the FRU manufacturing date, in minutes
+var fruEpoch = time.Date(1996, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// ReadEEPROM reads and decodes the eeprom attribute of the device with the
+// given minor number
+func ReadEEPROM(minor uint) (EEPROM, error) {
+	dir, err := accelDir(minor)
+	if err != nil {
+		return EEPROM{}, err
+	}
+	b, err := os.ReadFile(filepath.Join(dir, "device", "eeprom"))
+	if err != nil {
+		return EEPROM{}, err
+	}
+	return ParseEEPROM(b)
+}
+
+// ParseEEPROM decodes an eeprom blob. Trailing padding is ignored
+func ParseEEPROM(b []byte) (EEPROM, error) {
+	var e EEPROM
+
+	if len(b) < 8 {
+		return e, fmt.Errorf("%w: truncated common header", ErrInvalidEEPROM)
+	}
+	header := b[:8]
+	if header[0] != 0x01 {
+		return e, fmt.Errorf("%w: unknown format version %#x", ErrInvalidEEPROM, header[0])
+	}
+	if checksum(header) != 0 {
+		return e, fmt.Errorf("%w: common header checksum", ErrInvalidEEPROM)
+	}
+
+	if off := int(header[3]) * 8; off != 0 {
+		area, err := fruArea(b, off, "board")
+		if err != nil {
+			return e, err
+		}
+		// version, length, language, then 3 bytes of manufacturing date
+		if len(area) < 6 {
+			return e, fmt.Errorf("%w: truncated board area", ErrInvalidEEPROM)
+		}
+		minutes := int(area[3]) | int(area[4])<<8 | int(area[5])<<16
+		if minutes != 0 {
+			e.ManufacturingDate = fruEpoch.Add(time.Duration(minutes) * time.Minute)
+		}
+		fields, err := fruFields(area[6:], "board")
+		if err != nil {
+			return e, err
+		}
+		fixed := []*string{&e.BoardManufacturer, &e.BoardProductName, &e.BoardSerial, &e.BoardPartNumber, &e.BoardFRUFileID}
+		e.BoardCustom = assignFields(fields, fixed)
+	}
+
+	if off := int(header[4]) * 8; off != 0 {
+		area, err := fruArea(b, off, "product")
+		if err != nil {
+			return e, err
+		}
+		// version, length, language
+		fields, err := fruFields(area[3:], "product")
+		if err != nil {
+			return e, err
+		}
+		fixed := []*string{&e.ProductManufacturer, &e.ProductName, &e.ProductPartNumber, &e.ProductVersion, &e.ProductSerial, &e.AssetTag}
+		// the FRU file ID follows the asset tag, it is not kept
+		var fileID string
+		e.ProductCustom = assignFields(fields, append(fixed, &fileID))
+	}
+	return e, nil
+}
+
+func checksum(b []byte) byte {
+	var sum byte
+	for _, c := range b {
+		sum += c
+	}
+	return sum
+}
+
+// fruArea returns the info area at off, after checking its bounds and
+// checksum. The second byte of an area is its length in multiples of 8
+func fruArea(b []byte, off int, name string) ([]byte, error) {
+	if off+2 > len(b) {
+		return nil, fmt.Errorf("%w: truncated %s area", ErrInvalidEEPROM, name)
+	}
+	if b[off] != 0x01 {
+		return nil, fmt.Errorf("%w: unknown %s area version %#x", ErrInvalidEEPROM, name, b[off])
+	}
+	end := off + int(b[off+1])*8
+	if end <= off+2 || end > len(b) {
+		return nil, fmt.Errorf("%w: truncated %s area", ErrInvalidEEPROM, name)
+	}
+	area := b[off:end]
+	if checksum(area) != 0 {
+		return nil, fmt.Errorf("%w: %s area checksum", ErrInvalidEEPROM, name)
+	}
+	return area, nil
+}
+
+// fruFields decodes type/length encoded fields up to the 0xc1 end marker
+func fruFields(b []byte, name string) ([]string, error) {
+	var fields []string
+	for i := 0; ; {
+		if i >= len(b) {
+			return nil, fmt.Errorf("%w: %s area is missing its end marker", ErrInvalidEEPROM, name)
+		}
+		tl := b[i]
+		if tl == 0xc1 {
+			return fields, nil
+		}
+		n := int(tl & 0x3f)
+		if i+1+n > len(b) {
+			return nil, fmt.Errorf("%w: %s field %d overruns its area", ErrInvalidEEPROM, name, len(fields))
+		}
+		fields = append(fields, decodeFRUField(tl>>6, b[i+1:i+1+n]))
+		i += 1 + n
+	}
+}
+
+// decodeFRUField decodes a field of the given type code: binary, BCD plus,
+// 6-bit packed ASCII or 8-bit ASCII
+func decodeFRUField(typ byte, b []byte) string {
+	switch typ {
+	case 0:
+		return hex.EncodeToString(b)
+	case 1:
+		const digits = "0123456789 -.???"
+		var s strings.Builder
+		for _, c := range b {
+			s.WriteByte(digits[c>>4])
+			s.WriteByte(digits[c&0xf])
+		}
+		return strings.TrimSpace(s.String())
+	case 2:
+		var s strings.Builder
+		for i := 0; i+2 < len(b); i += 3 {
+			v := uint32(b[i]) | uint32(b[i+1])<<8 | uint32(b[i+2])<<16
+			for j := 0; j < 4; j++ {
+				s.WriteByte(byte(0x20 + (v>>(6*j))&0x3f))
+			}
+		}
+		return strings.TrimSpace(s.String())
+	default:
+		return strings.TrimRight(string(b), " \x00")
+	}
+}
+
+// assignFields stores the leading fields in fixed and returns the rest
+func assignFields(fields []string, fixed []*string) []string {
+	for i, p := range fixed {
+		if i == len(fields) {
+			return nil
+		}
+		*p = fields[i]
+	}
+	if len(fields) == len(fixed) {
+		return nil
+	}
+	return fields[len(fixed):]
+}
+
+// EEPROMMismatch is a field of the EEPROM that disagrees with HLML
+type EEPROMMismatch struct {
+	Field  string
+	EEPROM string
+	HLML   string
+}
+
+func (m EEPROMMismatch) Error() string {
+	return fmt.Sprintf("eeprom %s %q does not match hlml %q", m.Field, m.EEPROM, m.HLML)
+}
+
+// CrossCheck compares the board serial and product version of the EEPROM
+// with the SerialNumber and PCBAssemblyVersion HLML reports for dev. Fields
+// that are empty in the EEPROM or unsupported by the device are skipped
+func (e EEPROM) CrossCheck(ctx context.Context, dev DeviceInterface) ([]EEPROMMismatch, error) {
+	var mismatches []EEPROMMismatch
+	for _, c := range []struct {
+		field  string
+		eeprom string
+		get    func(context.Context) (string, error)
+	}{
+		{"serial", e.BoardSerial, dev.SerialNumberContext},
+		{"assembly version", e.ProductVersion, dev.PCBAssemblyVersionContext},
+	} {
+		if c.eeprom == "" {
+			continue
+		}
+		v, err := c.get(ctx)
+		if errors.Is(err, ErrNotSupported) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !strings.EqualFold(strings.TrimSpace(v), c.eeprom) {
+			mismatches = append(mismatches, EEPROMMismatch{Field: c.field, EEPROM: c.eeprom, HLML: v})
+		}
+	}
+	return mismatches, nil
+}
@@ -0,0 +1,50 @@
+/*
+ * Copyright (c) 2022, HabanaLabs Ltd.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the Lic
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package gohlml
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadEEPROM(t *testing.T) {
+	root := t.TempDir()
+	useSysfsRoot(t, root)
+
+	for i, name := range []string{"hl225.bin", "hl225-truncated.bin", "hl225-corrupt.bin"} {
+		blob, err := os.ReadFile("testdata/eeprom/" + name)
+		assert.Nil(t, err, err)
+		writeAccel(t, root, fmt.Sprintf("accel%d", i), fmt.Sprintf("261:%d", i), map[string]string{"eeprom": string(blob)})
+	}
+	writeAccel(t, root, "accel3", "261:3", nil)
+
+	e, err := ReadEEPROM(0)
+	assert.Nil(t, err, err)
+	assert.Equal(t, "AM24900001", e.BoardSerial)
+	assert.Equal(t, "HL2080A0-01", e.ProductPartNumber)
+	assert.Equal(t, "V1.1", e.ProductVersion)
+
+	_, err = ReadEEPROM(1)
+	assert.ErrorIs(t, err, ErrInvalidEEPROM)
+	_, err = ReadEEPROM(2)
+	assert.ErrorIs(t, err, ErrInvalidEEPROM)
+	_, err = ReadEEPROM(3)
+	assert.True(t, errors.Is(err, os.ErrNotExist), err)
+}
@@ -0,0 +1,109 @@
+/*
+ * Copyright (c) 2022, HabanaLabs Ltd.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the Lic
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package gohlml_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/HabanaAI/gohlml"
+	"github.com/HabanaAI/gohlml/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func readEEPROMFixture(t *testing.T, name string) []byte {
+	t.Helper()
+
+	b, err := os.ReadFile("testdata/eeprom/" + name)
+	assert.Nil(t, err, err)
+	return b
+}
+
+func TestParseEEPROM(t *testing.T) {
+	e, err := gohlml.ParseEEPROM(readEEPROMFixture(t, "hl225.bin"))
+	assert.Nil(t, err, err)
+	assert.Equal(t, gohlml.EEPROM{
+		ManufacturingDate:   time.Date(2023, 3, 16, 8, 0, 0, 0, time.UTC),
+		BoardManufacturer:   "Habana Labs",
+		BoardProductName:    "HL-225",
+		BoardSerial:         "AM24900001",
+		BoardPartNumber:     "HL2080A0",
+		BoardCustom:         []string{"123-45"},
+		ProductManufacturer: "Habana Labs",
+		ProductName:         "HL-225",
+		ProductPartNumber:   "HL2080A0-01",
+		ProductVersion:      "V1.1",
+		ProductSerial:       "AM24900001",
+	}, e)
+}
+
+func TestParseEEPROMInvalid(t *testing.T) {
+	blob := readEEPROMFixture(t, "hl225.bin")
+	corrupt := func(off int) []byte {
+		b := append([]byte(nil), blob...)
+		b[off] ^= 0x5a
+		return b
+	}
+
+	tests := []struct {
+		name string
+		blob []byte
+	}{
+		{name: "empty", blob: nil},
+		{name: "truncated header", blob: blob[:5]},
+		{name: "truncated board area", blob: blob[:40]},
+		{name: "truncated product area", blob: blob[:70]},
+		{name: "truncated fixture", blob: readEEPROMFixture(t, "hl225-truncated.bin")},
+		{name: "corrupt fixture", blob: readEEPROMFixture(t, "hl225-corrupt.bin")},
+		{name: "erased", blob: make([]byte, 256)},
+		{name: "corrupt header", blob: corrupt(3)},
+		{name: "corrupt board field", blob: corrupt(20)},
+		{name: "corrupt product field", blob: corrupt(80)},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := gohlml.ParseEEPROM(tc.blob)
+			assert.ErrorIs(t, err, gohlml.ErrInvalidEEPROM)
+		})
+	}
+}
+
+func TestEEPROMCrossCheck(t *testing.T) {
+	e, err := gohlml.ParseEEPROM(readEEPROMFixture(t, "hl225.bin"))
+	assert.Nil(t, err, err)
+
+	dev := &mock.Device{
+		SerialNumberContextFunc:       func(context.Context) (string, error) { return "AM24900001", nil },
+		PCBAssemblyVersionContextFunc: func(context.Context) (string, error) { return "v1.1", nil },
+	}
+	mismatches, err := e.CrossCheck(context.Background(), dev)
+	assert.Nil(t, err, err)
+	assert.Empty(t, mismatches)
+
+	dev.SerialNumberContextFunc = func(context.Context) (string, error) { return "AM24900002", nil }
+	dev.PCBAssemblyVersionContextFunc = func(context.Context) (string, error) {
+		return "", gohlml.ErrNotSupported
+	}
+	mismatches, err = e.CrossCheck(context.Background(), dev)
+	assert.Nil(t, err, err)
+	assert.Equal(t, []gohlml.EEPROMMismatch{{Field: "serial", EEPROM: "AM24900001", HLML: "AM24900002"}}, mismatches)
+
+	dev.SerialNumberContextFunc = func(context.Context) (string, error) { return "", gohlml.ErrAipIsLost }
+	_, err = e.CrossCheck(context.Background(), dev)
+	assert.ErrorIs(t, err, gohlml.ErrAipIsLost)
+}
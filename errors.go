@@ -0,0 +1,159 @@
+/*
+ * Copyright (c) 2022, HabanaLabs Ltd.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the Lic
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gohlml
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrNotIntialized      = errors.New("hlml not initialized")
+	ErrInvalidArgument    = errors.New("invalid argument")
+	ErrNotSupported       = errors.New("not supported")
+	ErrAlreadyInitialized = errors.New("hlml already initialized")
+	ErrNotFound           = errors.New("not found")
+	ErrInsufficientSize   = errors.New("insufficient size")
+	ErrDriverNotLoaded    = errors.New("driver not loaded")
+	ErrTimeout            = errors.New("timeout")
+	ErrAipIsLost          = errors.New("aip is lost")
+	ErrMemoryError        = errors.New("memory error")
+	ErrNoData             = errors.New("no data")
+	ErrUnknownError       = errors.New("unknown error")
+	// ErrLibraryUnavailable is returned by every HLML call when the package
+	// was built without cgo or for a platform libhlml does not support
+	ErrLibraryUnavailable = errors.New("hlml library unavailable in this build")
+	// ErrInvalidEEPROM is returned for an eeprom blob that is truncated,
+	// corrupt or not in the FRU format ParseEEPROM decodes
+	ErrInvalidEEPROM = errors.New("invalid eeprom")
+	// ErrDeviceNodeMismatch is returned by DeviceNodes for a node that is
+	// not a character device or whose device numbers do not match the device
+	ErrDeviceNodeMismatch = errors.New("device node mismatch")
+)
+
+// Return is an hlml_return_t status code
+type Return int
+
+// Status codes of hlml_return_t as defined in hlml.h
+const (
+	ReturnSuccess            Return = 0
+	ReturnUninitialized      Return = 1
+	ReturnInvalidArgument    Return = 2
+	ReturnNotSupported       Return = 3
+	ReturnAlreadyInitialized Return = 5
+	ReturnNotFound           Return = 6
+	ReturnInsufficientSize   Return = 7
+	ReturnDriverNotLoaded    Return = 9
+	ReturnTimeout            Return = 10
+	ReturnAipIsLost          Return = 15
+	ReturnMemory             Return = 20
+	ReturnNoData             Return = 21
+	ReturnUnknown            Return = 49
+)
+
+var returnNames = map[Return]string{
+	ReturnSuccess:            "HLML_SUCCESS",
+	ReturnUninitialized:      "HLML_ERROR_UNINITIALIZED",
+	ReturnInvalidArgument:    "HLML_ERROR_INVALID_ARGUMENT",
+	ReturnNotSupported:       "HLML_ERROR_NOT_SUPPORTED",
+	ReturnAlreadyInitialized: "HLML_ERROR_ALREADY_INITIALIZED",
+	ReturnNotFound:           "HLML_ERROR_NOT_FOUND",
+	ReturnInsufficientSize:   "HLML_ERROR_INSUFFICIENT_SIZE",
+	ReturnDriverNotLoaded:    "HLML_ERROR_DRIVER_NOT_LOADED",
+	ReturnTimeout:            "HLML_ERROR_TIMEOUT",
+	ReturnAipIsLost:          "HLML_ERROR_AIP_IS_LOST",
+	ReturnMemory:             "HLML_ERROR_MEMORY",
+	ReturnNoData:             "HLML_ERROR_NO_DATA",
+	ReturnUnknown:            "HLML_ERROR_UNKNOWN",
+}
+
+// String returns the hlml.h name of the code
+func (r Return) String() string {
+	if name, ok := returnNames[r]; ok {
+		return name
+	}
+	return fmt.Sprintf("hlml_return_t(%d)", int(r))
+}
+
+// sentinel maps the code to the matching package error, or nil for
+// HLML_SUCCESS and codes unknown to this package
+func (r Return) sentinel() error {
+	switch r {
+	case ReturnUninitialized:
+		return ErrNotIntialized
+	case ReturnInvalidArgument:
+		return ErrInvalidArgument
+	case ReturnNotSupported:
+		return ErrNotSupported
+	case ReturnAlreadyInitialized:
+		return ErrAlreadyInitialized
+	case ReturnNotFound:
+		return ErrNotFound
+	case ReturnInsufficientSize:
+		return ErrInsufficientSize
+	case ReturnDriverNotLoaded:
+		return ErrDriverNotLoaded
+	case ReturnTimeout:
+		return ErrTimeout
+	case ReturnAipIsLost:
+		return ErrAipIsLost
+	case ReturnMemory:
+		return ErrMemoryError
+	case ReturnNoData:
+		return ErrNoData
+	case ReturnUnknown:
+		return ErrUnknownError
+	}
+	return nil
+}
+
+// Error is returned by the HLML bindings when a call fails. It unwraps to
+// the sentinel error of its code, so errors.Is(err, ErrAipIsLost) keeps
+// working
+type Error struct {
+	// Code is the raw hlml_return_t of the call
+	Code Return
+	// Op is the HLML function that failed, e.g. "hlml_device_get_power_usage"
+	Op string
+	// Device is the PCI bus id of the device the call was issued for, or
+	// empty for library level calls and unresolvable devices
+	Device string
+}
+
+func (e *Error) Error() string {
+	msg := fmt.Sprintf("invalid HLML error return code %d", int(e.Code))
+	if err := e.Code.sentinel(); err != nil {
+		msg = err.Error()
+	}
+	if e.Device != "" {
+		return fmt.Sprintf("%s on %s: %s", e.Op, e.Device, msg)
+	}
+	return fmt.Sprintf("%s: %s", e.Op, msg)
+}
+
+// Unwrap returns the sentinel error matching Code
+func (e *Error) Unwrap() error {
+	return e.Code.sentinel()
+}
+
+// newError returns nil for HLML_SUCCESS and an *Error otherwise
+func newError(code Return, op, device string) error {
+	if code == ReturnSuccess {
+		return nil
+	}
+	return &Error{Code: code, Op: op, Device: device}
+}
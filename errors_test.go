@@ -0,0 +1,67 @@
+/*
+ * Copyright (c) 2022, HabanaLabs Ltd.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the Lic
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package gohlml
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorIs(t *testing.T) {
+	tests := []struct {
+		code     Return
+		sentinel error
+	}{
+		{code: ReturnUninitialized, sentinel: ErrNotIntialized},
+		{code: ReturnInvalidArgument, sentinel: ErrInvalidArgument},
+		{code: ReturnNotSupported, sentinel: ErrNotSupported},
+		{code: ReturnAlreadyInitialized, sentinel: ErrAlreadyInitialized},
+		{code: ReturnNotFound, sentinel: ErrNotFound},
+		{code: ReturnInsufficientSize, sentinel: ErrInsufficientSize},
+		{code: ReturnDriverNotLoaded, sentinel: ErrDriverNotLoaded},
+		{code: ReturnTimeout, sentinel: ErrTimeout},
+		{code: ReturnAipIsLost, sentinel: ErrAipIsLost},
+		{code: ReturnMemory, sentinel: ErrMemoryError},
+		{code: ReturnNoData, sentinel: ErrNoData},
+		{code: ReturnUnknown, sentinel: ErrUnknownError},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.code.String(), func(t *testing.T) {
+			err := newError(tc.code, "hlml_device_get_power_usage", "0000:19:00.0")
+			assert.ErrorIs(t, err, tc.sentinel)
+			assert.ErrorIs(t, fmt.Errorf("wrapped: %w", err), tc.sentinel)
+		})
+	}
+
+	assert.Nil(t, newError(ReturnSuccess, "hlml_init", ""))
+}
+
+func TestErrorMessage(t *testing.T) {
+	err := newError(ReturnAipIsLost, "hlml_device_get_power_usage", "0000:19:00.0")
+	assert.Equal(t, "hlml_device_get_power_usage on 0000:19:00.0: aip is lost", err.Error())
+
+	err = newError(ReturnDriverNotLoaded, "hlml_init", "")
+	assert.Equal(t, "hlml_init: driver not loaded", err.Error())
+
+	err = newError(Return(42), "hlml_init", "")
+	assert.Equal(t, "hlml_init: invalid HLML error return code 42", err.Error())
+	assert.Nil(t, errors.Unwrap(err), "Unknown codes have no sentinel")
+	assert.Equal(t, "hlml_return_t(42)", Return(42).String())
+}
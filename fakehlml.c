@@ -0,0 +1,666 @@
+//go:build linux && cgo && fakehlml
+
+/*
+ * Copyright (c) 2022, HabanaLabs Ltd.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the Lic
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+/*
+ * Fake libhlml used by the fakehlml build tag. Every API of hlml.h is
+ * implemented here and forwards to the Go side in fakehlml.go, which serves
+ * the device description named by HLML_FAKE_CONFIG. Device and event set
+ * handles encode the index of the device or set on the Go side.
+ */
+
+#include <stdint.h>
+#include <string.h>
+
+#include "hlml.h"
+#include "_cgo_export.h"
+
+/*
+ * Handles point into this array so that they are valid addresses that the
+ * Go runtime accepts on goroutine stacks.
+ */
+#define MAX_HANDLES	4096
+
+static char handles[MAX_HANDLES];
+
+#define HANDLE(idx)	((void *)&handles[(idx) % MAX_HANDLES])
+#define INDEX(handle)	((handle) ? (int)((char *)(handle) - handles) : -1)
+
+static hlml_return_t get_uint(hlml_device_t device, const char *op,
+			      const char *field, int arg, unsigned int *out)
+{
+	unsigned long long val;
+	hlml_return_t rc;
+
+	if (!out)
+		return HLML_ERROR_INVALID_ARGUMENT;
+
+	rc = fakeGetValue(INDEX(device), (char *)op, (char *)field, arg, &val);
+	if (rc == HLML_SUCCESS)
+		*out = (unsigned int)val;
+	return rc;
+}
+
+static hlml_return_t get_ull(hlml_device_t device, const char *op,
+			     const char *field, int arg, unsigned long long *out)
+{
+	if (!out)
+		return HLML_ERROR_INVALID_ARGUMENT;
+
+	return fakeGetValue(INDEX(device), (char *)op, (char *)field, arg, out);
+}
+
+static hlml_return_t get_string(hlml_device_t device, const char *op,
+				const char *field, char *buf, unsigned int length)
+{
+	return fakeGetString(INDEX(device), (char *)op, (char *)field, buf, length);
+}
+
+static hlml_return_t find_device(const char *key, const char *value,
+				 unsigned int index, hlml_device_t *device)
+{
+	hlml_return_t rc;
+	int idx;
+
+	if (!device)
+		return HLML_ERROR_INVALID_ARGUMENT;
+
+	rc = fakeFindDevice((char *)key, (char *)value, index, &idx);
+	if (rc == HLML_SUCCESS)
+		*device = HANDLE(idx);
+	return rc;
+}
+
+hlml_return_t hlml_init(void)
+{
+	return fakeInit();
+}
+
+hlml_return_t hlml_init_with_flags(unsigned int flags)
+{
+	(void)flags;
+	return fakeInit();
+}
+
+hlml_return_t hlml_shutdown(void)
+{
+	return fakeShutdown();
+}
+
+hlml_return_t hlml_device_get_count(unsigned int *device_count)
+{
+	if (!device_count)
+		return HLML_ERROR_INVALID_ARGUMENT;
+
+	return fakeDeviceCount(device_count);
+}
+
+hlml_return_t hlml_device_get_handle_by_pci_bus_id(const char *pci_addr, hlml_device_t *device)
+{
+	return find_device("bus_id", pci_addr, 0, device);
+}
+
+hlml_return_t hlml_device_get_handle_by_index(unsigned int index, hlml_device_t *device)
+{
+	return find_device("index", NULL, index, device);
+}
+
+hlml_return_t hlml_device_get_handle_by_UUID(const char *uuid, hlml_device_t *device)
+{
+	return find_device("uuid", uuid, 0, device);
+}
+
+hlml_return_t hlml_device_get_name(hlml_device_t device, char *name,
+				   unsigned int length)
+{
+	return get_string(device, __func__, "name", name, length);
+}
+
+hlml_return_t hlml_device_get_pci_info(hlml_device_t device,
+				       hlml_pci_info_t *pci)
+{
+	unsigned int domain, bus, dev, id;
+	hlml_return_t rc;
+
+	if (!pci)
+		return HLML_ERROR_INVALID_ARGUMENT;
+
+	memset(pci, 0, sizeof(*pci));
+
+	rc = get_uint(device, __func__, "domain", 0, &domain);
+	if (rc == HLML_SUCCESS)
+		rc = get_uint(device, __func__, "bus", 0, &bus);
+	if (rc == HLML_SUCCESS)
+		rc = get_uint(device, __func__, "device", 0, &dev);
+	if (rc == HLML_SUCCESS)
+		rc = get_uint(device, __func__, "pci_device_id", 0, &id);
+	if (rc == HLML_SUCCESS)
+		rc = get_string(device, __func__, "bus_id", pci->bus_id, PCI_ADDR_LEN);
+	if (rc == HLML_SUCCESS)
+		rc = get_string(device, __func__, "link_speed", pci->caps.link_speed, PCI_LINK_INFO_LEN);
+	if (rc == HLML_SUCCESS)
+		rc = get_string(device, __func__, "link_width", pci->caps.link_width, PCI_LINK_INFO_LEN);
+	if (rc != HLML_SUCCESS)
+		return rc;
+
+	pci->domain = domain;
+	pci->bus = bus;
+	pci->device = dev;
+	pci->pci_device_id = id;
+	return HLML_SUCCESS;
+}
+
+hlml_return_t hlml_device_get_clock_info(hlml_device_t device,
+					 hlml_clock_type_t type,
+					 unsigned int *clock)
+{
+	return get_uint(device, __func__, "clock", type, clock);
+}
+
+hlml_return_t hlml_device_get_max_clock_info(hlml_device_t device,
+					     hlml_clock_type_t type,
+					     unsigned int *clock)
+{
+	return get_uint(device, __func__, "max_clock", type, clock);
+}
+
+hlml_return_t hlml_device_get_utilization_rates(hlml_device_t device,
+					hlml_utilization_t *utilization)
+{
+	if (!utilization)
+		return HLML_ERROR_INVALID_ARGUMENT;
+
+	return get_uint(device, __func__, "utilization", 0, &utilization->aip);
+}
+
+hlml_return_t hlml_device_get_memory_info(hlml_device_t device,
+					  hlml_memory_t *memory)
+{
+	hlml_return_t rc;
+
+	if (!memory)
+		return HLML_ERROR_INVALID_ARGUMENT;
+
+	rc = get_ull(device, __func__, "memory_total", 0, &memory->total);
+	if (rc == HLML_SUCCESS)
+		rc = get_ull(device, __func__, "memory_used", 0, &memory->used);
+	if (rc == HLML_SUCCESS)
+		memory->free = memory->total - memory->used;
+	return rc;
+}
+
+hlml_return_t hlml_device_get_temperature(hlml_device_t device,
+					  hlml_temperature_sensors_t sensor_type,
+					  unsigned int *temp)
+{
+	return get_uint(device, __func__, "temperature", sensor_type, temp);
+}
+
+hlml_return_t hlml_device_get_temperature_threshold(hlml_device_t device,
+				hlml_temperature_thresholds_t threshold_type,
+				unsigned int *temp)
+{
+	return get_uint(device, __func__, "threshold", threshold_type, temp);
+}
+
+hlml_return_t hlml_device_get_persistence_mode(hlml_device_t device,
+						hlml_enable_state_t *mode)
+{
+	(void)device;
+	(void)mode;
+	return HLML_ERROR_NOT_SUPPORTED;
+}
+
+hlml_return_t hlml_device_get_performance_state(hlml_device_t device,
+						hlml_p_states_t *p_state)
+{
+	(void)device;
+	(void)p_state;
+	return HLML_ERROR_NOT_SUPPORTED;
+}
+
+hlml_return_t hlml_device_get_power_usage(hlml_device_t device,
+					  unsigned int *power)
+{
+	return get_uint(device, __func__, "power_usage", 0, power);
+}
+
+hlml_return_t hlml_device_get_power_management_default_limit(hlml_device_t device,
+						unsigned int *default_limit)
+{
+	return get_uint(device, __func__, "power_default_limit", 0, default_limit);
+}
+
+hlml_return_t hlml_device_get_ecc_mode(hlml_device_t device,
+				       hlml_enable_state_t *current,
+				       hlml_enable_state_t *pending)
+{
+	unsigned int cur, pend;
+	hlml_return_t rc;
+
+	if (!current || !pending)
+		return HLML_ERROR_INVALID_ARGUMENT;
+
+	rc = get_uint(device, __func__, "ecc_current", 0, &cur);
+	if (rc == HLML_SUCCESS)
+		rc = get_uint(device, __func__, "ecc_pending", 0, &pend);
+	if (rc != HLML_SUCCESS)
+		return rc;
+
+	*current = cur ? HLML_FEATURE_ENABLED : HLML_FEATURE_DISABLED;
+	*pending = pend ? HLML_FEATURE_ENABLED : HLML_FEATURE_DISABLED;
+	return HLML_SUCCESS;
+}
+
+hlml_return_t hlml_device_get_total_ecc_errors(hlml_device_t device,
+					hlml_memory_error_type_t error_type,
+					hlml_ecc_counter_type_t counter_type,
+					unsigned long long *ecc_counts)
+{
+	(void)counter_type;
+	if (error_type != HLML_MEMORY_ERROR_TYPE_UNCORRECTED)
+		return HLML_ERROR_NOT_SUPPORTED;
+
+	return get_ull(device, __func__, "ecc_errors", 0, ecc_counts);
+}
+
+hlml_return_t hlml_device_get_memory_error_counter(hlml_device_t device,
+					hlml_memory_error_type_t error_type,
+					hlml_ecc_counter_type_t counter_type,
+					hlml_memory_location_type_t location,
+					unsigned long long *ecc_counts)
+{
+	(void)counter_type;
+	(void)location;
+	if (error_type != HLML_MEMORY_ERROR_TYPE_UNCORRECTED)
+		return HLML_ERROR_NOT_SUPPORTED;
+
+	return get_ull(device, __func__, "ecc_errors", 0, ecc_counts);
+}
+
+hlml_return_t hlml_device_get_uuid(hlml_device_t device,
+				   char *uuid,
+				   unsigned int length)
+{
+	return get_string(device, __func__, "uuid", uuid, length);
+}
+
+hlml_return_t hlml_device_get_minor_number(hlml_device_t device,
+					   unsigned int *minor_number)
+{
+	return get_uint(device, __func__, "minor", 0, minor_number);
+}
+
+hlml_return_t hlml_device_register_events(hlml_device_t device,
+					  unsigned long long event_types,
+					  hlml_event_set_t set)
+{
+	return fakeRegisterEvents(INDEX(device), event_types, INDEX(set));
+}
+
+hlml_return_t hlml_event_set_create(hlml_event_set_t *set)
+{
+	hlml_return_t rc;
+	int id;
+
+	if (!set)
+		return HLML_ERROR_INVALID_ARGUMENT;
+
+	rc = fakeEventSetCreate(&id);
+	if (rc == HLML_SUCCESS)
+		*set = HANDLE(id);
+	return rc;
+}
+
+hlml_return_t hlml_event_set_free(hlml_event_set_t set)
+{
+	return fakeEventSetFree(INDEX(set));
+}
+
+hlml_return_t hlml_event_set_wait(hlml_event_set_t set,
+				  hlml_event_data_t *data,
+				  unsigned int timeoutms)
+{
+	unsigned long long type;
+	hlml_return_t rc;
+	int idx;
+
+	if (!data)
+		return HLML_ERROR_INVALID_ARGUMENT;
+
+	memset(data, 0, sizeof(*data));
+
+	rc = fakeEventSetWait(INDEX(set), timeoutms, &idx, &type);
+	if (rc == HLML_SUCCESS) {
+		data->device = HANDLE(idx);
+		data->event_type = type;
+	}
+	return rc;
+}
+
+hlml_return_t hlml_device_get_mac_info(hlml_device_t device,
+				       hlml_mac_info_t *mac_info,
+				       unsigned int mac_info_size,
+				       unsigned int start_mac_id,
+				       unsigned int *actual_mac_count)
+{
+	unsigned long long mask;
+	unsigned int i, count = 0;
+	hlml_return_t rc;
+	int minor;
+
+	if (!mac_info || !actual_mac_count)
+		return HLML_ERROR_INVALID_ARGUMENT;
+
+	rc = get_ull(device, __func__, "ports_mask", 0, &mask);
+	if (rc != HLML_SUCCESS)
+		return rc;
+
+	/* Locally administered addresses derived from the device and port */
+	minor = INDEX(device);
+	for (i = start_mac_id; i < 64 && count < mac_info_size; i++) {
+		if (!(mask & (1ULL << i)))
+			continue;
+
+		memset(&mac_info[count], 0, sizeof(mac_info[count]));
+		mac_info[count].addr[0] = 0x02;
+		mac_info[count].addr[4] = (unsigned char)minor;
+		mac_info[count].addr[5] = (unsigned char)i;
+		mac_info[count].id = (int)i;
+		count++;
+	}
+
+	*actual_mac_count = count;
+	return HLML_SUCCESS;
+}
+
+hlml_return_t hlml_device_err_inject(hlml_device_t device, hlml_err_inject_t err_type)
+{
+	return fakeInjectError(INDEX(device), err_type);
+}
+
+hlml_return_t hlml_device_get_hl_revision(hlml_device_t device, int *hl_revision)
+{
+	unsigned int rev;
+	hlml_return_t rc;
+
+	if (!hl_revision)
+		return HLML_ERROR_INVALID_ARGUMENT;
+
+	rc = get_uint(device, __func__, "hl_revision", 0, &rev);
+	if (rc == HLML_SUCCESS)
+		*hl_revision = (int)rev;
+	return rc;
+}
+
+hlml_return_t hlml_device_get_pcb_info(hlml_device_t device, hlml_pcb_info_t *pcb)
+{
+	hlml_return_t rc;
+
+	if (!pcb)
+		return HLML_ERROR_INVALID_ARGUMENT;
+
+	rc = get_string(device, __func__, "pcb_version", pcb->pcb_ver, HL_FIELD_MAX_SIZE);
+	if (rc == HLML_SUCCESS)
+		rc = get_string(device, __func__, "pcb_assembly_version",
+				pcb->pcb_assembly_ver, HL_FIELD_MAX_SIZE);
+	return rc;
+}
+
+hlml_return_t hlml_device_get_serial(hlml_device_t device, char *serial, unsigned int length)
+{
+	return get_string(device, __func__, "serial", serial, length);
+}
+
+hlml_return_t hlml_device_get_module_id(hlml_device_t device, unsigned int *module_id)
+{
+	return get_uint(device, __func__, "module_id", 0, module_id);
+}
+
+hlml_return_t hlml_device_get_board_id(hlml_device_t device, unsigned int *board_id)
+{
+	return get_uint(device, __func__, "board_id", 0, board_id);
+}
+
+hlml_return_t hlml_device_get_pcie_throughput(hlml_device_t device,
+					      hlml_pcie_util_counter_t counter,
+					      unsigned int *value)
+{
+	return get_uint(device, __func__, "pcie_throughput", counter, value);
+}
+
+hlml_return_t hlml_device_get_pcie_replay_counter(hlml_device_t device, unsigned int *value)
+{
+	return get_uint(device, __func__, "replay_count", 0, value);
+}
+
+hlml_return_t hlml_device_get_curr_pcie_link_generation(hlml_device_t device,
+							unsigned int *curr_link_gen)
+{
+	return get_uint(device, __func__, "link_generation", 0, curr_link_gen);
+}
+
+hlml_return_t hlml_device_get_curr_pcie_link_width(hlml_device_t device,
+						   unsigned int *curr_link_width)
+{
+	return get_uint(device, __func__, "current_link_width", 0, curr_link_width);
+}
+
+hlml_return_t hlml_device_get_current_clocks_throttle_reasons(hlml_device_t device,
+		unsigned long long *clocks_throttle_reasons)
+{
+	return get_ull(device, __func__, "throttle_reasons", 0, clocks_throttle_reasons);
+}
+
+hlml_return_t hlml_device_get_total_energy_consumption(hlml_device_t device,
+		unsigned long long *energy)
+{
+	return get_ull(device, __func__, "energy", 0, energy);
+}
+
+hlml_return_t hlml_get_mac_addr_info(hlml_device_t device, uint64_t *mask, uint64_t *ext_mask)
+{
+	unsigned long long m, ext;
+	hlml_return_t rc;
+
+	if (!mask || !ext_mask)
+		return HLML_ERROR_INVALID_ARGUMENT;
+
+	rc = get_ull(device, __func__, "ports_mask", 0, &m);
+	if (rc == HLML_SUCCESS)
+		rc = get_ull(device, __func__, "external_ports_mask", 0, &ext);
+	if (rc != HLML_SUCCESS)
+		return rc;
+
+	memset(mask, 0, sizeof(*mask) * PORTS_ARR_SIZE);
+	memset(ext_mask, 0, sizeof(*ext_mask) * PORTS_ARR_SIZE);
+	mask[0] = m;
+	ext_mask[0] = ext;
+	return HLML_SUCCESS;
+}
+
+hlml_return_t hlml_nic_get_link(hlml_device_t device, uint32_t port, bool *up)
+{
+	unsigned int val;
+	hlml_return_t rc;
+
+	if (!up)
+		return HLML_ERROR_INVALID_ARGUMENT;
+
+	rc = get_uint(device, __func__, "link_up", (int)port, &val);
+	if (rc == HLML_SUCCESS)
+		*up = val != 0;
+	return rc;
+}
+
+hlml_return_t hlml_nic_get_statistics(hlml_device_t device, hlml_nic_stats_info_t *stats_info)
+{
+	(void)device;
+	(void)stats_info;
+	return HLML_ERROR_NOT_SUPPORTED;
+}
+
+hlml_return_t hlml_device_clear_cpu_affinity(hlml_device_t device)
+{
+	unsigned int minor;
+
+	return get_uint(device, __func__, "minor", 0, &minor);
+}
+
+hlml_return_t hlml_device_get_cpu_affinity(hlml_device_t device,
+					   unsigned int cpu_set_size,
+					   unsigned long *cpu_set)
+{
+	(void)device;
+	(void)cpu_set_size;
+	(void)cpu_set;
+	return HLML_ERROR_NOT_SUPPORTED;
+}
+
+hlml_return_t hlml_device_get_cpu_affinity_within_scope(hlml_device_t device,
+							unsigned int cpu_set_size,
+							unsigned long *cpu_set,
+							hlml_affinity_scope_t scope)
+{
+	(void)device;
+	(void)cpu_set_size;
+	(void)cpu_set;
+	(void)scope;
+	return HLML_ERROR_NOT_SUPPORTED;
+}
+
+hlml_return_t hlml_device_get_memory_affinity(hlml_device_t device,
+					      unsigned int node_set_size,
+					      unsigned long *node_set,
+					      hlml_affinity_scope_t scope)
+{
+	(void)device;
+	(void)node_set_size;
+	(void)node_set;
+	(void)scope;
+	return HLML_ERROR_NOT_SUPPORTED;
+}
+
+hlml_return_t hlml_device_set_cpu_affinity(hlml_device_t device)
+{
+	unsigned int minor;
+
+	return get_uint(device, __func__, "minor", 0, &minor);
+}
+
+hlml_return_t hlml_device_get_violation_status(hlml_device_t device,
+					       hlml_perf_policy_type_t perf_policy_type,
+					       hlml_violation_time_t *viol_time)
+{
+	if (!viol_time)
+		return HLML_ERROR_INVALID_ARGUMENT;
+
+	viol_time->reference_time = 0;
+	return get_ull(device, __func__, "violation", perf_policy_type,
+		       &viol_time->violation_time);
+}
+
+hlml_return_t hlml_device_get_replaced_rows(hlml_device_t device,
+					    hlml_row_replacement_cause_t cause,
+					    unsigned int *row_count,
+					    hlml_row_address_t *addresses)
+{
+	unsigned int count;
+	hlml_return_t rc;
+
+	if (!row_count)
+		return HLML_ERROR_INVALID_ARGUMENT;
+
+	rc = get_uint(device, __func__, "replaced_rows", cause, &count);
+	if (rc != HLML_SUCCESS)
+		return rc;
+
+	if (addresses) {
+		if (*row_count < count)
+			return HLML_ERROR_INSUFFICIENT_SIZE;
+		memset(addresses, 0, sizeof(*addresses) * count);
+	}
+	*row_count = count;
+	return HLML_SUCCESS;
+}
+
+hlml_return_t hlml_device_get_replaced_rows_pending_status(hlml_device_t device,
+							   hlml_enable_state_t *is_pending)
+{
+	unsigned int pending;
+	hlml_return_t rc;
+
+	if (!is_pending)
+		return HLML_ERROR_INVALID_ARGUMENT;
+
+	rc = get_uint(device, __func__, "rows_pending", 0, &pending);
+	if (rc == HLML_SUCCESS)
+		*is_pending = pending ? HLML_FEATURE_ENABLED : HLML_FEATURE_DISABLED;
+	return rc;
+}
+
+hlml_return_t hlml_get_hlml_version(char *version, unsigned int length)
+{
+	return fakeGetString(-1, (char *)__func__, "", version, length);
+}
+
+hlml_return_t hlml_get_driver_version(char *driver_version, unsigned int length)
+{
+	return fakeGetString(-1, (char *)__func__, "", driver_version, length);
+}
+
+hlml_return_t hlml_get_model_number(hlml_device_t device, char *model_number,
+				    unsigned int length)
+{
+	return get_string(device, __func__, "model_number", model_number, length);
+}
+
+hlml_return_t hlml_get_serial_number(hlml_device_t device, char *serial_number,
+				     unsigned int length)
+{
+	return get_string(device, __func__, "serial", serial_number, length);
+}
+
+hlml_return_t hlml_get_firmware_fit_version(hlml_device_t device, char *firmware_fit,
+					    unsigned int length)
+{
+	return get_string(device, __func__, "fit", firmware_fit, length);
+}
+
+hlml_return_t hlml_get_firmware_spi_version(hlml_device_t device, char *firmware_spi,
+					    unsigned int length)
+{
+	return get_string(device, __func__, "spi", firmware_spi, length);
+}
+
+hlml_return_t hlml_get_fw_boot_version(hlml_device_t device, char *fw_boot_version,
+				       unsigned int length)
+{
+	return get_string(device, __func__, "boot", fw_boot_version, length);
+}
+
+hlml_return_t hlml_get_fw_os_version(hlml_device_t device, char *fw_os_version,
+				     unsigned int length)
+{
+	return get_string(device, __func__, "os", fw_os_version, length);
+}
+
+hlml_return_t hlml_get_cpld_version(hlml_device_t device, char *cpld_version,
+				    unsigned int length)
+{
+	return get_string(device, __func__, "cpld", cpld_version, length);
+}
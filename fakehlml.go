@@ -0,0 +1,541 @@
+//go:build linux && cgo && fakehlml
+
+/*
+ * Copyright (c) 2022, HabanaLabs Ltd.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the Lic
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gohlml
+
+// This file backs the fake libhlml in fakehlml.c. The C shim implements every
+// function in hlml.h and forwards to the exported Go functions below, which
+// serve values from the JSON description named by HLML_FAKE_CONFIG. Build
+// with -tags fakehlml to run against it on machines without Habana devices.
+
+/*
+#include "hlml.h"
+*/
+import "C"
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+	"unsafe"
+)
+
+// FakeConfigEnv names the environment variable holding the path of the JSON
+// device description loaded by the fake library on hlml_init
+const FakeConfigEnv = "HLML_FAKE_CONFIG"
+
+// FakeConfig describes the system emulated by the fake library
+type FakeConfig struct {
+	// InitError is the hlml_return_t returned by hlml_init, e.g. 9 for
+	// HLML_ERROR_DRIVER_NOT_LOADED
+	InitError     int          `json:"init_error"`
+	HLMLVersion   string       `json:"hlml_version"`
+	DriverVersion string       `json:"driver_version"`
+	Devices       []FakeDevice `json:"devices"`
+	// Delays maps an hlml function not bound to a device, such as
+	// hlml_device_get_handle_by_UUID, to the number of milliseconds it
+	// blocks before reading its arguments
+	Delays map[string]uint `json:"delays"`
+}
+
+// FakeDevice describes a single emulated device. Values that HLML reports
+// per type are keyed by name: clocks by "soc", "ic", "mme" and "tpc",
+// temperatures by "aip" and "board", thresholds by "shutdown", "slowdown",
+// "memory" and "gpu"
+type FakeDevice struct {
+	Name            string            `json:"name"`
+	UUID            string            `json:"uuid"`
+	Serial          string            `json:"serial"`
+	ModelNumber     string            `json:"model_number"`
+	Minor           uint64            `json:"minor"`
+	ModuleID        uint64            `json:"module_id"`
+	BoardID         uint64            `json:"board_id"`
+	HLRevision      int64             `json:"hl_revision"`
+	PCI             FakePCI           `json:"pci"`
+	Memory          FakeMemory        `json:"memory"`
+	Utilization     uint64            `json:"utilization"`
+	Clocks          map[string]uint64 `json:"clocks"`
+	MaxClocks       map[string]uint64 `json:"max_clocks"`
+	PowerUsage      uint64            `json:"power_usage"`
+	PowerLimit      uint64            `json:"power_default_limit"`
+	Temperatures    map[string]uint64 `json:"temperatures"`
+	Thresholds      map[string]uint64 `json:"thresholds"`
+	ECCCurrent      uint64            `json:"ecc_current"`
+	ECCPending      uint64            `json:"ecc_pending"`
+	ECCErrors       uint64            `json:"ecc_errors"`
+	PCBVersion      string            `json:"pcb_version"`
+	PCBAssemblyVer  string            `json:"pcb_assembly_version"`
+	ThrottleReasons uint64            `json:"throttle_reasons"`
+	Energy          uint64            `json:"energy"`
+	PortsMask       uint64            `json:"ports_mask"`
+	ExternalMask    uint64            `json:"external_ports_mask"`
+	LinksUp         []uint            `json:"links_up"`
+	ReplacedRows    map[string]uint64 `json:"replaced_rows"`
+	RowsPending     uint64            `json:"rows_pending"`
+	Violations      map[string]uint64 `json:"violations"`
+	Firmware        map[string]string `json:"firmware"`
+	// Events are delivered, in order, to event sets the device is
+	// registered with
+	Events []uint64 `json:"events"`
+	// Errors maps an hlml function name to the hlml_return_t it returns
+	// for this device, e.g. {"hlml_device_get_power_usage": 15}
+	Errors map[string]int `json:"errors"`
+	// Delays maps an hlml function name to the number of milliseconds it
+	// blocks for this device before returning
+	Delays map[string]uint `json:"delays"`
+}
+
+// FakePCI describes the PCI properties of an emulated device
+type FakePCI struct {
+	BusID          string `json:"bus_id"`
+	Domain         uint64 `json:"domain"`
+	Bus            uint64 `json:"bus"`
+	Device         uint64 `json:"device"`
+	DeviceID       uint64 `json:"device_id"`
+	LinkSpeed      string `json:"link_speed"`
+	LinkWidth      string `json:"link_width"`
+	LinkGeneration uint64 `json:"link_generation"`
+	CurLinkWidth   uint64 `json:"current_link_width"`
+	TX             uint64 `json:"tx"`
+	RX             uint64 `json:"rx"`
+	ReplayCount    uint64 `json:"replay_count"`
+}
+
+// FakeMemory describes the device memory in bytes
+type FakeMemory struct {
+	Total uint64 `json:"total"`
+	Used  uint64 `json:"used"`
+}
+
+type fakeEventSet struct {
+	masks map[int]uint64
+}
+
+var fake struct {
+	sync.Mutex
+	initialized bool
+	config      FakeConfig
+	sets        map[int]*fakeEventSet
+	nextSet     int
+	// lookups records the values devices were looked up by
+	lookups []string
+}
+
+var (
+	fakeClocks      = []string{"soc", "ic", "mme", "tpc"}
+	fakeSensors     = []string{"aip", "board", "other"}
+	fakeThresholds  = []string{"shutdown", "slowdown", "memory", "gpu"}
+	fakeRowCauses   = []string{"single_bit", "double_bit"}
+	fakePerfPolices = []string{"power", "thermal"}
+)
+
+func loadFakeConfig() (FakeConfig, C.hlml_return_t) {
+	var cfg FakeConfig
+
+	b, err := os.ReadFile(os.Getenv(FakeConfigEnv))
+	if err != nil {
+		return cfg, C.HLML_ERROR_DRIVER_NOT_LOADED
+	}
+	if err := json.Unmarshal(b, &cfg); err != nil {
+		return cfg, C.HLML_ERROR_UNKNOWN
+	}
+	return cfg, C.hlml_return_t(cfg.InitError)
+}
+
+// fakeDevice returns the device at idx, or the error configured for op
+func fakeDevice(idx C.int, op string) (*FakeDevice, C.hlml_return_t) {
+	if !fake.initialized {
+		return nil, C.HLML_ERROR_UNINITIALIZED
+	}
+	if idx < 0 || int(idx) >= len(fake.config.Devices) {
+		return nil, C.HLML_ERROR_INVALID_ARGUMENT
+	}
+	dev := &fake.config.Devices[idx]
+	if rc, ok := dev.Errors[op]; ok && rc != C.HLML_SUCCESS {
+		return nil, C.hlml_return_t(rc)
+	}
+	return dev, C.HLML_SUCCESS
+}
+
+// fakeDelay blocks for the delay configured for op. It must be called
+// without holding the fake lock
+func fakeDelay(idx C.int, op string) {
+	fake.Lock()
+	var delay uint
+	if fake.initialized && idx >= 0 && int(idx) < len(fake.config.Devices) {
+		delay = fake.config.Devices[idx].Delays[op]
+	}
+	fake.Unlock()
+
+	time.Sleep(time.Duration(delay) * time.Millisecond)
+}
+
+func fakeIndexed(m map[string]uint64, names []string, arg C.int) (uint64, C.hlml_return_t) {
+	if arg < 0 || int(arg) >= len(names) {
+		return 0, C.HLML_ERROR_INVALID_ARGUMENT
+	}
+	v, ok := m[names[arg]]
+	if !ok {
+		return 0, C.HLML_ERROR_NOT_SUPPORTED
+	}
+	return v, C.HLML_SUCCESS
+}
+
+func fakeCopyString(s string, buf *C.char, length C.uint) C.hlml_return_t {
+	if buf == nil || length == 0 {
+		return C.HLML_ERROR_INVALID_ARGUMENT
+	}
+	if uint(len(s)) >= uint(length) {
+		return C.HLML_ERROR_INSUFFICIENT_SIZE
+	}
+	dst := unsafe.Slice((*byte)(unsafe.Pointer(buf)), length)
+	copy(dst, s)
+	dst[len(s)] = 0
+	return C.HLML_SUCCESS
+}
+
+//export fakeInit
+func fakeInit() C.hlml_return_t {
+	fake.Lock()
+	defer fake.Unlock()
+
+	if fake.initialized {
+		return C.HLML_ERROR_ALREADY_INITIALIZED
+	}
+	cfg, rc := loadFakeConfig()
+	if rc != C.HLML_SUCCESS {
+		return rc
+	}
+	fake.config = cfg
+	fake.sets = make(map[int]*fakeEventSet)
+	fake.lookups = nil
+	fake.initialized = true
+	return C.HLML_SUCCESS
+}
+
+//export fakeShutdown
+func fakeShutdown() C.hlml_return_t {
+	fake.Lock()
+	defer fake.Unlock()
+
+	if !fake.initialized {
+		return C.HLML_ERROR_UNINITIALIZED
+	}
+	fake.initialized = false
+	fake.sets = nil
+	return C.HLML_SUCCESS
+}
+
+//export fakeDeviceCount
+func fakeDeviceCount(count *C.uint) C.hlml_return_t {
+	fake.Lock()
+	defer fake.Unlock()
+
+	if !fake.initialized {
+		return C.HLML_ERROR_UNINITIALIZED
+	}
+	*count = C.uint(len(fake.config.Devices))
+	return C.HLML_SUCCESS
+}
+
+// fakeFindOps maps the keys of fakeFindDevice to the hlml functions using
+// them
+var fakeFindOps = map[string]string{
+	"index":  "hlml_device_get_handle_by_index",
+	"uuid":   "hlml_device_get_handle_by_UUID",
+	"bus_id": "hlml_device_get_handle_by_pci_bus_id",
+}
+
+// fakeFindDevice resolves a device index by the given key, which is one of
+// "index", "uuid" or "bus_id"
+//
+//export fakeFindDevice
+func fakeFindDevice(key *C.char, value *C.char, index C.uint, idx *C.int) C.hlml_return_t {
+	k, v := C.GoString(key), ""
+	fake.Lock()
+	delay := fake.config.Delays[fakeFindOps[k]]
+	fake.Unlock()
+	time.Sleep(time.Duration(delay) * time.Millisecond)
+
+	fake.Lock()
+	defer fake.Unlock()
+
+	if !fake.initialized {
+		return C.HLML_ERROR_UNINITIALIZED
+	}
+	if value != nil {
+		v = C.GoString(value)
+		fake.lookups = append(fake.lookups, v)
+	}
+	for i, dev := range fake.config.Devices {
+		if (k == "index" && uint(i) == uint(index)) ||
+			(k == "uuid" && dev.UUID == v) ||
+			(k == "bus_id" && dev.PCI.BusID == v) {
+			*idx = C.int(i)
+			return C.HLML_SUCCESS
+		}
+	}
+	if k == "index" {
+		return C.HLML_ERROR_INVALID_ARGUMENT
+	}
+	return C.HLML_ERROR_NOT_FOUND
+}
+
+//export fakeGetString
+func fakeGetString(idx C.int, op *C.char, field *C.char, buf *C.char, length C.uint) C.hlml_return_t {
+	fakeDelay(idx, C.GoString(op))
+	fake.Lock()
+	defer fake.Unlock()
+
+	var s string
+	switch name := C.GoString(op); name {
+	case "hlml_get_hlml_version", "hlml_get_driver_version":
+		if !fake.initialized {
+			return C.HLML_ERROR_UNINITIALIZED
+		}
+		s = fake.config.HLMLVersion
+		if name == "hlml_get_driver_version" {
+			s = fake.config.DriverVersion
+		}
+		return fakeCopyString(s, buf, length)
+	}
+
+	dev, rc := fakeDevice(idx, C.GoString(op))
+	if rc != C.HLML_SUCCESS {
+		return rc
+	}
+	switch C.GoString(field) {
+	case "name":
+		s = dev.Name
+	case "uuid":
+		s = dev.UUID
+	case "serial":
+		s = dev.Serial
+	case "model_number":
+		s = dev.ModelNumber
+	case "bus_id":
+		s = dev.PCI.BusID
+	case "link_speed":
+		s = dev.PCI.LinkSpeed
+	case "link_width":
+		s = dev.PCI.LinkWidth
+	case "pcb_version":
+		s = dev.PCBVersion
+	case "pcb_assembly_version":
+		s = dev.PCBAssemblyVer
+	default:
+		v, ok := dev.Firmware[C.GoString(field)]
+		if !ok {
+			return C.HLML_ERROR_NOT_SUPPORTED
+		}
+		s = v
+	}
+	return fakeCopyString(s, buf, length)
+}
+
+//export fakeGetValue
+func fakeGetValue(idx C.int, op *C.char, field *C.char, arg C.int, val *C.ulonglong) C.hlml_return_t {
+	fakeDelay(idx, C.GoString(op))
+	fake.Lock()
+	defer fake.Unlock()
+
+	dev, rc := fakeDevice(idx, C.GoString(op))
+	if rc != C.HLML_SUCCESS {
+		return rc
+	}
+
+	var v uint64
+	rc = C.HLML_SUCCESS
+	switch C.GoString(field) {
+	case "minor":
+		v = dev.Minor
+	case "module_id":
+		v = dev.ModuleID
+	case "board_id":
+		v = dev.BoardID
+	case "hl_revision":
+		v = uint64(dev.HLRevision)
+	case "domain":
+		v = dev.PCI.Domain
+	case "bus":
+		v = dev.PCI.Bus
+	case "device":
+		v = dev.PCI.Device
+	case "pci_device_id":
+		v = dev.PCI.DeviceID
+	case "link_generation":
+		v = dev.PCI.LinkGeneration
+	case "current_link_width":
+		v = dev.PCI.CurLinkWidth
+	case "pcie_throughput":
+		v, rc = fakeIndexed(map[string]uint64{"tx": dev.PCI.TX, "rx": dev.PCI.RX}, []string{"tx", "rx"}, arg)
+	case "replay_count":
+		v = dev.PCI.ReplayCount
+	case "memory_total":
+		v = dev.Memory.Total
+	case "memory_used":
+		v = dev.Memory.Used
+	case "utilization":
+		v = dev.Utilization
+	case "clock":
+		v, rc = fakeIndexed(dev.Clocks, fakeClocks, arg)
+	case "max_clock":
+		v, rc = fakeIndexed(dev.MaxClocks, fakeClocks, arg)
+	case "power_usage":
+		v = dev.PowerUsage
+	case "power_default_limit":
+		v = dev.PowerLimit
+	case "temperature":
+		v, rc = fakeIndexed(dev.Temperatures, fakeSensors, arg)
+	case "threshold":
+		v, rc = fakeIndexed(dev.Thresholds, fakeThresholds, arg)
+	case "ecc_current":
+		v = dev.ECCCurrent
+	case "ecc_pending":
+		v = dev.ECCPending
+	case "ecc_errors":
+		v = dev.ECCErrors
+	case "throttle_reasons":
+		v = dev.ThrottleReasons
+	case "energy":
+		v = dev.Energy
+	case "ports_mask":
+		v = dev.PortsMask
+	case "external_ports_mask":
+		v = dev.ExternalMask
+	case "link_up":
+		for _, p := range dev.LinksUp {
+			if p == uint(arg) {
+				v = 1
+			}
+		}
+	case "replaced_rows":
+		v, rc = fakeIndexed(dev.ReplacedRows, fakeRowCauses, arg)
+	case "rows_pending":
+		v = dev.RowsPending
+	case "violation":
+		v, rc = fakeIndexed(dev.Violations, fakePerfPolices, arg)
+	default:
+		return C.HLML_ERROR_NOT_SUPPORTED
+	}
+	*val = C.ulonglong(v)
+	return rc
+}
+
+//export fakeEventSetCreate
+func fakeEventSetCreate(id *C.int) C.hlml_return_t {
+	fake.Lock()
+	defer fake.Unlock()
+
+	if !fake.initialized {
+		return C.HLML_ERROR_UNINITIALIZED
+	}
+	fake.nextSet++
+	fake.sets[fake.nextSet] = &fakeEventSet{masks: make(map[int]uint64)}
+	*id = C.int(fake.nextSet)
+	return C.HLML_SUCCESS
+}
+
+//export fakeEventSetFree
+func fakeEventSetFree(id C.int) C.hlml_return_t {
+	fake.Lock()
+	defer fake.Unlock()
+
+	if _, ok := fake.sets[int(id)]; !ok {
+		return C.HLML_ERROR_INVALID_ARGUMENT
+	}
+	delete(fake.sets, int(id))
+	return C.HLML_SUCCESS
+}
+
+//export fakeRegisterEvents
+func fakeRegisterEvents(idx C.int, events C.ulonglong, id C.int) C.hlml_return_t {
+	fake.Lock()
+	defer fake.Unlock()
+
+	if _, rc := fakeDevice(idx, "hlml_device_register_events"); rc != C.HLML_SUCCESS {
+		return rc
+	}
+	set, ok := fake.sets[int(id)]
+	if !ok {
+		return C.HLML_ERROR_INVALID_ARGUMENT
+	}
+	set.masks[int(idx)] |= uint64(events)
+	return C.HLML_SUCCESS
+}
+
+//export fakeEventSetWait
+func fakeEventSetWait(id C.int, timeoutms C.uint, idx *C.int, eventType *C.ulonglong) C.hlml_return_t {
+	deadline := time.Now().Add(time.Duration(timeoutms) * time.Millisecond)
+	for {
+		if rc, ok := fakePopEvent(int(id), idx, eventType); ok {
+			return rc
+		}
+		if time.Now().After(deadline) {
+			return C.HLML_ERROR_TIMEOUT
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func fakePopEvent(id int, idx *C.int, eventType *C.ulonglong) (C.hlml_return_t, bool) {
+	fake.Lock()
+	defer fake.Unlock()
+
+	if !fake.initialized {
+		return C.HLML_ERROR_UNINITIALIZED, true
+	}
+	set, ok := fake.sets[id]
+	if !ok {
+		return C.HLML_ERROR_INVALID_ARGUMENT, true
+	}
+	for i := range fake.config.Devices {
+		dev := &fake.config.Devices[i]
+		for j, e := range dev.Events {
+			if set.masks[i]&e == 0 {
+				continue
+			}
+			dev.Events = append(dev.Events[:j], dev.Events[j+1:]...)
+			*idx = C.int(i)
+			*eventType = C.ulonglong(e)
+			return C.HLML_SUCCESS, true
+		}
+	}
+	return C.HLML_SUCCESS, false
+}
+
+//export fakeInjectError
+func fakeInjectError(idx C.int, errType C.int) C.hlml_return_t {
+	fake.Lock()
+	defer fake.Unlock()
+
+	dev, rc := fakeDevice(idx, "hlml_device_err_inject")
+	if rc != C.HLML_SUCCESS {
+		return rc
+	}
+	switch errType {
+	case C.HLML_ERR_INJECT_THERMAL_EVENT:
+		dev.Events = append(dev.Events, C.HLML_EVENT_CLOCK_RATE)
+	case C.HLML_ERR_INJECT_NON_FATAL_EVENT:
+		dev.Events = append(dev.Events, C.HLML_EVENT_ECC_SERR)
+	default:
+		dev.Events = append(dev.Events, C.HLML_EVENT_CRITICAL_ERR)
+	}
+	return C.HLML_SUCCESS
+}
@@ -0,0 +1,356 @@
+//go:build linux && cgo && fakehlml
+
+/*
+ * Copyright (c) 2022, HabanaLabs Ltd.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the Lic
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package gohlml
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMain(m *testing.M) {
+	if os.Getenv(FakeConfigEnv) == "" {
+		os.Setenv(FakeConfigEnv, "testdata/fakehlml.json")
+	}
+
+	root, err := os.MkdirTemp("", "fakesysfs")
+	if err != nil {
+		panic(err)
+	}
+	if err := writeFakeSysfs(root); err != nil {
+		panic(err)
+	}
+	// set as the default so that tests restoring it with SetSysfsRoot("")
+	// keep the fixture
+	os.Setenv(SysfsRootEnv, root)
+
+	code := m.Run()
+	os.RemoveAll(root)
+	os.Exit(code)
+}
+
+// writeFakeSysfs lays out, under root, the sysfs entries of the devices in
+// testdata/fakehlml.json
+func writeFakeSysfs(root string) error {
+	files := map[string]string{
+		"module/habanalabs/version": "1.17.0-fake\n",
+	}
+	links := map[string]string{}
+	for i, busID := range []string{"0000:19:00.0", "0000:1a:00.0"} {
+		dev := filepath.Join("devices", "pci0000:00", busID)
+		files[filepath.Join(dev, "vendor")] = "0x1da3\n"
+		files[filepath.Join(dev, "device")] = "0x1020\n"
+		files[filepath.Join(dev, "numa_node")] = fmt.Sprintf("%d\n", i)
+		files[filepath.Join(dev, "armcp_kernel_ver")] = "Linux gaudi2 5.10.18-hldev10 #1 SMP\n"
+		files[filepath.Join(dev, "uboot_ver")] = "U-Boot 2021.04-fw-44.0.0\n"
+		links[filepath.Join("bus", "pci", "devices", busID)] = dev
+		links[filepath.Join("class", "accel", fmt.Sprintf("accel%d", i), "device")] = dev
+	}
+
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			return err
+		}
+	}
+	for name, target := range links {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return err
+		}
+		if err := os.Symlink(filepath.Join(root, target), path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestFakeDeviceCount(t *testing.T) {
+	err := Initialize()
+	assert.Nil(t, err, err)
+
+	cnt, err := DeviceCount()
+	assert.Nil(t, err, err)
+	assert.Equal(t, uint(2), cnt, "The fixture describes 2 devices")
+
+	for i := uint(0); i < cnt; i++ {
+		dev, err := DeviceHandleByIndex(i)
+		assert.Nil(t, err, err)
+
+		minor, err := dev.MinorNumber()
+		assert.Nil(t, err, err)
+		assert.Equal(t, i, minor)
+	}
+
+	_, err = DeviceHandleByIndex(cnt)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+
+	_, err = DeviceHandleByUUID("no-such-uuid")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	err = Shutdown()
+	assert.Nil(t, err, err)
+}
+
+func TestFakeReinitialize(t *testing.T) {
+	err := Reinitialize()
+	assert.ErrorIs(t, err, ErrNotIntialized)
+
+	err = Initialize()
+	assert.Nil(t, err, err)
+	gen := Generation()
+
+	err = Reinitialize()
+	assert.Nil(t, err, err)
+	assert.True(t, IsInitialized())
+	assert.Equal(t, gen+1, Generation(), "Reinitialize should start a new generation")
+
+	cnt, err := DeviceCount()
+	assert.Nil(t, err, err)
+	assert.Equal(t, uint(2), cnt)
+
+	err = Shutdown()
+	assert.Nil(t, err, err)
+	assert.False(t, IsInitialized(), "Reinitialize should not take a reference")
+}
+
+func TestFakeAipIsLost(t *testing.T) {
+	err := Initialize()
+	assert.Nil(t, err, err)
+
+	dev, err := DeviceHandleByIndex(1)
+	assert.Nil(t, err, err)
+
+	_, err = dev.PowerUsage()
+	assert.ErrorIs(t, err, ErrAipIsLost)
+
+	var hlmlErr *Error
+	assert.True(t, errors.As(err, &hlmlErr), "Should return an *Error")
+	assert.Equal(t, ReturnAipIsLost, hlmlErr.Code)
+	assert.Equal(t, "hlml_device_get_power_usage", hlmlErr.Op)
+	assert.Equal(t, "0000:1a:00.0", hlmlErr.Device)
+
+	_, err = dev.TemperatureOnChip()
+	assert.ErrorIs(t, err, ErrAipIsLost)
+
+	_, err = dev.SerialNumber()
+	assert.Nil(t, err, "Only the configured calls should fail")
+
+	err = Shutdown()
+	assert.Nil(t, err, err)
+}
+
+func TestFakeInitError(t *testing.T) {
+	saved := os.Getenv(FakeConfigEnv)
+	defer os.Setenv(FakeConfigEnv, saved)
+
+	os.Setenv(FakeConfigEnv, "testdata/does-not-exist.json")
+	err := Initialize()
+	assert.ErrorIs(t, err, ErrDriverNotLoaded)
+}
+
+func TestFakeEvents(t *testing.T) {
+	err := Initialize()
+	assert.Nil(t, err, err)
+
+	es := NewEventSet()
+	defer DeleteEventSet(es)
+
+	err = RegisterEventForDevice(es, HlmlCriticalError, "AM24900001")
+	assert.Nil(t, err, err)
+
+	event, err := WaitForEvent(es, 100)
+	assert.Nil(t, err, err)
+	assert.Equal(t, "AM24900001", event.Serial)
+	assert.Equal(t, uint64(HlmlCriticalError), event.Etype)
+
+	event, err = WaitForEvent(es, 10)
+	assert.ErrorIs(t, err, ErrTimeout, "No event is left to deliver")
+	assert.Equal(t, Event{}, event)
+
+	err = Shutdown()
+	assert.Nil(t, err, err)
+}
+
+func TestFakeStuckCall(t *testing.T) {
+	err := Initialize()
+	assert.Nil(t, err, err)
+
+	dev, err := DeviceHandleByIndex(1)
+	assert.Nil(t, err, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err = dev.PCIeLinkGenerationContext(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 200*time.Millisecond, "Caller should not wait for the stuck call")
+
+	state := WorkerStatus()
+	assert.Equal(t, "hlml_device_get_curr_pcie_link_generation", state.Op)
+	assert.False(t, state.Since.IsZero())
+	assert.GreaterOrEqual(t, state.Abandoned, uint64(1))
+
+	ctx2, cancel2 := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel2()
+	_, err = dev.SerialNumberContext(ctx2)
+	assert.ErrorIs(t, err, context.DeadlineExceeded, "Calls queued behind the stuck call should time out too")
+
+	serial, err := dev.SerialNumber()
+	assert.Nil(t, err, "The worker should recover once the stuck call returns")
+	assert.Equal(t, "AM24900002", serial)
+	assert.Equal(t, "", WorkerStatus().Op)
+
+	err = Shutdown()
+	assert.Nil(t, err, err)
+}
+
+func TestFakeStuckUUIDLookup(t *testing.T) {
+	b, err := os.ReadFile("testdata/fakehlml.json")
+	assert.Nil(t, err, err)
+	var cfg FakeConfig
+	assert.Nil(t, json.Unmarshal(b, &cfg))
+	cfg.Delays = map[string]uint{"hlml_device_get_handle_by_UUID": 200}
+	b, err = json.Marshal(cfg)
+	assert.Nil(t, err, err)
+	path := filepath.Join(t.TempDir(), "fakehlml.json")
+	assert.Nil(t, os.WriteFile(path, b, 0o644))
+	t.Setenv(FakeConfigEnv, path)
+
+	err = Initialize()
+	assert.Nil(t, err, err)
+
+	uuid := cfg.Devices[1].UUID
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err = DeviceHandleByUUIDContext(ctx, uuid)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 150*time.Millisecond, "Caller should not wait for the stuck lookup")
+
+	// the abandoned lookup reads its uuid after the caller returned, and
+	// after the next lookup may have reused freed memory for another uuid
+	other := cfg.Devices[0].UUID
+	dev, err := DeviceHandleByUUID(other)
+	assert.Nil(t, err, err)
+	serial, err := dev.SerialNumber()
+	assert.Nil(t, err, err)
+	assert.Equal(t, "AM24900001", serial)
+	fake.Lock()
+	lookups := append([]string(nil), fake.lookups...)
+	fake.Unlock()
+	assert.Equal(t, []string{uuid, other}, lookups, "The abandoned lookup should see its uuid intact")
+
+	err = Shutdown()
+	assert.Nil(t, err, err)
+}
+
+func TestFakeDeadlineBehindReinitialize(t *testing.T) {
+	err := Initialize()
+	assert.Nil(t, err, err)
+
+	dev, err := DeviceHandleByIndex(1)
+	assert.Nil(t, err, err)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		_, _ = dev.PCIeLinkGeneration()
+	}()
+	for WorkerStatus().Op != "hlml_device_get_curr_pcie_link_generation" {
+		time.Sleep(time.Millisecond)
+	}
+	var reinitErr error
+	go func() {
+		defer wg.Done()
+		reinitErr = Reinitialize()
+	}()
+	// let Reinitialize queue up behind the stuck call
+	time.Sleep(10 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err = dev.SerialNumberContext(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 100*time.Millisecond,
+		"A pending Reinitialize should not hold up calls with a deadline")
+
+	wg.Wait()
+	assert.Nil(t, reinitErr, reinitErr)
+
+	err = Shutdown()
+	assert.Nil(t, err, err)
+}
+
+func TestFakeWaitForEventContext(t *testing.T) {
+	err := Initialize()
+	assert.Nil(t, err, err)
+
+	es := NewEventSet()
+	defer DeleteEventSet(es)
+
+	err = RegisterEventForDevice(es, HlmlCriticalError, "AM24900002")
+	assert.Nil(t, err, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err = WaitForEventContext(ctx, es, 5000)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), time.Second, "The wait should be bounded by the deadline")
+
+	err = Shutdown()
+	assert.Nil(t, err, err)
+}
+
+func TestFakeNumaNode(t *testing.T) {
+	err := Initialize()
+	assert.Nil(t, err, err)
+
+	dev, err := DeviceHandleByIndex(1)
+	assert.Nil(t, err, err)
+
+	node, err := dev.NumaNode()
+	assert.Nil(t, err, err)
+	if assert.NotNil(t, node) {
+		assert.Equal(t, uint(1), *node)
+	}
+
+	name, err := GetDeviceTypeName()
+	assert.Nil(t, err, err)
+	assert.Equal(t, "gaudi", name)
+
+	err = Shutdown()
+	assert.Nil(t, err, err)
+}
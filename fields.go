@@ -0,0 +1,216 @@
+/*
+ * Copyright (c) 2022, HabanaLabs Ltd.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the Lic
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gohlml
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// FieldType is the Go type of a field value
+type FieldType int
+
+const (
+	// FieldUint values are uint
+	FieldUint FieldType = iota + 1
+	// FieldInt values are int
+	FieldInt
+	// FieldUint64 values are uint64
+	FieldUint64
+	// FieldString values are string
+	FieldString
+)
+
+var fieldTypeNames = map[FieldType]string{
+	FieldUint:   "uint",
+	FieldInt:    "int",
+	FieldUint64: "uint64",
+	FieldString: "string",
+}
+
+func (t FieldType) String() string {
+	if name, ok := fieldTypeNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("FieldType(%d)", int(t))
+}
+
+// FieldInfo describes a field that Query can read
+type FieldInfo struct {
+	// ID names the field, e.g. temperature.aip
+	ID   string
+	Type FieldType
+	// Unit of the value, empty for counts, identifiers and bit masks
+	Unit        string
+	Description string
+}
+
+// FieldValue is a field read by Query. Value holds the Go type given by
+// Type, unless Err is set
+type FieldValue struct {
+	ID    string
+	Type  FieldType
+	Value any
+	Err   error
+}
+
+type field struct {
+	info FieldInfo
+	get  func(ctx context.Context, dev DeviceInterface) (any, error)
+}
+
+// def defines a field read by fn, typically a DeviceInterface method
+// expression
+func def[T uint | int | uint64 | string](id, unit, desc string, fn func(DeviceInterface, context.Context) (T, error)) field {
+	var t FieldType
+	switch any(*new(T)).(type) {
+	case uint:
+		t = FieldUint
+	case int:
+		t = FieldInt
+	case uint64:
+		t = FieldUint64
+	case string:
+		t = FieldString
+	}
+	return field{
+		info: FieldInfo{ID: id, Type: t, Unit: unit, Description: desc},
+		get: func(ctx context.Context, dev DeviceInterface) (any, error) {
+			return fn(dev, ctx)
+		},
+	}
+}
+
+// memory selects one of the MemoryInfo values
+func memory(i int) func(DeviceInterface, context.Context) (uint64, error) {
+	return func(dev DeviceInterface, ctx context.Context) (uint64, error) {
+		total, used, free, err := dev.MemoryInfoContext(ctx)
+		return []uint64{total, used, free}[i], err
+	}
+}
+
+// ecc selects one of the ECCMode values
+func ecc(i int) func(DeviceInterface, context.Context) (uint, error) {
+	return func(dev DeviceInterface, ctx context.Context) (uint, error) {
+		current, pending, err := dev.ECCModeContext(ctx)
+		return []uint{current, pending}[i], err
+	}
+}
+
+var fields = map[string]field{}
+
+func init() {
+	for _, f := range []field{
+		def("device.name", "", "device name", DeviceInterface.NameContext),
+		def("device.uuid", "", "unique id", DeviceInterface.UUIDContext),
+		def("device.serial", "", "serial number", DeviceInterface.SerialNumberContext),
+		def("device.minor", "", "minor number", DeviceInterface.MinorNumberContext),
+		def("device.module_id", "", "module id", DeviceInterface.ModuleIDContext),
+		def("device.board_id", "", "PCB board id", DeviceInterface.BoardIDContext),
+		def("device.hl_revision", "", "HL revision", DeviceInterface.HLRevisionContext),
+		def("device.pcb_version", "", "PCB version", DeviceInterface.PCBVersionContext),
+		def("device.pcb_assembly_version", "", "PCB assembly version", DeviceInterface.PCBAssemblyVersionContext),
+
+		def("memory.total", "bytes", "total device memory", memory(0)),
+		def("memory.used", "bytes", "used device memory", memory(1)),
+		def("memory.free", "bytes", "free device memory", memory(2)),
+		def("utilization.aip", "%", "AIP utilization", DeviceInterface.UtilizationInfoContext),
+
+		def("clock.soc", "MHz", "current SoC clock", DeviceInterface.SOCClockInfoContext),
+		def("clock.soc.max", "MHz", "maximum SoC clock", DeviceInterface.SOCClockMaxContext),
+		def("clock.ic.max", "MHz", "maximum IC clock", DeviceInterface.ICClockMaxContext),
+		def("clock.mme.max", "MHz", "maximum MME clock", DeviceInterface.MMEClockMaxContext),
+		def("clock.tpc.max", "MHz", "maximum TPC clock", DeviceInterface.TPCClockMaxContext),
+		def("clock.throttle_reasons", "", "clock throttle reasons bit mask", DeviceInterface.ClockThrottleReasonsContext),
+
+		def("power.usage", "mW", "power usage", DeviceInterface.PowerUsageContext),
+		def("power.default_limit", "mW", "default power management limit", DeviceInterface.PowerManagementDefaultLimitContext),
+		def("energy.consumption", "mJ", "energy consumed since the driver was loaded", DeviceInterface.EnergyConsumptionCounterContext),
+
+		def("temperature.aip", "C", "AIP temperature", DeviceInterface.TemperatureOnChipContext),
+		def("temperature.board", "C", "board temperature", DeviceInterface.TemperatureOnBoardContext),
+		def("temperature.threshold.shutdown", "C", "shutdown temperature threshold", DeviceInterface.TemperatureThresholdShutdownContext),
+		def("temperature.threshold.slowdown", "C", "slowdown temperature threshold", DeviceInterface.TemperatureThresholdSlowdownContext),
+		def("temperature.threshold.memory", "C", "memory temperature threshold", DeviceInterface.TemperatureThresholdMemoryContext),
+		def("temperature.threshold.gpu", "C", "AIP temperature threshold", DeviceInterface.TemperatureThresholdGPUContext),
+
+		def("pci.bus_id", "", "PCI bus id", DeviceInterface.PCIBusIDContext),
+		def("pci.domain", "", "PCI domain", DeviceInterface.PCIDomainContext),
+		def("pci.bus", "", "PCI bus", DeviceInterface.PCIBusContext),
+		def("pci.device_id", "", "PCI device id", DeviceInterface.PCIIDContext),
+		def("pcie.tx", "KB/s", "PCIe transmit throughput", DeviceInterface.PCIeTXContext),
+		def("pcie.rx", "KB/s", "PCIe receive throughput", DeviceInterface.PCIeRXContext),
+		def("pcie.replay_count", "", "PCIe replay count", DeviceInterface.PCIReplayCounterContext),
+		def("pcie.link.generation", "", "current PCIe link generation", DeviceInterface.PCIeLinkGenerationContext),
+		def("pcie.link.width", "", "current PCIe link width", DeviceInterface.PCIeLinkWidthContext),
+
+		def("ecc.mode.current", "", "current ECC mode", ecc(0)),
+		def("ecc.mode.pending", "", "pending ECC mode", ecc(1)),
+		def("rows.replaced.single_bit", "", "rows replaced for single bit ECC errors", DeviceInterface.ReplacedRowSingleBitECCContext),
+		def("rows.replaced.double_bit", "", "rows replaced for double bit ECC errors", DeviceInterface.ReplacedRowDoubleBitECCContext),
+		def("rows.replaced.pending", "", "1 if rows are pending replacement on the next power cycle", DeviceInterface.IsReplacedRowsPendingStatusContext),
+	} {
+		fields[f.info.ID] = f
+	}
+}
+
+// Fields lists every field Query can read, ordered by ID
+func Fields() []FieldInfo {
+	infos := make([]FieldInfo, 0, len(fields))
+	for _, f := range fields {
+		infos = append(infos, f.info)
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].ID < infos[j].ID })
+	return infos
+}
+
+// LookupField returns the description of the field with the given ID
+func LookupField(id string) (FieldInfo, bool) {
+	f, ok := fields[id]
+	return f.info, ok
+}
+
+// Query reads the fields with the given IDs from every device. The result
+// has a row per device and, within it, a value per field, in the order
+// they were given. Failures of single fields are reported in their Err. It
+// fails only for an unknown field ID, before reading any
+func Query(ctx context.Context, devices []DeviceInterface, ids []string) ([][]FieldValue, error) {
+	selected := make([]field, len(ids))
+	for i, id := range ids {
+		f, ok := fields[id]
+		if !ok {
+			return nil, fmt.Errorf("field %q: %w", id, ErrNotFound)
+		}
+		selected[i] = f
+	}
+
+	values := make([][]FieldValue, len(devices))
+	for i, dev := range devices {
+		values[i] = make([]FieldValue, len(selected))
+		for j, f := range selected {
+			v := FieldValue{ID: f.info.ID, Type: f.info.Type}
+			if x, err := f.get(ctx, dev); err != nil {
+				v.Err = err
+			} else {
+				v.Value = x
+			}
+			values[i][j] = v
+		}
+	}
+	return values, nil
+}
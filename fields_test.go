@@ -0,0 +1,78 @@
+/*
+ * Copyright (c) 2022, HabanaLabs Ltd.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the Lic
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package gohlml_test
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/HabanaAI/gohlml"
+	"github.com/HabanaAI/gohlml/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFields(t *testing.T) {
+	infos := gohlml.Fields()
+	assert.True(t, sort.SliceIsSorted(infos, func(i, j int) bool { return infos[i].ID < infos[j].ID }))
+
+	info, ok := gohlml.LookupField("temperature.aip")
+	assert.True(t, ok)
+	assert.Equal(t, gohlml.FieldUint, info.Type)
+	assert.Equal(t, "C", info.Unit)
+
+	info, ok = gohlml.LookupField("memory.used")
+	assert.True(t, ok)
+	assert.Equal(t, gohlml.FieldUint64, info.Type)
+
+	_, ok = gohlml.LookupField("no.such.field")
+	assert.False(t, ok)
+}
+
+func TestQuery(t *testing.T) {
+	devices := []gohlml.DeviceInterface{
+		&mock.Device{
+			TemperatureOnChipContextFunc: func(context.Context) (uint, error) { return 41, nil },
+			MemoryInfoContextFunc: func(context.Context) (uint64, uint64, uint64, error) {
+				return 32 << 30, 1 << 30, 31 << 30, nil
+			},
+			SerialNumberContextFunc: func(context.Context) (string, error) { return "AM24900001", nil },
+		},
+		&mock.Device{
+			TemperatureOnChipContextFunc: func(context.Context) (uint, error) {
+				return 0, gohlml.ErrAipIsLost
+			},
+		},
+	}
+
+	values, err := gohlml.Query(context.Background(), devices,
+		[]string{"temperature.aip", "memory.used", "device.serial"})
+	assert.Nil(t, err, err)
+	if assert.Len(t, values, 2) {
+		assert.Equal(t, []gohlml.FieldValue{
+			{ID: "temperature.aip", Type: gohlml.FieldUint, Value: uint(41)},
+			{ID: "memory.used", Type: gohlml.FieldUint64, Value: uint64(1 << 30)},
+			{ID: "device.serial", Type: gohlml.FieldString, Value: "AM24900001"},
+		}, values[0])
+		assert.ErrorIs(t, values[1][0].Err, gohlml.ErrAipIsLost)
+		assert.Nil(t, values[1][0].Value)
+	}
+
+	_, err = gohlml.Query(context.Background(), devices, []string{"temperature.aip", "no.such.field"})
+	assert.ErrorIs(t, err, gohlml.ErrNotFound)
+	assert.Len(t, devices[0].(*mock.Device).TemperatureOnChipContextCalls(), 1,
+		"An unknown field should fail before reading any")
+}
@@ -0,0 +1,80 @@
+/*
+ * Copyright (c) 2022, HabanaLabs Ltd.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the Lic
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gohlml
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// firmwareAliases maps the version attributes of older drivers to the name
+// newer drivers expose them under
+var firmwareAliases = map[string]string{
+	"armcp_kernel": "cpucp_kernel",
+	"armcp":        "cpucp",
+}
+
+// FirmwareVersions returns the firmware component versions of dev, read
+// from every *_ver attribute of its accel device. Keys are the attribute
+// names without the _ver suffix, e.g. cpucp_kernel, uboot, preboot_btl or
+// fuse. Versions older drivers report under armcp names are returned under
+// the cpucp names
+func FirmwareVersions(dev DeviceInterface) (map[string]string, error) {
+	return FirmwareVersionsContext(context.Background(), dev)
+}
+
+// FirmwareVersionsContext is like FirmwareVersions but returns ctx.Err() if
+// ctx is done before the minor number of dev is resolved
+func FirmwareVersionsContext(ctx context.Context, dev DeviceInterface) (map[string]string, error) {
+	minor, err := dev.MinorNumberContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	dir, err := accelDir(minor)
+	if err != nil {
+		return nil, err
+	}
+	dir = filepath.Join(dir, "device")
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := map[string]string{}
+	for _, e := range entries {
+		name, ok := strings.CutSuffix(e.Name(), "_ver")
+		if !ok || e.IsDir() {
+			continue
+		}
+		v, ok, err := readAttr(dir, e.Name())
+		if err != nil || !ok {
+			continue
+		}
+		versions[name] = v
+	}
+	for old, name := range firmwareAliases {
+		if v, ok := versions[old]; ok {
+			if _, ok := versions[name]; !ok {
+				versions[name] = v
+			}
+			delete(versions, old)
+		}
+	}
+	return versions, nil
+}
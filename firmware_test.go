@@ -0,0 +1,84 @@
+/*
+ * Copyright (c) 2022, HabanaLabs Ltd.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the Lic
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package gohlml_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/HabanaAI/gohlml"
+	"github.com/HabanaAI/gohlml/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFirmwareVersions(t *testing.T) {
+	root := t.TempDir()
+	saved := gohlml.SysfsRoot()
+	defer gohlml.SetSysfsRoot(saved)
+	gohlml.SetSysfsRoot(root)
+
+	for accel, files := range map[string]map[string]string{
+		// an older driver
+		"accel0": {
+			"armcp_kernel_ver": "Linux gaudi 4.9.0-hl #1\n",
+			"uboot_ver":        "U-Boot 2017.05-fw-32.5.0\n",
+			"status":           "operational\n",
+		},
+		// a newer driver, where the device at minor 1 is accel3
+		"accel3": {
+			"cpucp_kernel_ver": "Linux gaudi2 5.10.18-hldev10 #1\n",
+			"armcp_kernel_ver": "stale\n",
+			"preboot_btl_ver":  "BTL version 44.0.0\n",
+			"fuse_ver":         "0x0000a3c1\n",
+			"thermal_ver":      "1.2.3\n",
+		},
+	} {
+		dev := filepath.Join(root, "devices", accel)
+		assert.Nil(t, os.MkdirAll(filepath.Join(dev, "power_ver"), 0o755), "Directories should be skipped")
+		for name, v := range files {
+			assert.Nil(t, os.WriteFile(filepath.Join(dev, name), []byte(v), 0o644))
+		}
+		class := filepath.Join(root, "class", "accel", accel)
+		assert.Nil(t, os.MkdirAll(class, 0o755))
+		assert.Nil(t, os.Symlink(dev, filepath.Join(class, "device")))
+	}
+	assert.Nil(t, os.WriteFile(filepath.Join(root, "class", "accel", "accel3", "dev"), []byte("261:1\n"), 0o644))
+
+	minor := func(m uint) *mock.Device {
+		return &mock.Device{MinorNumberContextFunc: func(context.Context) (uint, error) { return m, nil }}
+	}
+
+	versions, err := gohlml.FirmwareVersions(minor(0))
+	assert.Nil(t, err, err)
+	assert.Equal(t, map[string]string{
+		"cpucp_kernel": "Linux gaudi 4.9.0-hl #1",
+		"uboot":        "U-Boot 2017.05-fw-32.5.0",
+	}, versions)
+
+	versions, err = gohlml.FirmwareVersions(minor(1))
+	assert.Nil(t, err, err)
+	assert.Equal(t, map[string]string{
+		"cpucp_kernel": "Linux gaudi2 5.10.18-hldev10 #1",
+		"preboot_btl":  "BTL version 44.0.0",
+		"fuse":         "0x0000a3c1",
+		"thermal":      "1.2.3",
+	}, versions, "The new attribute name should take precedence")
+
+	_, err = gohlml.FirmwareVersions(minor(2))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
@@ -1,3 +1,5 @@
+//go:build linux && cgo
+
 /*
  * Copyright (c) 2022, HabanaLabs Ltd.  All rights reserved.
  *
@@ -14,27 +16,22 @@
  * limitations under the License.
  */
 
-// Package gohlml is a Go package serves as a bridge to work
-// with hlml C library. It allows access to native Habana device
-// commands and information.
 package gohlml
 
 /*
-#cgo LDFLAGS: "/usr/lib/habanalabs/libhlml.so" -ldl -Wl,--unresolved-symbols=ignore-all
 #include "hlml.h"
 #include <stdlib.h>
 */
 import "C"
 
 import (
-	"bytes"
+	"context"
 	"errors"
 	"fmt"
-	"log"
-	"os"
-	"path/filepath"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 	"unsafe"
 )
 
@@ -42,323 +39,781 @@ const (
 	szUUID = 256
 	// HlmlCriticalError indicates a critical error in the device
 	HlmlCriticalError = C.HLML_EVENT_CRITICAL_ERR
-	// HLDriverPath indicates on habana device dir
-	HLDriverPath = "/sys/class/accel"
-	// HLModulePath indicates on habana module dir
-	HLModulePath = "/sys/module/habanalabs"
-	// BITSPerLong repsenets 64 bits in logs
-	BITSPerLong = 64
 )
 
-var pciBasePath = "/sys/bus/pci/devices"
-
 // Device struct maps to C HLML structure
 type Device struct{ dev C.hlml_device_t }
 
 // EventSet is a cast of the C type of the hlml event set
 type EventSet struct{ set C.hlml_event_set_t }
 
-// Event contains uuid and event type
-type Event struct {
-	Serial string
-	Etype  uint64
-}
-
-// PCIInfo contains the PCI properties of the device
-type PCIInfo struct {
-	BusID    string
-	DeviceID uint
-}
-
-var (
-	ErrNotIntialized      = errors.New("hlml not initialized")
-	ErrInvalidArgument    = errors.New("invalid argument")
-	ErrNotSupported       = errors.New("not supported")
-	ErrAlreadyInitialized = errors.New("hlml already initialized")
-	ErrNotFound           = errors.New("not found")
-	ErrInsufficientSize   = errors.New("insufficient size")
-	ErrDriverNotLoaded    = errors.New("driver not loaded")
-	ErrAipIsLost          = errors.New("aip is lost")
-	ErrMemoryError        = errors.New("memory error")
-	ErrNoData             = errors.New("no data")
-	ErrUnknownError       = errors.New("unknown error")
-)
+// lifecycle counts the Initialize calls not yet matched by a Shutdown. HLML
+// is initialized while refs is positive. The transitions run as worker
+// jobs, so hlml_shutdown never overtakes a call submitted before it and no
+// lock is held while a caller waits on the worker. refs, init and op are
+// therefore only written on the worker. init and op record how HLML was
+// first initialized, for Reinitialize. gen counts the successful
+// initializations
+var lifecycle struct {
+	// mu guards the fields below. It is never held across an HLML call
+	mu   sync.Mutex
+	refs int
+	init func() C.hlml_return_t
+	op   string
+	gen  uint64
+
+	// inline is read locked by the calls running off the worker and write
+	// locked by the transitions, so that HLML is not shut down under them
+	inline sync.RWMutex
+}
+
+func initialized() bool {
+	lifecycle.mu.Lock()
+	defer lifecycle.mu.Unlock()
+
+	return lifecycle.refs > 0
+}
+
+// call runs the library level HLML function op on the worker, failing with
+// ErrNotIntialized instead when the library is not initialized
+func call(ctx context.Context, op string, fn func() C.hlml_return_t) error {
+	return do(ctx, op, nil, fn)
+}
+
+// call runs the HLML function op for the device on the worker
+func (d Device) call(ctx context.Context, op string, fn func() C.hlml_return_t) error {
+	return do(ctx, op, &d, fn)
+}
 
-func errorString(ret C.hlml_return_t) error {
-	switch ret {
-	case C.HLML_SUCCESS:
-		fallthrough
-	case C.HLML_ERROR_TIMEOUT:
-		return nil
-	case C.HLML_ERROR_UNINITIALIZED:
-		return ErrNotIntialized
-	case C.HLML_ERROR_INVALID_ARGUMENT:
-		return ErrInvalidArgument
-	case C.HLML_ERROR_NOT_SUPPORTED:
-		return ErrNotSupported
-	case C.HLML_ERROR_ALREADY_INITIALIZED:
-		return ErrAlreadyInitialized
-	case C.HLML_ERROR_NOT_FOUND:
-		return ErrNotFound
-	case C.HLML_ERROR_INSUFFICIENT_SIZE:
-		return ErrInsufficientSize
-	case C.HLML_ERROR_DRIVER_NOT_LOADED:
-		return ErrDriverNotLoaded
-	case C.HLML_ERROR_AIP_IS_LOST:
-		return ErrAipIsLost
-	case C.HLML_ERROR_MEMORY:
-		return ErrMemoryError
-	case C.HLML_ERROR_NO_DATA:
-		return ErrNoData
-	case C.HLML_ERROR_UNKNOWN:
-		return ErrUnknownError
-	}
-
-	return fmt.Errorf("invalid HLML error return code %d", ret)
-}
-
-// Initialize initializes the HLML library
+// do runs fn on the worker. Callers must not read the buffers fn writes
+// when do fails: after ctx.Err(), fn may still be running and writing them.
+// For the same reason, C memory fn passes to HLML must be allocated and
+// freed inside fn rather than by the caller
+func do(ctx context.Context, op string, d *Device, fn func() C.hlml_return_t) error {
+	if !initialized() {
+		return newError(ReturnUninitialized, op, "")
+	}
+
+	var rc C.hlml_return_t
+	var device string
+	err := runOnWorker(ctx, op, func() {
+		// a Shutdown may have run since the check above
+		if !initialized() {
+			rc = C.hlml_return_t(ReturnUninitialized)
+			return
+		}
+		rc, device = invoke(op, d, fn)
+	})
+	if err != nil {
+		return err
+	}
+	return newError(Return(rc), op, device)
+}
+
+// invoke runs fn, records it in the metrics and traces it when call tracing
+// is on. The bus id of d is resolved only for failed or traced calls
+func invoke(op string, d *Device, fn func() C.hlml_return_t) (C.hlml_return_t, string) {
+	start := time.Now()
+	rc := fn()
+	latency := time.Since(start)
+
+	var device string
+	if d != nil && (rc != C.HLML_SUCCESS || tracing.Load()) {
+		device = d.busID()
+	}
+	recordCall(op, Return(rc), latency)
+	traceCall(op, device, Return(rc), latency)
+	return rc, device
+}
+
+// callInline runs op on the calling goroutine rather than on the worker. It
+// is used for blocking waits that would otherwise stall every other call
+func callInline(op string, fn func() C.hlml_return_t) error {
+	lifecycle.inline.RLock()
+	defer lifecycle.inline.RUnlock()
+
+	if !initialized() {
+		return newError(ReturnUninitialized, op, "")
+	}
+	rc, _ := invoke(op, nil, fn)
+	return newError(Return(rc), op, "")
+}
+
+// busID resolves the PCI bus id of the device for error reports. It must be
+// called on the worker
+func (d Device) busID() string {
+	var pci C.hlml_pci_info_t
+
+	if C.hlml_device_get_pci_info(d.dev, &pci) != C.HLML_SUCCESS {
+		return ""
+	}
+	return C.GoString(&pci.bus_id[0])
+}
+
+// transition runs fn on the worker once no call runs off the worker. It
+// waits without a deadline, but holds no lock that calls wait on meanwhile,
+// so calls with a deadline still give up in time
+func transition(op string, fn func()) {
+	_ = runOnWorker(context.Background(), op, func() {
+		lifecycle.inline.Lock()
+		defer lifecycle.inline.Unlock()
+
+		fn()
+	})
+}
+
+// acquire takes a reference on the library, running init only for the first
+// reference
+func acquire(op string, init func() C.hlml_return_t) error {
+	var err error
+	transition(op, func() {
+		if !initialized() {
+			rc, _ := invoke(op, nil, init)
+			if err = newError(Return(rc), op, ""); err != nil {
+				return
+			}
+			lifecycle.mu.Lock()
+			lifecycle.init, lifecycle.op = init, op
+			lifecycle.gen++
+			lifecycle.mu.Unlock()
+		}
+		lifecycle.mu.Lock()
+		lifecycle.refs++
+		lifecycle.mu.Unlock()
+	})
+	return err
+}
+
+// Initialize initializes the HLML library. Calls are reference counted and
+// every successful Initialize must be paired with a Shutdown
 func Initialize() error {
-	return errorString(C.hlml_init())
+	return acquire("hlml_init", func() C.hlml_return_t {
+		return C.hlml_init()
+	})
 }
 
-// InitWithLogs initializes the HLML library with logging on
+// InitWithLogs initializes the HLML library with logging on. It is
+// InitWithLogLevel with LogLevelVerbose
 func InitWithLogs() error {
-	return errorString(C.hlml_init_with_flags(0x6))
+	return InitWithLogLevel(LogLevelVerbose)
 }
 
-// Shutdown shutdowns the HLML library
+// InitWithLogLevel initializes the HLML library with libhlml logging at the
+// given level. The level only takes effect if this is the first reference
+// to the library
+func InitWithLogLevel(level LogLevel) error {
+	if level == LogLevelDefault {
+		return Initialize()
+	}
+	return acquire("hlml_init_with_flags", func() C.hlml_return_t {
+		return C.hlml_init_with_flags(C.uint(level))
+	})
+}
+
+// Shutdown releases a reference taken by Initialize. HLML is shut down when
+// the last reference is released
 func Shutdown() error {
-	return errorString(C.hlml_shutdown())
+	err := newError(ReturnUninitialized, "hlml_shutdown", "")
+	transition("hlml_shutdown", func() {
+		lifecycle.mu.Lock()
+		if lifecycle.refs == 0 {
+			lifecycle.mu.Unlock()
+			return
+		}
+		lifecycle.refs--
+		last := lifecycle.refs == 0
+		lifecycle.mu.Unlock()
+
+		err = nil
+		if last {
+			rc, _ := invoke("hlml_shutdown", nil, func() C.hlml_return_t {
+				return C.hlml_shutdown()
+			})
+			err = newError(Return(rc), "hlml_shutdown", "")
+		}
+	})
+	return err
+}
+
+// Reinitialize shuts HLML down and initializes it again, the way it was
+// first initialized, without changing the reference count. It recovers the
+// library after a device reset or driver reload, and invalidates every
+// Device obtained before it. It fails with ErrNotIntialized when HLML is not
+// initialized, since there is then nothing to recover
+func Reinitialize() error {
+	err := newError(ReturnUninitialized, "hlml_init", "")
+	transition("hlml_init", func() {
+		lifecycle.mu.Lock()
+		refs, init, op := lifecycle.refs, lifecycle.init, lifecycle.op
+		lifecycle.mu.Unlock()
+		if refs == 0 {
+			return
+		}
+
+		// a failed shutdown is expected once the driver is gone
+		_, _ = invoke("hlml_shutdown", nil, func() C.hlml_return_t {
+			return C.hlml_shutdown()
+		})
+		rc, _ := invoke(op, nil, init)
+		// on failure the references are kept, so that a later Reinitialize
+		// can retry once the driver is back
+		if err = newError(Return(rc), op, ""); err != nil {
+			return
+		}
+		lifecycle.mu.Lock()
+		lifecycle.gen++
+		lifecycle.mu.Unlock()
+	})
+	return err
+}
+
+// Generation counts the times HLML was initialized, by the first Initialize
+// or by Reinitialize. Devices obtained under an earlier generation are
+// stale
+func Generation() uint64 {
+	lifecycle.mu.Lock()
+	defer lifecycle.mu.Unlock()
+
+	return lifecycle.gen
+}
+
+// IsInitialized reports whether HLML is initialized
+func IsInitialized() bool {
+	return initialized()
 }
 
 // DeviceCount gets number of Habana devices in the system
 func DeviceCount() (uint, error) {
+	return DeviceCountContext(context.Background())
+}
+
+// DeviceCountContext is like DeviceCount but returns ctx.Err() if ctx is
+// done before the call completes
+func DeviceCountContext(ctx context.Context) (uint, error) {
 	var NumOfDevices C.uint
 
-	rc := C.hlml_device_get_count(&NumOfDevices)
-	return uint(NumOfDevices), errorString(rc)
+	err := call(ctx, "hlml_device_get_count", func() C.hlml_return_t {
+		return C.hlml_device_get_count(&NumOfDevices)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return uint(NumOfDevices), nil
 }
 
 // DeviceHandleByIndex gets a handle to a particular device by index
 func DeviceHandleByIndex(idx uint) (Device, error) {
+	return DeviceHandleByIndexContext(context.Background(), idx)
+}
+
+// DeviceHandleByIndexContext is like DeviceHandleByIndex but returns
+// ctx.Err() if ctx is done before the call completes
+func DeviceHandleByIndexContext(ctx context.Context, idx uint) (Device, error) {
 	var dev C.hlml_device_t
 
-	rc := C.hlml_device_get_handle_by_index(C.uint(idx), &dev)
-	return Device{dev}, errorString(rc)
+	err := call(ctx, "hlml_device_get_handle_by_index", func() C.hlml_return_t {
+		return C.hlml_device_get_handle_by_index(C.uint(idx), &dev)
+	})
+	if err != nil {
+		return Device{}, err
+	}
+	return Device{dev}, nil
 }
 
 // DeviceHandleByUUID gets a handle to a particular device by UUIC
 func DeviceHandleByUUID(uuid string) (Device, error) {
-	var dev C.hlml_device_t
+	return DeviceHandleByUUIDContext(context.Background(), uuid)
+}
 
-	cstr := C.CString(uuid)
-	defer C.free(unsafe.Pointer(cstr))
+// DeviceHandleByUUIDContext is like DeviceHandleByUUID but returns ctx.Err()
+// if ctx is done before the call completes
+func DeviceHandleByUUIDContext(ctx context.Context, uuid string) (Device, error) {
+	var dev C.hlml_device_t
 
-	rc := C.hlml_device_get_handle_by_UUID(cstr, &dev)
-	return Device{dev}, errorString(rc)
+	err := call(ctx, "hlml_device_get_handle_by_UUID", func() C.hlml_return_t {
+		cstr := C.CString(uuid)
+		defer C.free(unsafe.Pointer(cstr))
+		return C.hlml_device_get_handle_by_UUID(cstr, &dev)
+	})
+	if err != nil {
+		return Device{}, err
+	}
+	return Device{dev}, nil
 }
 
 // DeviceHandleBySerial gets a handle to a particular device by serial number
 func DeviceHandleBySerial(serial string) (*Device, error) {
-	numDevices, _ := DeviceCount()
+	return DeviceHandleBySerialContext(context.Background(), serial)
+}
+
+// DeviceHandleBySerialContext is like DeviceHandleBySerial but returns
+// ctx.Err() if ctx is done before the call completes
+func DeviceHandleBySerialContext(ctx context.Context, serial string) (*Device, error) {
+	numDevices, _ := DeviceCountContext(ctx)
 
 	for i := uint(0); i < numDevices; i++ {
-		handle, _ := DeviceHandleByIndex(i)
+		handle, _ := DeviceHandleByIndexContext(ctx, i)
 
-		currentSerial, _ := handle.SerialNumber()
+		currentSerial, _ := handle.SerialNumberContext(ctx)
 
 		if currentSerial == serial {
 			return &handle, nil
 		}
 	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 
 	return nil, errors.New("could not find device with serial number")
 }
 
 // MinorNumber returns Minor number.
 func (d Device) MinorNumber() (uint, error) {
+	return d.MinorNumberContext(context.Background())
+}
+
+// MinorNumberContext is like MinorNumber but returns ctx.Err() if ctx is
+// done before the call completes
+func (d Device) MinorNumberContext(ctx context.Context) (uint, error) {
 	var minor C.uint
 
-	rc := C.hlml_device_get_minor_number(d.dev, &minor)
-	return uint(minor), errorString(rc)
+	err := d.call(ctx, "hlml_device_get_minor_number", func() C.hlml_return_t {
+		return C.hlml_device_get_minor_number(d.dev, &minor)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return uint(minor), nil
 }
 
 // Name returns Device Name
 func (d Device) Name() (string, error) {
+	return d.NameContext(context.Background())
+}
+
+// NameContext is like Name but returns ctx.Err() if ctx is done before the
+// call completes
+func (d Device) NameContext(ctx context.Context) (string, error) {
 	var name [szUUID]C.char
 
-	rc := C.hlml_device_get_name(d.dev, &name[0], szUUID)
-	return C.GoString(&name[0]), errorString(rc)
+	err := d.call(ctx, "hlml_device_get_name", func() C.hlml_return_t {
+		return C.hlml_device_get_name(d.dev, &name[0], szUUID)
+	})
+	if err != nil {
+		return "", err
+	}
+	return C.GoString(&name[0]), nil
 }
 
 // UUID returns the unique id for a given device
 func (d Device) UUID() (string, error) {
+	return d.UUIDContext(context.Background())
+}
+
+// UUIDContext is like UUID but returns ctx.Err() if ctx is done before the
+// call completes
+func (d Device) UUIDContext(ctx context.Context) (string, error) {
 	var uuid [szUUID]C.char
 
-	rc := C.hlml_device_get_uuid(d.dev, &uuid[0], szUUID)
-	return C.GoString(&uuid[0]), errorString(rc)
+	err := d.call(ctx, "hlml_device_get_uuid", func() C.hlml_return_t {
+		return C.hlml_device_get_uuid(d.dev, &uuid[0], szUUID)
+	})
+	if err != nil {
+		return "", err
+	}
+	return C.GoString(&uuid[0]), nil
 }
 
 // PCIDomain returns the PCI domain for a given device
 func (d Device) PCIDomain() (uint, error) {
+	return d.PCIDomainContext(context.Background())
+}
+
+// PCIDomainContext is like PCIDomain but returns ctx.Err() if ctx is done
+// before the call completes
+func (d Device) PCIDomainContext(ctx context.Context) (uint, error) {
 	var pci C.hlml_pci_info_t
 
-	rc := C.hlml_device_get_pci_info(d.dev, &pci)
-	return uint(pci.domain), errorString(rc)
+	err := d.call(ctx, "hlml_device_get_pci_info", func() C.hlml_return_t {
+		return C.hlml_device_get_pci_info(d.dev, &pci)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return uint(pci.domain), nil
 }
 
 // PCIBus returns the PCI bus info for a given device
 func (d Device) PCIBus() (uint, error) {
+	return d.PCIBusContext(context.Background())
+}
+
+// PCIBusContext is like PCIBus but returns ctx.Err() if ctx is done before
+// the call completes
+func (d Device) PCIBusContext(ctx context.Context) (uint, error) {
 	var pci C.hlml_pci_info_t
 
-	rc := C.hlml_device_get_pci_info(d.dev, &pci)
-	return uint(pci.bus), errorString(rc)
+	err := d.call(ctx, "hlml_device_get_pci_info", func() C.hlml_return_t {
+		return C.hlml_device_get_pci_info(d.dev, &pci)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return uint(pci.bus), nil
 }
 
 // PCIBusID returns the PCI bus id for a given device
 func (d Device) PCIBusID() (string, error) {
+	return d.PCIBusIDContext(context.Background())
+}
+
+// PCIBusIDContext is like PCIBusID but returns ctx.Err() if ctx is done
+// before the call completes
+func (d Device) PCIBusIDContext(ctx context.Context) (string, error) {
 	var pci C.hlml_pci_info_t
 
-	rc := C.hlml_device_get_pci_info(d.dev, &pci)
-	return C.GoString(&pci.bus_id[0]), errorString(rc)
+	err := d.call(ctx, "hlml_device_get_pci_info", func() C.hlml_return_t {
+		return C.hlml_device_get_pci_info(d.dev, &pci)
+	})
+	if err != nil {
+		return "", err
+	}
+	return C.GoString(&pci.bus_id[0]), nil
 }
 
 // PCIID returns the PCI id for a given device
 func (d Device) PCIID() (uint, error) {
+	return d.PCIIDContext(context.Background())
+}
+
+// PCIIDContext is like PCIID but returns ctx.Err() if ctx is done before the
+// call completes
+func (d Device) PCIIDContext(ctx context.Context) (uint, error) {
 	var pci C.hlml_pci_info_t
 
-	rc := C.hlml_device_get_pci_info(d.dev, &pci)
-	return uint(pci.pci_device_id), errorString(rc)
+	err := d.call(ctx, "hlml_device_get_pci_info", func() C.hlml_return_t {
+		return C.hlml_device_get_pci_info(d.dev, &pci)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return uint(pci.pci_device_id), nil
 }
 
 // PCILinkSpeed returns the current PCI link speed for a given device
 func (d Device) PCILinkSpeed() (uint, error) {
+	return d.PCILinkSpeedContext(context.Background())
+}
+
+// PCILinkSpeedContext is like PCILinkSpeed but returns ctx.Err() if ctx is
+// done before the call completes
+func (d Device) PCILinkSpeedContext(ctx context.Context) (uint, error) {
 	var pci C.hlml_pci_info_t
 
-	rc := C.hlml_device_get_pci_info(d.dev, &pci)
+	err := d.call(ctx, "hlml_device_get_pci_info", func() C.hlml_return_t {
+		return C.hlml_device_get_pci_info(d.dev, &pci)
+	})
+	if err != nil {
+		return 0, err
+	}
 	speed := C.GoString(&pci.caps.link_speed[0])
 	speed = strings.ReplaceAll(speed, "0x", "")
 	res, _ := strconv.Atoi(speed)
-	return uint(res), errorString(rc)
+	return uint(res), nil
 }
 
 // PCILinkWidth returns the current PCI link width for a given device
 func (d Device) PCILinkWidth() (uint, error) {
+	return d.PCILinkWidthContext(context.Background())
+}
+
+// PCILinkWidthContext is like PCILinkWidth but returns ctx.Err() if ctx is
+// done before the call completes
+func (d Device) PCILinkWidthContext(ctx context.Context) (uint, error) {
 	var pci C.hlml_pci_info_t
 
-	rc := C.hlml_device_get_pci_info(d.dev, &pci)
+	err := d.call(ctx, "hlml_device_get_pci_info", func() C.hlml_return_t {
+		return C.hlml_device_get_pci_info(d.dev, &pci)
+	})
+	if err != nil {
+		return 0, err
+	}
 	width := C.GoString(&pci.caps.link_width[0])
 	res, _ := strconv.Atoi(width)
-	return uint(res), errorString(rc)
+	return uint(res), nil
 }
 
 // MemoryInfo returns the current memory usage in bytes for total, used, free
 func (d Device) MemoryInfo() (uint64, uint64, uint64, error) {
+	return d.MemoryInfoContext(context.Background())
+}
+
+// MemoryInfoContext is like MemoryInfo but returns ctx.Err() if ctx is done
+// before the call completes
+func (d Device) MemoryInfoContext(ctx context.Context) (uint64, uint64, uint64, error) {
 	var mem C.hlml_memory_t
-	rc := C.hlml_device_get_memory_info(d.dev, &mem)
-	return uint64(mem.total), uint64(mem.used), uint64(mem.total - mem.used), errorString(rc)
+	err := d.call(ctx, "hlml_device_get_memory_info", func() C.hlml_return_t {
+		return C.hlml_device_get_memory_info(d.dev, &mem)
+	})
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	return uint64(mem.total), uint64(mem.used), uint64(mem.total - mem.used), nil
 }
 
 // UtilizationInfo returns the utilization aip rate for a given device
 func (d Device) UtilizationInfo() (uint, error) {
+	return d.UtilizationInfoContext(context.Background())
+}
+
+// UtilizationInfoContext is like UtilizationInfo but returns ctx.Err() if
+// ctx is done before the call completes
+func (d Device) UtilizationInfoContext(ctx context.Context) (uint, error) {
 	var util C.hlml_utilization_t
 
-	rc := C.hlml_device_get_utilization_rates(d.dev, &util)
-	return uint(util.aip), errorString(rc)
+	err := d.call(ctx, "hlml_device_get_utilization_rates", func() C.hlml_return_t {
+		return C.hlml_device_get_utilization_rates(d.dev, &util)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return uint(util.aip), nil
 }
 
 // SOCClockInfo returns the SoC clock frequency for a given device
 func (d Device) SOCClockInfo() (uint, error) {
+	return d.SOCClockInfoContext(context.Background())
+}
+
+// SOCClockInfoContext is like SOCClockInfo but returns ctx.Err() if ctx is
+// done before the call completes
+func (d Device) SOCClockInfoContext(ctx context.Context) (uint, error) {
 	var freq C.uint
 
-	rc := C.hlml_device_get_clock_info(d.dev, C.HLML_CLOCK_SOC, &freq)
-	return uint(freq), errorString(rc)
+	err := d.call(ctx, "hlml_device_get_clock_info", func() C.hlml_return_t {
+		return C.hlml_device_get_clock_info(d.dev, C.HLML_CLOCK_SOC, &freq)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return uint(freq), nil
 }
 
 // SOCClockMax returns the maximum SoC clock frequency for a given device
 func (d Device) SOCClockMax() (uint, error) {
+	return d.SOCClockMaxContext(context.Background())
+}
+
+// SOCClockMaxContext is like SOCClockMax but returns ctx.Err() if ctx is
+// done before the call completes
+func (d Device) SOCClockMaxContext(ctx context.Context) (uint, error) {
 	var freq C.uint
-	rc := C.hlml_device_get_max_clock_info(d.dev, C.HLML_CLOCK_SOC, &freq)
-	return uint(freq), errorString(rc)
+	err := d.call(ctx, "hlml_device_get_max_clock_info", func() C.hlml_return_t {
+		return C.hlml_device_get_max_clock_info(d.dev, C.HLML_CLOCK_SOC, &freq)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return uint(freq), nil
 }
 
 // ICClockMax returns the maximum IC clock frequency for a given device
 func (d Device) ICClockMax() (uint, error) {
+	return d.ICClockMaxContext(context.Background())
+}
+
+// ICClockMaxContext is like ICClockMax but returns ctx.Err() if ctx is done
+// before the call completes
+func (d Device) ICClockMaxContext(ctx context.Context) (uint, error) {
 	var freq C.uint
-	rc := C.hlml_device_get_max_clock_info(d.dev, C.HLML_CLOCK_IC, &freq)
-	return uint(freq), errorString(rc)
+	err := d.call(ctx, "hlml_device_get_max_clock_info", func() C.hlml_return_t {
+		return C.hlml_device_get_max_clock_info(d.dev, C.HLML_CLOCK_IC, &freq)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return uint(freq), nil
 }
 
 // MMEClockMax returns the maximum MME clock frequency for a given device
 func (d Device) MMEClockMax() (uint, error) {
+	return d.MMEClockMaxContext(context.Background())
+}
+
+// MMEClockMaxContext is like MMEClockMax but returns ctx.Err() if ctx is
+// done before the call completes
+func (d Device) MMEClockMaxContext(ctx context.Context) (uint, error) {
 	var freq C.uint
-	rc := C.hlml_device_get_max_clock_info(d.dev, C.HLML_CLOCK_MME, &freq)
-	return uint(freq), errorString(rc)
+	err := d.call(ctx, "hlml_device_get_max_clock_info", func() C.hlml_return_t {
+		return C.hlml_device_get_max_clock_info(d.dev, C.HLML_CLOCK_MME, &freq)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return uint(freq), nil
 }
 
 // TPCClockMax returns the maximum TPC clock frequency for a given device
 func (d Device) TPCClockMax() (uint, error) {
+	return d.TPCClockMaxContext(context.Background())
+}
+
+// TPCClockMaxContext is like TPCClockMax but returns ctx.Err() if ctx is
+// done before the call completes
+func (d Device) TPCClockMaxContext(ctx context.Context) (uint, error) {
 	var freq C.uint
-	rc := C.hlml_device_get_max_clock_info(d.dev, C.HLML_CLOCK_TPC, &freq)
-	return uint(freq), errorString(rc)
+	err := d.call(ctx, "hlml_device_get_max_clock_info", func() C.hlml_return_t {
+		return C.hlml_device_get_max_clock_info(d.dev, C.HLML_CLOCK_TPC, &freq)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return uint(freq), nil
 }
 
 // PowerUsage returns the power usage in milliwatts for a given device
 func (d Device) PowerUsage() (uint, error) {
+	return d.PowerUsageContext(context.Background())
+}
+
+// PowerUsageContext is like PowerUsage but returns ctx.Err() if ctx is done
+// before the call completes
+func (d Device) PowerUsageContext(ctx context.Context) (uint, error) {
 	var power C.uint
-	rc := C.hlml_device_get_power_usage(d.dev, &power)
-	return uint(power), errorString(rc)
+	err := d.call(ctx, "hlml_device_get_power_usage", func() C.hlml_return_t {
+		return C.hlml_device_get_power_usage(d.dev, &power)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return uint(power), nil
 }
 
 // TemperatureOnBoard returns the temperature in celsius for a device board
 func (d Device) TemperatureOnBoard() (uint, error) {
+	return d.TemperatureOnBoardContext(context.Background())
+}
+
+// TemperatureOnBoardContext is like TemperatureOnBoard but returns ctx.Err()
+// if ctx is done before the call completes
+func (d Device) TemperatureOnBoardContext(ctx context.Context) (uint, error) {
 	var onBoard C.uint
-	rc := C.hlml_device_get_temperature(d.dev, C.HLML_TEMPERATURE_ON_BOARD, &onBoard)
-	return uint(onBoard), errorString(rc)
+	err := d.call(ctx, "hlml_device_get_temperature", func() C.hlml_return_t {
+		return C.hlml_device_get_temperature(d.dev, C.HLML_TEMPERATURE_ON_BOARD, &onBoard)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return uint(onBoard), nil
 }
 
 // TemperatureOnChip returns the temperature in celsius for a the device chip
 func (d Device) TemperatureOnChip() (uint, error) {
+	return d.TemperatureOnChipContext(context.Background())
+}
+
+// TemperatureOnChipContext is like TemperatureOnChip but returns ctx.Err()
+// if ctx is done before the call completes
+func (d Device) TemperatureOnChipContext(ctx context.Context) (uint, error) {
 	var onChip C.uint
-	rc := C.hlml_device_get_temperature(d.dev, C.HLML_TEMPERATURE_ON_AIP, &onChip)
-	return uint(onChip), errorString(rc)
+	err := d.call(ctx, "hlml_device_get_temperature", func() C.hlml_return_t {
+		return C.hlml_device_get_temperature(d.dev, C.HLML_TEMPERATURE_ON_AIP, &onChip)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return uint(onChip), nil
 }
 
 // TemperatureThresholdShutdown Retrieves the known temperature threshold for the AIP with the specified threshold type in degrees
 func (d Device) TemperatureThresholdShutdown() (uint, error) {
+	return d.TemperatureThresholdShutdownContext(context.Background())
+}
+
+// TemperatureThresholdShutdownContext is like TemperatureThresholdShutdown
+// but returns ctx.Err() if ctx is done before the call completes
+func (d Device) TemperatureThresholdShutdownContext(ctx context.Context) (uint, error) {
 	var temp C.uint
-	rc := C.hlml_device_get_temperature_threshold(d.dev, C.HLML_TEMPERATURE_THRESHOLD_SHUTDOWN, &temp)
-	return uint(temp), errorString(rc)
+	err := d.call(ctx, "hlml_device_get_temperature_threshold", func() C.hlml_return_t {
+		return C.hlml_device_get_temperature_threshold(d.dev, C.HLML_TEMPERATURE_THRESHOLD_SHUTDOWN, &temp)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return uint(temp), nil
 }
 
 // TemperatureThresholdSlowdown Retrieves the known temperature threshold for the AIP with the specified threshold type in degrees
 func (d Device) TemperatureThresholdSlowdown() (uint, error) {
+	return d.TemperatureThresholdSlowdownContext(context.Background())
+}
+
+// TemperatureThresholdSlowdownContext is like TemperatureThresholdSlowdown
+// but returns ctx.Err() if ctx is done before the call completes
+func (d Device) TemperatureThresholdSlowdownContext(ctx context.Context) (uint, error) {
 	var temp C.uint
-	rc := C.hlml_device_get_temperature_threshold(d.dev, C.HLML_TEMPERATURE_THRESHOLD_SLOWDOWN, &temp)
-	return uint(temp), errorString(rc)
+	err := d.call(ctx, "hlml_device_get_temperature_threshold", func() C.hlml_return_t {
+		return C.hlml_device_get_temperature_threshold(d.dev, C.HLML_TEMPERATURE_THRESHOLD_SLOWDOWN, &temp)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return uint(temp), nil
 }
 
 // TemperatureThresholdMemory Retrieves the known temperature threshold for the AIP with the specified threshold type in degrees
 func (d Device) TemperatureThresholdMemory() (uint, error) {
+	return d.TemperatureThresholdMemoryContext(context.Background())
+}
+
+// TemperatureThresholdMemoryContext is like TemperatureThresholdMemory but
+// returns ctx.Err() if ctx is done before the call completes
+func (d Device) TemperatureThresholdMemoryContext(ctx context.Context) (uint, error) {
 	var temp C.uint
-	rc := C.hlml_device_get_temperature_threshold(d.dev, C.HLML_TEMPERATURE_THRESHOLD_MEM_MAX, &temp)
-	return uint(temp), errorString(rc)
+	err := d.call(ctx, "hlml_device_get_temperature_threshold", func() C.hlml_return_t {
+		return C.hlml_device_get_temperature_threshold(d.dev, C.HLML_TEMPERATURE_THRESHOLD_MEM_MAX, &temp)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return uint(temp), nil
 }
 
 // TemperatureThresholdGPU Retrieves the known temperature threshold for the AIP with the specified threshold type in degrees
 func (d Device) TemperatureThresholdGPU() (uint, error) {
+	return d.TemperatureThresholdGPUContext(context.Background())
+}
+
+// TemperatureThresholdGPUContext is like TemperatureThresholdGPU but returns
+// ctx.Err() if ctx is done before the call completes
+func (d Device) TemperatureThresholdGPUContext(ctx context.Context) (uint, error) {
 	var temp C.uint
-	rc := C.hlml_device_get_temperature_threshold(d.dev, C.HLML_TEMPERATURE_THRESHOLD_GPU_MAX, &temp)
-	return uint(temp), errorString(rc)
+	err := d.call(ctx, "hlml_device_get_temperature_threshold", func() C.hlml_return_t {
+		return C.hlml_device_get_temperature_threshold(d.dev, C.HLML_TEMPERATURE_THRESHOLD_GPU_MAX, &temp)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return uint(temp), nil
 }
 
 // PowerManagementDefaultLimit Retrieves default power management limit on this device, in milliwatts.
 // Default power management limit is a power management limit that the device boots with.
 func (d Device) PowerManagementDefaultLimit() (uint, error) {
+	return d.PowerManagementDefaultLimitContext(context.Background())
+}
+
+// PowerManagementDefaultLimitContext is like PowerManagementDefaultLimit but
+// returns ctx.Err() if ctx is done before the call completes
+func (d Device) PowerManagementDefaultLimitContext(ctx context.Context) (uint, error) {
 	var limit C.uint
-	rc := C.hlml_device_get_power_management_default_limit(d.dev, &limit)
-	return uint(limit), errorString(rc)
+	err := d.call(ctx, "hlml_device_get_power_management_default_limit", func() C.hlml_return_t {
+		return C.hlml_device_get_power_management_default_limit(d.dev, &limit)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return uint(limit), nil
 }
 
 // ECCMode retrieves the current and pending ECC modes for the device
@@ -366,120 +821,285 @@ func (d Device) PowerManagementDefaultLimit() (uint, error) {
 //	1 - ECCMode enabled
 //	0 - ECCMode disabled
 func (d Device) ECCMode() (uint, uint, error) {
+	return d.ECCModeContext(context.Background())
+}
+
+// ECCModeContext is like ECCMode but returns ctx.Err() if ctx is done before
+// the call completes
+func (d Device) ECCModeContext(ctx context.Context) (uint, uint, error) {
 	var current, pending C.hlml_enable_state_t
-	rc := C.hlml_device_get_ecc_mode(d.dev, &current, &pending)
-	return uint(current), uint(pending), errorString(rc)
+	err := d.call(ctx, "hlml_device_get_ecc_mode", func() C.hlml_return_t {
+		return C.hlml_device_get_ecc_mode(d.dev, &current, &pending)
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	return uint(current), uint(pending), nil
 }
 
 // HLRevision returns the revision of the HL library
 func (d Device) HLRevision() (int, error) {
+	return d.HLRevisionContext(context.Background())
+}
+
+// HLRevisionContext is like HLRevision but returns ctx.Err() if ctx is done
+// before the call completes
+func (d Device) HLRevisionContext(ctx context.Context) (int, error) {
 	var rev C.int
-	rc := C.hlml_device_get_hl_revision(d.dev, &rev)
-	return int(rev), errorString(rc)
+	err := d.call(ctx, "hlml_device_get_hl_revision", func() C.hlml_return_t {
+		return C.hlml_device_get_hl_revision(d.dev, &rev)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int(rev), nil
 }
 
 // PCBVersion returns the PCB version
 func (d Device) PCBVersion() (string, error) {
+	return d.PCBVersionContext(context.Background())
+}
+
+// PCBVersionContext is like PCBVersion but returns ctx.Err() if ctx is done
+// before the call completes
+func (d Device) PCBVersionContext(ctx context.Context) (string, error) {
 	var pcb C.hlml_pcb_info_t
 
-	rc := C.hlml_device_get_pcb_info(d.dev, &pcb)
-	return C.GoString(&pcb.pcb_ver[0]), errorString(rc)
+	err := d.call(ctx, "hlml_device_get_pcb_info", func() C.hlml_return_t {
+		return C.hlml_device_get_pcb_info(d.dev, &pcb)
+	})
+	if err != nil {
+		return "", err
+	}
+	return C.GoString(&pcb.pcb_ver[0]), nil
 }
 
 // PCBAssemblyVersion returns the PCB Assembly info
 func (d Device) PCBAssemblyVersion() (string, error) {
+	return d.PCBAssemblyVersionContext(context.Background())
+}
+
+// PCBAssemblyVersionContext is like PCBAssemblyVersion but returns ctx.Err()
+// if ctx is done before the call completes
+func (d Device) PCBAssemblyVersionContext(ctx context.Context) (string, error) {
 	var pcb C.hlml_pcb_info_t
 
-	rc := C.hlml_device_get_pcb_info(d.dev, &pcb)
-	return C.GoString(&pcb.pcb_assembly_ver[0]), errorString(rc)
+	err := d.call(ctx, "hlml_device_get_pcb_info", func() C.hlml_return_t {
+		return C.hlml_device_get_pcb_info(d.dev, &pcb)
+	})
+	if err != nil {
+		return "", err
+	}
+	return C.GoString(&pcb.pcb_assembly_ver[0]), nil
 }
 
 // SerialNumber returns the device serial number
 func (d Device) SerialNumber() (string, error) {
+	return d.SerialNumberContext(context.Background())
+}
+
+// SerialNumberContext is like SerialNumber but returns ctx.Err() if ctx is
+// done before the call completes
+func (d Device) SerialNumberContext(ctx context.Context) (string, error) {
 	var serial [szUUID]C.char
 
-	rc := C.hlml_device_get_serial(d.dev, &serial[0], szUUID)
-	return C.GoString(&serial[0]), errorString(rc)
+	err := d.call(ctx, "hlml_device_get_serial", func() C.hlml_return_t {
+		return C.hlml_device_get_serial(d.dev, &serial[0], szUUID)
+	})
+	if err != nil {
+		return "", err
+	}
+	return C.GoString(&serial[0]), nil
 }
 
 // ModuleID returns the device moduleID
 func (d Device) ModuleID() (uint, error) {
+	return d.ModuleIDContext(context.Background())
+}
+
+// ModuleIDContext is like ModuleID but returns ctx.Err() if ctx is done
+// before the call completes
+func (d Device) ModuleIDContext(ctx context.Context) (uint, error) {
 	var moduleID C.uint
-	rc := C.hlml_device_get_module_id(d.dev, &moduleID)
-	return uint(moduleID), errorString(rc)
+	err := d.call(ctx, "hlml_device_get_module_id", func() C.hlml_return_t {
+		return C.hlml_device_get_module_id(d.dev, &moduleID)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return uint(moduleID), nil
 }
 
 // BoardID returns an ID for the PCB board
 func (d Device) BoardID() (uint, error) {
+	return d.BoardIDContext(context.Background())
+}
+
+// BoardIDContext is like BoardID but returns ctx.Err() if ctx is done before
+// the call completes
+func (d Device) BoardIDContext(ctx context.Context) (uint, error) {
 	var id C.uint
 
-	rc := C.hlml_device_get_board_id(d.dev, &id)
-	return uint(id), errorString(rc)
+	err := d.call(ctx, "hlml_device_get_board_id", func() C.hlml_return_t {
+		return C.hlml_device_get_board_id(d.dev, &id)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return uint(id), nil
 }
 
 // PCIeTX returns PCIe transmit throughput
 func (d Device) PCIeTX() (uint, error) {
+	return d.PCIeTXContext(context.Background())
+}
+
+// PCIeTXContext is like PCIeTX but returns ctx.Err() if ctx is done before
+// the call completes
+func (d Device) PCIeTXContext(ctx context.Context) (uint, error) {
 	var val C.uint
 
-	rc := C.hlml_device_get_pcie_throughput(d.dev, C.HLML_PCIE_UTIL_TX_BYTES, &val)
-	return uint(val), errorString(rc)
+	err := d.call(ctx, "hlml_device_get_pcie_throughput", func() C.hlml_return_t {
+		return C.hlml_device_get_pcie_throughput(d.dev, C.HLML_PCIE_UTIL_TX_BYTES, &val)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return uint(val), nil
 }
 
 // PCIeRX returns PCIe receive throughput
 func (d Device) PCIeRX() (uint, error) {
+	return d.PCIeRXContext(context.Background())
+}
+
+// PCIeRXContext is like PCIeRX but returns ctx.Err() if ctx is done before
+// the call completes
+func (d Device) PCIeRXContext(ctx context.Context) (uint, error) {
 	var val C.uint
 
-	rc := C.hlml_device_get_pcie_throughput(d.dev, C.HLML_PCIE_UTIL_RX_BYTES, &val)
-	return uint(val), errorString(rc)
+	err := d.call(ctx, "hlml_device_get_pcie_throughput", func() C.hlml_return_t {
+		return C.hlml_device_get_pcie_throughput(d.dev, C.HLML_PCIE_UTIL_RX_BYTES, &val)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return uint(val), nil
 }
 
 // PCIReplayCounter returns PCIe replay count
 func (d Device) PCIReplayCounter() (uint, error) {
+	return d.PCIReplayCounterContext(context.Background())
+}
+
+// PCIReplayCounterContext is like PCIReplayCounter but returns ctx.Err() if
+// ctx is done before the call completes
+func (d Device) PCIReplayCounterContext(ctx context.Context) (uint, error) {
 	var val C.uint
 
-	rc := C.hlml_device_get_pcie_replay_counter(d.dev, &val)
-	return uint(val), errorString(rc)
+	err := d.call(ctx, "hlml_device_get_pcie_replay_counter", func() C.hlml_return_t {
+		return C.hlml_device_get_pcie_replay_counter(d.dev, &val)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return uint(val), nil
 }
 
 // PCIeLinkGeneration returns PCIe replay count
 // MUST run with SUDO/priviledged
 func (d Device) PCIeLinkGeneration() (uint, error) {
+	return d.PCIeLinkGenerationContext(context.Background())
+}
+
+// PCIeLinkGenerationContext is like PCIeLinkGeneration but returns ctx.Err()
+// if ctx is done before the call completes
+func (d Device) PCIeLinkGenerationContext(ctx context.Context) (uint, error) {
 	var gen C.uint
 
-	rc := C.hlml_device_get_curr_pcie_link_generation(d.dev, &gen)
-	return uint(gen), errorString(rc)
+	err := d.call(ctx, "hlml_device_get_curr_pcie_link_generation", func() C.hlml_return_t {
+		return C.hlml_device_get_curr_pcie_link_generation(d.dev, &gen)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return uint(gen), nil
 }
 
 // PCIeLinkWidth returns PCIe link width
 func (d Device) PCIeLinkWidth() (uint, error) {
+	return d.PCIeLinkWidthContext(context.Background())
+}
+
+// PCIeLinkWidthContext is like PCIeLinkWidth but returns ctx.Err() if ctx is
+// done before the call completes
+func (d Device) PCIeLinkWidthContext(ctx context.Context) (uint, error) {
 	var width C.uint
 
-	rc := C.hlml_device_get_curr_pcie_link_width(d.dev, &width)
-	return uint(width), errorString(rc)
+	err := d.call(ctx, "hlml_device_get_curr_pcie_link_width", func() C.hlml_return_t {
+		return C.hlml_device_get_curr_pcie_link_width(d.dev, &width)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return uint(width), nil
 }
 
 // ClockThrottleReasons returns current clock throttle reasons
 func (d Device) ClockThrottleReasons() (uint64, error) {
+	return d.ClockThrottleReasonsContext(context.Background())
+}
+
+// ClockThrottleReasonsContext is like ClockThrottleReasons but returns
+// ctx.Err() if ctx is done before the call completes
+func (d Device) ClockThrottleReasonsContext(ctx context.Context) (uint64, error) {
 	var reasons C.ulonglong
 
-	rc := C.hlml_device_get_current_clocks_throttle_reasons(d.dev, &reasons)
-	return uint64(reasons), errorString(rc)
+	err := d.call(ctx, "hlml_device_get_current_clocks_throttle_reasons", func() C.hlml_return_t {
+		return C.hlml_device_get_current_clocks_throttle_reasons(d.dev, &reasons)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return uint64(reasons), nil
 }
 
 // EnergyConsumptionCounter returns energy consumption
 func (d Device) EnergyConsumptionCounter() (uint64, error) {
+	return d.EnergyConsumptionCounterContext(context.Background())
+}
+
+// EnergyConsumptionCounterContext is like EnergyConsumptionCounter but
+// returns ctx.Err() if ctx is done before the call completes
+func (d Device) EnergyConsumptionCounterContext(ctx context.Context) (uint64, error) {
 	var energy C.ulonglong
 
-	rc := C.hlml_device_get_total_energy_consumption(d.dev, &energy)
-	return uint64(energy), errorString(rc)
+	err := d.call(ctx, "hlml_device_get_total_energy_consumption", func() C.hlml_return_t {
+		return C.hlml_device_get_total_energy_consumption(d.dev, &energy)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return uint64(energy), nil
 }
 
 // MacAddressInfo retrieves the masks for supported ports and external ports.
 func (d Device) MacAddressInfo() (map[int]string, error) {
+	return d.MacAddressInfoContext(context.Background())
+}
+
+// MacAddressInfoContext is like MacAddressInfo but returns ctx.Err() if ctx
+// is done before the call completes
+func (d Device) MacAddressInfoContext(ctx context.Context) (map[int]string, error) {
 	var mask [C.PORTS_ARR_SIZE]C.uint64_t
 	var extMask [C.PORTS_ARR_SIZE]C.uint64_t
 
-	rc := C.hlml_get_mac_addr_info(d.dev, &mask[0], &extMask[0])
+	err := d.call(ctx, "hlml_get_mac_addr_info", func() C.hlml_return_t {
+		return C.hlml_get_mac_addr_info(d.dev, &mask[0], &extMask[0])
+	})
+	if err != nil {
+		return nil, err
+	}
 
 	ports := make(map[int]string)
 	maskBinary := strconv.FormatInt(int64(mask[0]), 2)
@@ -492,204 +1112,157 @@ func (d Device) MacAddressInfo() (map[int]string, error) {
 		}
 	}
 
-	return ports, errorString(rc)
+	return ports, nil
 }
 
 // NicLinkStatus gets a port and checks its status.
 // return 1 (up) or 0 (down)
 func (d Device) NicLinkStatus(port uint) (uint, error) {
+	return d.NicLinkStatusContext(context.Background(), port)
+}
+
+// NicLinkStatusContext is like NicLinkStatus but returns ctx.Err() if ctx is
+// done before the call completes
+func (d Device) NicLinkStatusContext(ctx context.Context, port uint) (uint, error) {
 	var up C.bool
-	rc := C.hlml_nic_get_link(d.dev, C.uint(port), &up)
+	err := d.call(ctx, "hlml_nic_get_link", func() C.hlml_return_t {
+		return C.hlml_nic_get_link(d.dev, C.uint(port), &up)
+	})
+	if err != nil {
+		return 0, err
+	}
 	if up {
-		return uint(1), errorString(rc)
+		return uint(1), nil
 	}
-	return uint(0), errorString(rc)
+	return uint(0), nil
 }
 
 // ReplacedRowDoubleBitECC returns the number of rows with double-bit ecc errors
 func (d Device) ReplacedRowDoubleBitECC() (uint, error) {
+	return d.ReplacedRowDoubleBitECCContext(context.Background())
+}
+
+// ReplacedRowDoubleBitECCContext is like ReplacedRowDoubleBitECC but returns
+// ctx.Err() if ctx is done before the call completes
+func (d Device) ReplacedRowDoubleBitECCContext(ctx context.Context) (uint, error) {
 	var rowsCount C.uint = 0
-	rc := C.hlml_device_get_replaced_rows(d.dev, C.HLML_ROW_REPLACEMENT_CAUSE_DOUBLE_BIT_ECC_ERROR, &rowsCount, nil)
-	return uint(rowsCount), errorString(rc)
+	err := d.call(ctx, "hlml_device_get_replaced_rows", func() C.hlml_return_t {
+		return C.hlml_device_get_replaced_rows(d.dev, C.HLML_ROW_REPLACEMENT_CAUSE_DOUBLE_BIT_ECC_ERROR, &rowsCount, nil)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return uint(rowsCount), nil
 }
 
 // ReplacedRowSingleBitECC returns the number of rows with single-bit ecc errors
 func (d Device) ReplacedRowSingleBitECC() (uint, error) {
-	var rowsCount C.uint
-	rc := C.hlml_device_get_replaced_rows(d.dev, C.HLML_ROW_REPLACEMENT_CAUSE_MULTIPLE_SINGLE_BIT_ECC_ERRORS, &rowsCount, nil)
-	return uint(rowsCount), errorString(rc)
+	return d.ReplacedRowSingleBitECCContext(context.Background())
 }
 
-// IsReplacedRowsPendingStatus return 0 (false) or 1 (true) if there are any
-// rows need of replacement in a power cycle
-func (d Device) IsReplacedRowsPendingStatus() (int, error) {
-	var isPending C.hlml_enable_state_t
-	rc := C.hlml_device_get_replaced_rows_pending_status(d.dev, &isPending)
-	return int(isPending), errorString(rc)
-}
-
-// NumaNode returns the Numa affinity of the device or nil is no affinity.
-func (d Device) NumaNode() (*uint, error) {
-	busID, err := d.PCIBusID()
-	if err != nil {
-		return nil, err
-	}
-
-	b, err := os.ReadFile(fmt.Sprintf("/sys/bus/pci/devices/%s/numa_node", strings.ToLower(busID)))
-	if err != nil {
-		// report nil if NUMA support isn't enabled
-		return nil, nil
-	}
-	node, err := strconv.ParseInt(string(bytes.TrimSpace(b)), 10, 8)
+// ReplacedRowSingleBitECCContext is like ReplacedRowSingleBitECC but returns
+// ctx.Err() if ctx is done before the call completes
+func (d Device) ReplacedRowSingleBitECCContext(ctx context.Context) (uint, error) {
+	var rowsCount C.uint
+	err := d.call(ctx, "hlml_device_get_replaced_rows", func() C.hlml_return_t {
+		return C.hlml_device_get_replaced_rows(d.dev, C.HLML_ROW_REPLACEMENT_CAUSE_MULTIPLE_SINGLE_BIT_ECC_ERRORS, &rowsCount, nil)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("%v: %v", errors.New("failed to retrieve CPU affinity"), err)
+		return 0, err
 	}
-	if node < 0 {
-		return nil, nil
-	}
-
-	numaNode := uint(node)
-	return &numaNode, nil
+	return uint(rowsCount), nil
 }
 
-// FWVersion returns the firmware version for a given device
-func FWVersion(idx uint) (kernel string, uboot string, err error) {
-	b, err := os.ReadFile(fmt.Sprintf("%s/accel%d/device/armcp_kernel_ver", HLDriverPath, idx))
-	if err != nil {
-		return "", "", fmt.Errorf("file reading error %s", err)
-	}
-	kernel = string(b)
-
-	b, err = os.ReadFile(fmt.Sprintf("%s/accel%d/device/uboot_ver", HLDriverPath, idx))
-	if err != nil {
-		return "", "", fmt.Errorf("file reading error %s", err)
-	}
-	uboot = string(b)
-
-	return kernel, uboot, nil
+// IsReplacedRowsPendingStatus return 0 (false) or 1 (true) if there are any
+// rows need of replacement in a power cycle
+func (d Device) IsReplacedRowsPendingStatus() (int, error) {
+	return d.IsReplacedRowsPendingStatusContext(context.Background())
 }
 
-// SystemDriverVersion returns the driver version on the system
-func SystemDriverVersion() (string, error) {
-	driver, err := os.ReadFile(HLModulePath + "/version")
+// IsReplacedRowsPendingStatusContext is like IsReplacedRowsPendingStatus but
+// returns ctx.Err() if ctx is done before the call completes
+func (d Device) IsReplacedRowsPendingStatusContext(ctx context.Context) (int, error) {
+	var isPending C.hlml_enable_state_t
+	err := d.call(ctx, "hlml_device_get_replaced_rows_pending_status", func() C.hlml_return_t {
+		return C.hlml_device_get_replaced_rows_pending_status(d.dev, &isPending)
+	})
 	if err != nil {
-		return "", fmt.Errorf("file reading error %s", err)
+		return 0, err
 	}
-	return string(driver), nil
+	return int(isPending), nil
 }
 
 func NewEventSet() EventSet {
 	var set C.hlml_event_set_t
-	C.hlml_event_set_create(&set)
+	_ = call(context.Background(), "hlml_event_set_create", func() C.hlml_return_t {
+		return C.hlml_event_set_create(&set)
+	})
 
 	return EventSet{set}
 }
 
 func RegisterEventForDevice(es EventSet, event int, uuid string) error {
-	deviceHandle, err := DeviceHandleBySerial(uuid)
+	return RegisterEventForDeviceContext(context.Background(), es, event, uuid)
+}
+
+// RegisterEventForDeviceContext is like RegisterEventForDevice but returns
+// ctx.Err() if ctx is done before the call completes
+func RegisterEventForDeviceContext(ctx context.Context, es EventSet, event int, uuid string) error {
+	deviceHandle, err := DeviceHandleBySerialContext(ctx, uuid)
 	if err != nil {
 		return fmt.Errorf("hlml: device not found")
 	}
 
-	r := C.hlml_device_register_events(deviceHandle.dev, C.ulonglong(event), es.set)
-	if r != C.HLML_SUCCESS {
-		return errorString(r)
-	}
-
-	return nil
+	return deviceHandle.call(ctx, "hlml_device_register_events", func() C.hlml_return_t {
+		return C.hlml_device_register_events(deviceHandle.dev, C.ulonglong(event), es.set)
+	})
 }
 
 func DeleteEventSet(es EventSet) {
-	C.hlml_event_set_free(es.set)
+	_ = call(context.Background(), "hlml_event_set_free", func() C.hlml_return_t {
+		return C.hlml_event_set_free(es.set)
+	})
 }
 
+// WaitForEvent waits up to timeout milliseconds for an event. It fails with
+// ErrTimeout when no event arrived in time. Shutdown blocks until a pending
+// wait returns
 func WaitForEvent(es EventSet, timeout uint) (Event, error) {
-	var data C.hlml_event_data_t
-
-	r := C.hlml_event_set_wait(es.set, &data, C.uint(timeout))
-	serial, _ := Device{data.device}.SerialNumber()
-
-	return Event{
-			Serial: serial,
-			Etype:  uint64(data.event_type),
-		},
-		errorString(r)
+	return WaitForEventContext(context.Background(), es, timeout)
 }
 
-func GetDeviceTypeName() (string, error) {
-	var deviceType string
-
-	err := filepath.Walk(pciBasePath, func(path string, info os.FileInfo, err error) error {
-		log.Println(pciBasePath, info.Name())
-		if err != nil {
-			return fmt.Errorf("error accessing file path %q", path)
-		}
-		if info.IsDir() {
-			log.Println("Not a device, continuing")
-			return nil
-		}
-		// Retrieve vendor for the device
-		vendorID, err := readIDFromFile(pciBasePath, info.Name(), "vendor")
-		if err != nil {
-			return fmt.Errorf("get vendor: %w", err)
-		}
-
-		// Habana vendor id is "1da3".
-		if vendorID != "1da3" {
-			return nil
-		}
+// WaitForEventContext is like WaitForEvent but waits no longer than the
+// deadline of ctx, returning ctx.Err() once it passes. The wait runs on the
+// calling goroutine, so it does not hold up other calls on the worker
+func WaitForEventContext(ctx context.Context, es EventSet, timeout uint) (Event, error) {
+	var data C.hlml_event_data_t
 
-		deviceID, err := readIDFromFile(pciBasePath, info.Name(), "device")
-		if err != nil {
-			return fmt.Errorf("get device info: %w", err)
+	var bounded bool
+	if deadline, ok := ctx.Deadline(); ok {
+		left := time.Until(deadline).Milliseconds()
+		if left < 0 {
+			left = 0
 		}
-
-		deviceType, err = getDeviceName(deviceID)
-		if err != nil {
-			return fmt.Errorf("get device name: %w", err)
+		if uint64(left) < uint64(timeout) {
+			timeout, bounded = uint(left), true
 		}
-
-		return nil
-	})
-	if err != nil {
-		return "", err
-	}
-
-	return deviceType, nil
-}
-
-func getDeviceName(deviceID string) (string, error) {
-	goya := []string{"0001"}
-	// Gaudi family includes Gaudi 1, Gaudi 2, and G3 PCIe Gaudi
-	gaudi := []string{"1000", "1001", "1010", "1011", "1020", "1021", "1030", "1060", "1061", "1062", "1063"}
-	greco := []string{"0020", "0030"}
-
-	switch {
-	case checkFamily(goya, deviceID):
-		return "goya", nil
-	case checkFamily(gaudi, deviceID):
-		return "gaudi", nil
-	case checkFamily(greco, deviceID):
-		return "greco", nil
-	default:
-		return "", errors.New("no habana devices on the system")
 	}
-}
-
-func checkFamily(family []string, id string) bool {
-	for _, m := range family {
-		if strings.HasSuffix(id, m) {
-			return true
-		}
+	err := callInline("hlml_event_set_wait", func() C.hlml_return_t {
+		return C.hlml_event_set_wait(es.set, &data, C.uint(timeout))
+	})
+	if errors.Is(err, ErrTimeout) && bounded {
+		// the wait is rounded down to milliseconds, so the deadline is at
+		// most a millisecond away
+		<-ctx.Done()
+		return Event{}, ctx.Err()
 	}
-	return false
-}
-
-func readIDFromFile(basePath string, deviceAddress string, property string) (string, error) {
-	data, err := os.ReadFile(filepath.Join(basePath, deviceAddress, property))
 	if err != nil {
-		return "", fmt.Errorf("could not read %s for device %s: %w", property, deviceAddress, err)
+		return Event{}, err
 	}
-	id := strings.Trim(string(data[2:]), "\n")
-	return id, nil
+	serial, _ := Device{data.device}.SerialNumberContext(ctx)
+
+	return Event{
+		Serial: serial,
+		Etype:  uint64(data.event_type),
+	}, nil
 }
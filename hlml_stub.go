@@ -0,0 +1,635 @@
+//go:build !linux || !cgo
+
+/*
+ * Copyright (c) 2022, HabanaLabs Ltd.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the Lic
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// This file stands in for hlml.go when cgo or libhlml is unavailable. It
+// exposes the same API, and every HLML call returns ErrLibraryUnavailable.
+
+package gohlml
+
+import "context"
+
+const (
+	// HlmlCriticalError indicates a critical error in the device
+	HlmlCriticalError = 1 << 1
+)
+
+// Device struct maps to C HLML structure. It carries no handle in this build
+type Device struct{}
+
+// EventSet is a cast of the C type of the hlml event set. It carries no
+// handle in this build
+type EventSet struct{}
+
+// Initialize initializes the HLML library. Calls are reference counted and
+// every successful Initialize must be paired with a Shutdown
+func Initialize() error {
+	return ErrLibraryUnavailable
+}
+
+// InitWithLogs initializes the HLML library with logging on. It is
+// InitWithLogLevel with LogLevelVerbose
+func InitWithLogs() error {
+	return ErrLibraryUnavailable
+}
+
+// InitWithLogLevel initializes the HLML library with libhlml logging at the
+// given level. The level only takes effect if this is the first reference
+// to the library
+func InitWithLogLevel(level LogLevel) error {
+	return ErrLibraryUnavailable
+}
+
+// Shutdown releases a reference taken by Initialize. HLML is shut down when
+// the last reference is released
+func Shutdown() error {
+	return ErrLibraryUnavailable
+}
+
+// Reinitialize shuts HLML down and initializes it again, the way it was
+// first initialized, without changing the reference count. It recovers the
+// library after a device reset or driver reload, and invalidates every
+// Device obtained before it
+func Reinitialize() error {
+	return ErrLibraryUnavailable
+}
+
+// Generation counts the times HLML was initialized, by the first Initialize
+// or by Reinitialize. Devices obtained under an earlier generation are
+// stale
+func Generation() uint64 {
+	return 0
+}
+
+// IsInitialized reports whether HLML is initialized
+func IsInitialized() bool {
+	return false
+}
+
+// DeviceCount gets number of Habana devices in the system
+func DeviceCount() (uint, error) {
+	return 0, ErrLibraryUnavailable
+}
+
+// DeviceCountContext is like DeviceCount but returns ctx.Err() if ctx is
+// done before the call completes
+func DeviceCountContext(ctx context.Context) (uint, error) {
+	return 0, ErrLibraryUnavailable
+}
+
+// DeviceHandleByIndex gets a handle to a particular device by index
+func DeviceHandleByIndex(idx uint) (Device, error) {
+	return Device{}, ErrLibraryUnavailable
+}
+
+// DeviceHandleByIndexContext is like DeviceHandleByIndex but returns
+// ctx.Err() if ctx is done before the call completes
+func DeviceHandleByIndexContext(ctx context.Context, idx uint) (Device, error) {
+	return Device{}, ErrLibraryUnavailable
+}
+
+// DeviceHandleByUUID gets a handle to a particular device by UUIC
+func DeviceHandleByUUID(uuid string) (Device, error) {
+	return Device{}, ErrLibraryUnavailable
+}
+
+// DeviceHandleByUUIDContext is like DeviceHandleByUUID but returns ctx.Err()
+// if ctx is done before the call completes
+func DeviceHandleByUUIDContext(ctx context.Context, uuid string) (Device, error) {
+	return Device{}, ErrLibraryUnavailable
+}
+
+// DeviceHandleBySerial gets a handle to a particular device by serial number
+func DeviceHandleBySerial(serial string) (*Device, error) {
+	return nil, ErrLibraryUnavailable
+}
+
+// DeviceHandleBySerialContext is like DeviceHandleBySerial but returns
+// ctx.Err() if ctx is done before the call completes
+func DeviceHandleBySerialContext(ctx context.Context, serial string) (*Device, error) {
+	return nil, ErrLibraryUnavailable
+}
+
+// MinorNumber returns Minor number.
+func (d Device) MinorNumber() (uint, error) {
+	return 0, ErrLibraryUnavailable
+}
+
+// MinorNumberContext is like MinorNumber but returns ctx.Err() if ctx is
+// done before the call completes
+func (d Device) MinorNumberContext(ctx context.Context) (uint, error) {
+	return 0, ErrLibraryUnavailable
+}
+
+// Name returns Device Name
+func (d Device) Name() (string, error) {
+	return "", ErrLibraryUnavailable
+}
+
+// NameContext is like Name but returns ctx.Err() if ctx is done before the
+// call completes
+func (d Device) NameContext(ctx context.Context) (string, error) {
+	return "", ErrLibraryUnavailable
+}
+
+// UUID returns the unique id for a given device
+func (d Device) UUID() (string, error) {
+	return "", ErrLibraryUnavailable
+}
+
+// UUIDContext is like UUID but returns ctx.Err() if ctx is done before the
+// call completes
+func (d Device) UUIDContext(ctx context.Context) (string, error) {
+	return "", ErrLibraryUnavailable
+}
+
+// PCIDomain returns the PCI domain for a given device
+func (d Device) PCIDomain() (uint, error) {
+	return 0, ErrLibraryUnavailable
+}
+
+// PCIDomainContext is like PCIDomain but returns ctx.Err() if ctx is done
+// before the call completes
+func (d Device) PCIDomainContext(ctx context.Context) (uint, error) {
+	return 0, ErrLibraryUnavailable
+}
+
+// PCIBus returns the PCI bus info for a given device
+func (d Device) PCIBus() (uint, error) {
+	return 0, ErrLibraryUnavailable
+}
+
+// PCIBusContext is like PCIBus but returns ctx.Err() if ctx is done before
+// the call completes
+func (d Device) PCIBusContext(ctx context.Context) (uint, error) {
+	return 0, ErrLibraryUnavailable
+}
+
+// PCIBusID returns the PCI bus id for a given device
+func (d Device) PCIBusID() (string, error) {
+	return "", ErrLibraryUnavailable
+}
+
+// PCIBusIDContext is like PCIBusID but returns ctx.Err() if ctx is done
+// before the call completes
+func (d Device) PCIBusIDContext(ctx context.Context) (string, error) {
+	return "", ErrLibraryUnavailable
+}
+
+// PCIID returns the PCI id for a given device
+func (d Device) PCIID() (uint, error) {
+	return 0, ErrLibraryUnavailable
+}
+
+// PCIIDContext is like PCIID but returns ctx.Err() if ctx is done before the
+// call completes
+func (d Device) PCIIDContext(ctx context.Context) (uint, error) {
+	return 0, ErrLibraryUnavailable
+}
+
+// PCILinkSpeed returns the current PCI link speed for a given device
+func (d Device) PCILinkSpeed() (uint, error) {
+	return 0, ErrLibraryUnavailable
+}
+
+// PCILinkSpeedContext is like PCILinkSpeed but returns ctx.Err() if ctx is
+// done before the call completes
+func (d Device) PCILinkSpeedContext(ctx context.Context) (uint, error) {
+	return 0, ErrLibraryUnavailable
+}
+
+// PCILinkWidth returns the current PCI link width for a given device
+func (d Device) PCILinkWidth() (uint, error) {
+	return 0, ErrLibraryUnavailable
+}
+
+// PCILinkWidthContext is like PCILinkWidth but returns ctx.Err() if ctx is
+// done before the call completes
+func (d Device) PCILinkWidthContext(ctx context.Context) (uint, error) {
+	return 0, ErrLibraryUnavailable
+}
+
+// MemoryInfo returns the current memory usage in bytes for total, used, free
+func (d Device) MemoryInfo() (uint64, uint64, uint64, error) {
+	return 0, 0, 0, ErrLibraryUnavailable
+}
+
+// MemoryInfoContext is like MemoryInfo but returns ctx.Err() if ctx is done
+// before the call completes
+func (d Device) MemoryInfoContext(ctx context.Context) (uint64, uint64, uint64, error) {
+	return 0, 0, 0, ErrLibraryUnavailable
+}
+
+// UtilizationInfo returns the utilization aip rate for a given device
+func (d Device) UtilizationInfo() (uint, error) {
+	return 0, ErrLibraryUnavailable
+}
+
+// UtilizationInfoContext is like UtilizationInfo but returns ctx.Err() if
+// ctx is done before the call completes
+func (d Device) UtilizationInfoContext(ctx context.Context) (uint, error) {
+	return 0, ErrLibraryUnavailable
+}
+
+// SOCClockInfo returns the SoC clock frequency for a given device
+func (d Device) SOCClockInfo() (uint, error) {
+	return 0, ErrLibraryUnavailable
+}
+
+// SOCClockInfoContext is like SOCClockInfo but returns ctx.Err() if ctx is
+// done before the call completes
+func (d Device) SOCClockInfoContext(ctx context.Context) (uint, error) {
+	return 0, ErrLibraryUnavailable
+}
+
+// SOCClockMax returns the maximum SoC clock frequency for a given device
+func (d Device) SOCClockMax() (uint, error) {
+	return 0, ErrLibraryUnavailable
+}
+
+// SOCClockMaxContext is like SOCClockMax but returns ctx.Err() if ctx is
+// done before the call completes
+func (d Device) SOCClockMaxContext(ctx context.Context) (uint, error) {
+	return 0, ErrLibraryUnavailable
+}
+
+// ICClockMax returns the maximum IC clock frequency for a given device
+func (d Device) ICClockMax() (uint, error) {
+	return 0, ErrLibraryUnavailable
+}
+
+// ICClockMaxContext is like ICClockMax but returns ctx.Err() if ctx is done
+// before the call completes
+func (d Device) ICClockMaxContext(ctx context.Context) (uint, error) {
+	return 0, ErrLibraryUnavailable
+}
+
+// MMEClockMax returns the maximum MME clock frequency for a given device
+func (d Device) MMEClockMax() (uint, error) {
+	return 0, ErrLibraryUnavailable
+}
+
+// MMEClockMaxContext is like MMEClockMax but returns ctx.Err() if ctx is
+// done before the call completes
+func (d Device) MMEClockMaxContext(ctx context.Context) (uint, error) {
+	return 0, ErrLibraryUnavailable
+}
+
+// TPCClockMax returns the maximum TPC clock frequency for a given device
+func (d Device) TPCClockMax() (uint, error) {
+	return 0, ErrLibraryUnavailable
+}
+
+// TPCClockMaxContext is like TPCClockMax but returns ctx.Err() if ctx is
+// done before the call completes
+func (d Device) TPCClockMaxContext(ctx context.Context) (uint, error) {
+	return 0, ErrLibraryUnavailable
+}
+
+// PowerUsage returns the power usage in milliwatts for a given device
+func (d Device) PowerUsage() (uint, error) {
+	return 0, ErrLibraryUnavailable
+}
+
+// PowerUsageContext is like PowerUsage but returns ctx.Err() if ctx is done
+// before the call completes
+func (d Device) PowerUsageContext(ctx context.Context) (uint, error) {
+	return 0, ErrLibraryUnavailable
+}
+
+// TemperatureOnBoard returns the temperature in celsius for a device board
+func (d Device) TemperatureOnBoard() (uint, error) {
+	return 0, ErrLibraryUnavailable
+}
+
+// TemperatureOnBoardContext is like TemperatureOnBoard but returns ctx.Err()
+// if ctx is done before the call completes
+func (d Device) TemperatureOnBoardContext(ctx context.Context) (uint, error) {
+	return 0, ErrLibraryUnavailable
+}
+
+// TemperatureOnChip returns the temperature in celsius for a the device chip
+func (d Device) TemperatureOnChip() (uint, error) {
+	return 0, ErrLibraryUnavailable
+}
+
+// TemperatureOnChipContext is like TemperatureOnChip but returns ctx.Err()
+// if ctx is done before the call completes
+func (d Device) TemperatureOnChipContext(ctx context.Context) (uint, error) {
+	return 0, ErrLibraryUnavailable
+}
+
+// TemperatureThresholdShutdown Retrieves the known temperature threshold for the AIP with the specified threshold type in degrees
+func (d Device) TemperatureThresholdShutdown() (uint, error) {
+	return 0, ErrLibraryUnavailable
+}
+
+// TemperatureThresholdShutdownContext is like TemperatureThresholdShutdown
+// but returns ctx.Err() if ctx is done before the call completes
+func (d Device) TemperatureThresholdShutdownContext(ctx context.Context) (uint, error) {
+	return 0, ErrLibraryUnavailable
+}
+
+// TemperatureThresholdSlowdown Retrieves the known temperature threshold for the AIP with the specified threshold type in degrees
+func (d Device) TemperatureThresholdSlowdown() (uint, error) {
+	return 0, ErrLibraryUnavailable
+}
+
+// TemperatureThresholdSlowdownContext is like TemperatureThresholdSlowdown
+// but returns ctx.Err() if ctx is done before the call completes
+func (d Device) TemperatureThresholdSlowdownContext(ctx context.Context) (uint, error) {
+	return 0, ErrLibraryUnavailable
+}
+
+// TemperatureThresholdMemory Retrieves the known temperature threshold for the AIP with the specified threshold type in degrees
+func (d Device) TemperatureThresholdMemory() (uint, error) {
+	return 0, ErrLibraryUnavailable
+}
+
+// TemperatureThresholdMemoryContext is like TemperatureThresholdMemory but
+// returns ctx.Err() if ctx is done before the call completes
+func (d Device) TemperatureThresholdMemoryContext(ctx context.Context) (uint, error) {
+	return 0, ErrLibraryUnavailable
+}
+
+// TemperatureThresholdGPU Retrieves the known temperature threshold for the AIP with the specified threshold type in degrees
+func (d Device) TemperatureThresholdGPU() (uint, error) {
+	return 0, ErrLibraryUnavailable
+}
+
+// TemperatureThresholdGPUContext is like TemperatureThresholdGPU but returns
+// ctx.Err() if ctx is done before the call completes
+func (d Device) TemperatureThresholdGPUContext(ctx context.Context) (uint, error) {
+	return 0, ErrLibraryUnavailable
+}
+
+// PowerManagementDefaultLimit Retrieves default power management limit on this device, in milliwatts.
+// Default power management limit is a power management limit that the device boots with.
+func (d Device) PowerManagementDefaultLimit() (uint, error) {
+	return 0, ErrLibraryUnavailable
+}
+
+// PowerManagementDefaultLimitContext is like PowerManagementDefaultLimit but
+// returns ctx.Err() if ctx is done before the call completes
+func (d Device) PowerManagementDefaultLimitContext(ctx context.Context) (uint, error) {
+	return 0, ErrLibraryUnavailable
+}
+
+// ECCMode retrieves the current and pending ECC modes for the device
+//
+//	1 - ECCMode enabled
+//	0 - ECCMode disabled
+func (d Device) ECCMode() (uint, uint, error) {
+	return 0, 0, ErrLibraryUnavailable
+}
+
+// ECCModeContext is like ECCMode but returns ctx.Err() if ctx is done before
+// the call completes
+func (d Device) ECCModeContext(ctx context.Context) (uint, uint, error) {
+	return 0, 0, ErrLibraryUnavailable
+}
+
+// HLRevision returns the revision of the HL library
+func (d Device) HLRevision() (int, error) {
+	return 0, ErrLibraryUnavailable
+}
+
+// HLRevisionContext is like HLRevision but returns ctx.Err() if ctx is done
+// before the call completes
+func (d Device) HLRevisionContext(ctx context.Context) (int, error) {
+	return 0, ErrLibraryUnavailable
+}
+
+// PCBVersion returns the PCB version
+func (d Device) PCBVersion() (string, error) {
+	return "", ErrLibraryUnavailable
+}
+
+// PCBVersionContext is like PCBVersion but returns ctx.Err() if ctx is done
+// before the call completes
+func (d Device) PCBVersionContext(ctx context.Context) (string, error) {
+	return "", ErrLibraryUnavailable
+}
+
+// PCBAssemblyVersion returns the PCB Assembly info
+func (d Device) PCBAssemblyVersion() (string, error) {
+	return "", ErrLibraryUnavailable
+}
+
+// PCBAssemblyVersionContext is like PCBAssemblyVersion but returns ctx.Err()
+// if ctx is done before the call completes
+func (d Device) PCBAssemblyVersionContext(ctx context.Context) (string, error) {
+	return "", ErrLibraryUnavailable
+}
+
+// SerialNumber returns the device serial number
+func (d Device) SerialNumber() (string, error) {
+	return "", ErrLibraryUnavailable
+}
+
+// SerialNumberContext is like SerialNumber but returns ctx.Err() if ctx is
+// done before the call completes
+func (d Device) SerialNumberContext(ctx context.Context) (string, error) {
+	return "", ErrLibraryUnavailable
+}
+
+// ModuleID returns the device moduleID
+func (d Device) ModuleID() (uint, error) {
+	return 0, ErrLibraryUnavailable
+}
+
+// ModuleIDContext is like ModuleID but returns ctx.Err() if ctx is done
+// before the call completes
+func (d Device) ModuleIDContext(ctx context.Context) (uint, error) {
+	return 0, ErrLibraryUnavailable
+}
+
+// BoardID returns an ID for the PCB board
+func (d Device) BoardID() (uint, error) {
+	return 0, ErrLibraryUnavailable
+}
+
+// BoardIDContext is like BoardID but returns ctx.Err() if ctx is done before
+// the call completes
+func (d Device) BoardIDContext(ctx context.Context) (uint, error) {
+	return 0, ErrLibraryUnavailable
+}
+
+// PCIeTX returns PCIe transmit throughput
+func (d Device) PCIeTX() (uint, error) {
+	return 0, ErrLibraryUnavailable
+}
+
+// PCIeTXContext is like PCIeTX but returns ctx.Err() if ctx is done before
+// the call completes
+func (d Device) PCIeTXContext(ctx context.Context) (uint, error) {
+	return 0, ErrLibraryUnavailable
+}
+
+// PCIeRX returns PCIe receive throughput
+func (d Device) PCIeRX() (uint, error) {
+	return 0, ErrLibraryUnavailable
+}
+
+// PCIeRXContext is like PCIeRX but returns ctx.Err() if ctx is done before
+// the call completes
+func (d Device) PCIeRXContext(ctx context.Context) (uint, error) {
+	return 0, ErrLibraryUnavailable
+}
+
+// PCIReplayCounter returns PCIe replay count
+func (d Device) PCIReplayCounter() (uint, error) {
+	return 0, ErrLibraryUnavailable
+}
+
+// PCIReplayCounterContext is like PCIReplayCounter but returns ctx.Err() if
+// ctx is done before the call completes
+func (d Device) PCIReplayCounterContext(ctx context.Context) (uint, error) {
+	return 0, ErrLibraryUnavailable
+}
+
+// PCIeLinkGeneration returns PCIe replay count
+// MUST run with SUDO/priviledged
+func (d Device) PCIeLinkGeneration() (uint, error) {
+	return 0, ErrLibraryUnavailable
+}
+
+// PCIeLinkGenerationContext is like PCIeLinkGeneration but returns ctx.Err()
+// if ctx is done before the call completes
+func (d Device) PCIeLinkGenerationContext(ctx context.Context) (uint, error) {
+	return 0, ErrLibraryUnavailable
+}
+
+// PCIeLinkWidth returns PCIe link width
+func (d Device) PCIeLinkWidth() (uint, error) {
+	return 0, ErrLibraryUnavailable
+}
+
+// PCIeLinkWidthContext is like PCIeLinkWidth but returns ctx.Err() if ctx is
+// done before the call completes
+func (d Device) PCIeLinkWidthContext(ctx context.Context) (uint, error) {
+	return 0, ErrLibraryUnavailable
+}
+
+// ClockThrottleReasons returns current clock throttle reasons
+func (d Device) ClockThrottleReasons() (uint64, error) {
+	return 0, ErrLibraryUnavailable
+}
+
+// ClockThrottleReasonsContext is like ClockThrottleReasons but returns
+// ctx.Err() if ctx is done before the call completes
+func (d Device) ClockThrottleReasonsContext(ctx context.Context) (uint64, error) {
+	return 0, ErrLibraryUnavailable
+}
+
+// EnergyConsumptionCounter returns energy consumption
+func (d Device) EnergyConsumptionCounter() (uint64, error) {
+	return 0, ErrLibraryUnavailable
+}
+
+// EnergyConsumptionCounterContext is like EnergyConsumptionCounter but
+// returns ctx.Err() if ctx is done before the call completes
+func (d Device) EnergyConsumptionCounterContext(ctx context.Context) (uint64, error) {
+	return 0, ErrLibraryUnavailable
+}
+
+// MacAddressInfo retrieves the masks for supported ports and external ports.
+func (d Device) MacAddressInfo() (map[int]string, error) {
+	return nil, ErrLibraryUnavailable
+}
+
+// MacAddressInfoContext is like MacAddressInfo but returns ctx.Err() if ctx
+// is done before the call completes
+func (d Device) MacAddressInfoContext(ctx context.Context) (map[int]string, error) {
+	return nil, ErrLibraryUnavailable
+}
+
+// NicLinkStatus gets a port and checks its status.
+// return 1 (up) or 0 (down)
+func (d Device) NicLinkStatus(port uint) (uint, error) {
+	return 0, ErrLibraryUnavailable
+}
+
+// NicLinkStatusContext is like NicLinkStatus but returns ctx.Err() if ctx is
+// done before the call completes
+func (d Device) NicLinkStatusContext(ctx context.Context, port uint) (uint, error) {
+	return 0, ErrLibraryUnavailable
+}
+
+// ReplacedRowDoubleBitECC returns the number of rows with double-bit ecc errors
+func (d Device) ReplacedRowDoubleBitECC() (uint, error) {
+	return 0, ErrLibraryUnavailable
+}
+
+// ReplacedRowDoubleBitECCContext is like ReplacedRowDoubleBitECC but returns
+// ctx.Err() if ctx is done before the call completes
+func (d Device) ReplacedRowDoubleBitECCContext(ctx context.Context) (uint, error) {
+	return 0, ErrLibraryUnavailable
+}
+
+// ReplacedRowSingleBitECC returns the number of rows with single-bit ecc errors
+func (d Device) ReplacedRowSingleBitECC() (uint, error) {
+	return 0, ErrLibraryUnavailable
+}
+
+// ReplacedRowSingleBitECCContext is like ReplacedRowSingleBitECC but returns
+// ctx.Err() if ctx is done before the call completes
+func (d Device) ReplacedRowSingleBitECCContext(ctx context.Context) (uint, error) {
+	return 0, ErrLibraryUnavailable
+}
+
+// IsReplacedRowsPendingStatus return 0 (false) or 1 (true) if there are any
+// rows need of replacement in a power cycle
+func (d Device) IsReplacedRowsPendingStatus() (int, error) {
+	return 0, ErrLibraryUnavailable
+}
+
+// IsReplacedRowsPendingStatusContext is like IsReplacedRowsPendingStatus but
+// returns ctx.Err() if ctx is done before the call completes
+func (d Device) IsReplacedRowsPendingStatusContext(ctx context.Context) (int, error) {
+	return 0, ErrLibraryUnavailable
+}
+
+func NewEventSet() EventSet {
+	return EventSet{}
+}
+
+func RegisterEventForDevice(es EventSet, event int, uuid string) error {
+	return ErrLibraryUnavailable
+}
+
+// RegisterEventForDeviceContext is like RegisterEventForDevice but returns
+// ctx.Err() if ctx is done before the call completes
+func RegisterEventForDeviceContext(ctx context.Context, es EventSet, event int, uuid string) error {
+	return ErrLibraryUnavailable
+}
+
+func DeleteEventSet(es EventSet) {}
+
+// WaitForEvent waits up to timeout milliseconds for an event. It fails with
+// ErrTimeout when no event arrived in time. Shutdown blocks until a pending
+// wait returns
+func WaitForEvent(es EventSet, timeout uint) (Event, error) {
+	return Event{}, ErrLibraryUnavailable
+}
+
+// WaitForEventContext is like WaitForEvent but waits no longer than the
+// deadline of ctx, returning ctx.Err() once it passes. The wait runs on the
+// calling goroutine, so it does not hold up other calls on the worker
+func WaitForEventContext(ctx context.Context, es EventSet, timeout uint) (Event, error) {
+	return Event{}, ErrLibraryUnavailable
+}
@@ -0,0 +1,65 @@
+//go:build !linux || !cgo
+
+/*
+ * Copyright (c) 2022, HabanaLabs Ltd.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the Lic
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package gohlml
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStubReturnsLibraryUnavailable(t *testing.T) {
+	err := Initialize()
+	assert.True(t, errors.Is(err, ErrLibraryUnavailable), err)
+
+	_, err = DeviceCount()
+	assert.True(t, errors.Is(err, ErrLibraryUnavailable), err)
+
+	_, err = DeviceHandleByIndex(0)
+	assert.True(t, errors.Is(err, ErrLibraryUnavailable), err)
+
+	_, err = Device{}.PowerUsage()
+	assert.True(t, errors.Is(err, ErrLibraryUnavailable), err)
+
+	_, err = WaitForEvent(NewEventSet(), 0)
+	assert.True(t, errors.Is(err, ErrLibraryUnavailable), err)
+
+	node, err := Device{}.NumaNode()
+	assert.Nil(t, node)
+	assert.True(t, errors.Is(err, ErrLibraryUnavailable), err)
+
+	err = Shutdown()
+	assert.True(t, errors.Is(err, ErrLibraryUnavailable), err)
+}
+
+func TestStubNumaNodeByBusID(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "bus", "pci", "devices", "0000:4d:00.0")
+	assert.Nil(t, os.MkdirAll(dir, 0o755))
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "numa_node"), []byte("1\n"), 0o644))
+	useSysfsRoot(t, root)
+
+	node, err := NumaNodeByBusID("0000:4D:00.0")
+	assert.Nil(t, err, err)
+	if assert.NotNil(t, node) {
+		assert.Equal(t, uint(1), *node)
+	}
+}
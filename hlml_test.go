@@ -1,3 +1,5 @@
+//go:build linux && cgo
+
 /*
  * Copyright (c) 2022, HabanaLabs Ltd.  All rights reserved.
  *
@@ -17,6 +19,7 @@ package gohlml
 
 import (
 	"log"
+	"sync"
 	"testing"
 	"time"
 
@@ -46,7 +49,7 @@ func TestRedundantInitialize(t *testing.T) {
 	assert.Nil(t, err, err)
 
 	err = Initialize()
-	assert.NotNil(t, err, err)
+	assert.Nil(t, err, "Initialize should be reference counted")
 
 	cnt, err := DeviceCount()
 
@@ -55,6 +58,39 @@ func TestRedundantInitialize(t *testing.T) {
 
 	err = Shutdown()
 	assert.Nil(t, err, err)
+	assert.True(t, IsInitialized(), "HLML should stay initialized while referenced")
+
+	_, err = DeviceCount()
+	assert.Nil(t, err, "Error should not be raised when HLML is still referenced")
+
+	err = Shutdown()
+	assert.Nil(t, err, err)
+	assert.False(t, IsInitialized(), "HLML should be shut down by the last Shutdown")
+
+	_, err = DeviceCount()
+	assert.ErrorIs(t, err, ErrNotIntialized)
+
+	err = Shutdown()
+	assert.ErrorIs(t, err, ErrNotIntialized, "Unbalanced Shutdown should fail")
+}
+
+func TestConcurrentInitialize(t *testing.T) {
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				assert.Nil(t, Initialize())
+				_, err := DeviceCount()
+				assert.Nil(t, err, err)
+				assert.Nil(t, Shutdown())
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.False(t, IsInitialized(), "Balanced calls should leave HLML shut down")
 }
 
 func TestInitWithLogs(t *testing.T) {
@@ -556,7 +592,12 @@ func TestMacAddressInfo(t *testing.T) {
 	ports, err := dev.MacAddressInfo()
 	printDuration("MacAddressInfo()", time.Since(start))
 	assert.Nil(t, err, "Should be able to get MacAddress info")
-	assert.Equal(t, len(ports), int(10), "there should be 10 ports in a device")
+
+	id, err := dev.PCIID()
+	assert.Nil(t, err, err)
+	model, ok := LookupPCIID(id)
+	assert.True(t, ok, "device id %#x is missing from the catalog", id)
+	assert.Equal(t, model.NICPorts, len(ports), "%s should have %d ports", model.Name, model.NICPorts)
 
 	err = Shutdown()
 	assert.Nil(t, err, err)
@@ -649,36 +690,3 @@ func prepareDevice(t *testing.T) Device {
 	assert.Nil(t, err, "Should be able to get device handle")
 	return dev
 }
-
-func TestGetDeviceFamily(t *testing.T) {
-	tests := []struct {
-		name          string
-		deviceName    string
-		deviceID      string
-		errorExpected bool
-	}{
-		{name: "Get Guadi 1 device", errorExpected: false, deviceName: "gaudi", deviceID: "1000"},
-		{name: "Get Guadi 2 device", errorExpected: false, deviceName: "gaudi", deviceID: "1020"},
-		{name: "Get Greco device", errorExpected: false, deviceName: "greco", deviceID: "0020"},
-		{name: "Get Goya device", errorExpected: false, deviceName: "goya", deviceID: "0001"},
-		{name: "No device matched", errorExpected: true, deviceName: "", deviceID: "9999"},
-	}
-
-	for _, tc := range tests {
-		pciBasePath = tc.deviceID
-		t.Run(tc.name, func(t *testing.T) {
-			d, err := getDeviceName(tc.deviceID)
-			if !tc.errorExpected && err != nil {
-				t.Fatalf("expected no error, got %v", err)
-			}
-
-			if tc.errorExpected && err == nil {
-				t.Errorf("expected error, got none")
-			}
-
-			if d != tc.deviceName {
-				t.Errorf("expected device %q, got %q", tc.deviceName, d)
-			}
-		})
-	}
-}
@@ -0,0 +1,200 @@
+/*
+ * Copyright (c) 2022, HabanaLabs Ltd.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the Lic
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gohlml
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// SensorKind is the quantity a hwmon channel measures
+type SensorKind int
+
+const (
+	// SensorTemperature channels are temp*, in degrees Celsius
+	SensorTemperature SensorKind = iota + 1
+	// SensorVoltage channels are in*, in volts
+	SensorVoltage
+	// SensorCurrent channels are curr*, in amperes
+	SensorCurrent
+	// SensorPower channels are power*, in watts
+	SensorPower
+	// SensorFan channels are fan*, in revolutions per minute
+	SensorFan
+)
+
+// sensorKinds maps the hwmon channel prefix to its kind, unit and the
+// divisor converting the raw value to that unit
+var sensorKinds = map[string]struct {
+	kind  SensorKind
+	unit  string
+	scale float64
+}{
+	"temp":  {SensorTemperature, "C", 1e3},
+	"in":    {SensorVoltage, "V", 1e3},
+	"curr":  {SensorCurrent, "A", 1e3},
+	"power": {SensorPower, "W", 1e6},
+	"fan":   {SensorFan, "RPM", 1},
+}
+
+var sensorKindNames = map[SensorKind]string{
+	SensorTemperature: "temperature",
+	SensorVoltage:     "voltage",
+	SensorCurrent:     "current",
+	SensorPower:       "power",
+	SensorFan:         "fan",
+}
+
+func (k SensorKind) String() string {
+	if name, ok := sensorKindNames[k]; ok {
+		return name
+	}
+	return fmt.Sprintf("SensorKind(%d)", int(k))
+}
+
+// HwmonSensor is a channel of the hwmon instance of a device
+type HwmonSensor struct {
+	// Channel is the hwmon channel name, e.g. in3
+	Channel string
+	// Label is the channel label, empty if the driver sets none
+	Label string
+	Kind  SensorKind
+	// Unit is the SI unit of Value, Crit and Max
+	Unit  string
+	Value float64
+	// Crit and Max are the channel limits, nil if the driver sets none
+	Crit *float64
+	Max  *float64
+	// Err is set if the value could not be read, e.g. while the device is
+	// in reset
+	Err error
+}
+
+// hwmonInput matches the value attribute of a channel. Power channels
+// expose either an instantaneous or an averaged value
+var hwmonInput = regexp.MustCompile(`^(temp|in|curr|power|fan)([0-9]+)_(input|average)$`)
+
+// HwmonSensors returns every temperature, voltage, current, power and fan
+// channel of the hwmon instance of the PCI device. Values are converted to
+// SI units
+func (d Device) HwmonSensors() ([]HwmonSensor, error) {
+	return d.HwmonSensorsContext(context.Background())
+}
+
+// HwmonSensorsContext is like HwmonSensors but returns ctx.Err() if ctx is
+// done before the PCI bus id is resolved
+func (d Device) HwmonSensorsContext(ctx context.Context) ([]HwmonSensor, error) {
+	busID, err := d.PCIBusIDContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return hwmonSensors(busID)
+}
+
+// hwmonDir returns the hwmon instance of the PCI device with the given bus id
+func hwmonDir(busID string) (string, error) {
+	matches, err := filepath.Glob(sysfsPath("bus", "pci", "devices", strings.ToLower(busID), "hwmon", "hwmon*"))
+	if err != nil {
+		return "", err
+	}
+	if len(matches) == 0 {
+		return "", fmt.Errorf("hwmon of %s: %w", busID, ErrNotFound)
+	}
+	return matches[0], nil
+}
+
+func hwmonSensors(busID string) ([]HwmonSensor, error) {
+	dir, err := hwmonDir(busID)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	type channel struct {
+		prefix string
+		n      int
+	}
+	var channels []channel
+	inputs := map[channel]string{}
+	for _, e := range entries {
+		m := hwmonInput.FindStringSubmatch(e.Name())
+		if m == nil {
+			continue
+		}
+		n, _ := strconv.Atoi(m[2])
+		c := channel{m[1], n}
+		_, ok := inputs[c]
+		if !ok {
+			channels = append(channels, c)
+		}
+		// prefer the instantaneous power value over the average
+		if !ok || m[3] == "input" {
+			inputs[c] = e.Name()
+		}
+	}
+	sort.Slice(channels, func(i, j int) bool {
+		ki, kj := sensorKinds[channels[i].prefix].kind, sensorKinds[channels[j].prefix].kind
+		if ki != kj {
+			return ki < kj
+		}
+		return channels[i].n < channels[j].n
+	})
+
+	sensors := make([]HwmonSensor, 0, len(channels))
+	for _, c := range channels {
+		k := sensorKinds[c.prefix]
+		name := c.prefix + strconv.Itoa(c.n)
+		s := HwmonSensor{Channel: name, Kind: k.kind, Unit: k.unit}
+
+		if label, ok, err := readAttr(dir, name+"_label"); err == nil && ok {
+			s.Label = label
+		}
+		s.Value, s.Err = readSensorValue(dir, inputs[c], k.scale)
+		for suffix, p := range map[string]**float64{"_crit": &s.Crit, "_max": &s.Max} {
+			if v, err := readSensorValue(dir, name+suffix, k.scale); err == nil {
+				*p = &v
+			}
+		}
+		sensors = append(sensors, s)
+	}
+	return sensors, nil
+}
+
+// readSensorValue reads a hwmon attribute and divides it by scale
+func readSensorValue(dir, name string, scale float64) (float64, error) {
+	v, ok, err := readAttr(dir, name)
+	if err != nil {
+		return 0, err
+	}
+	if !ok {
+		return 0, fmt.Errorf("%s: %w", name, os.ErrNotExist)
+	}
+	raw, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return float64(raw) / scale, nil
+}
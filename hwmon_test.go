@@ -0,0 +1,69 @@
+/*
+ * Copyright (c) 2022, HabanaLabs Ltd.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the Lic
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gohlml
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHwmonSensors(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "bus", "pci", "devices", "0000:33:00.0", "hwmon", "hwmon4")
+	assert.Nil(t, os.MkdirAll(dir, 0o755))
+	for name, v := range map[string]string{
+		"name":           "habanalabs",
+		"temp1_input":    "45000",
+		"temp1_label":    "Board temperature",
+		"temp1_crit":     "105000",
+		"temp1_max":      "95000",
+		"temp2_input":    "51500",
+		"in10_input":     "850",
+		"in10_label":     "Vcore",
+		"in2_input":      "12100",
+		"in2_max":        "13200",
+		"curr1_input":    "25500",
+		"power1_input":   "275000000",
+		"power1_average": "250000000",
+		"power2_average": "90000000",
+		"fan1_input":     "3200",
+	} {
+		assert.Nil(t, os.WriteFile(filepath.Join(dir, name), []byte(v+"\n"), 0o644))
+	}
+	useSysfsRoot(t, root)
+
+	f := func(v float64) *float64 { return &v }
+	sensors, err := hwmonSensors("0000:33:00.0")
+	assert.Nil(t, err)
+	assert.Equal(t, []HwmonSensor{
+		{Channel: "temp1", Label: "Board temperature", Kind: SensorTemperature, Unit: "C", Value: 45, Crit: f(105), Max: f(95)},
+		{Channel: "temp2", Kind: SensorTemperature, Unit: "C", Value: 51.5},
+		{Channel: "in2", Kind: SensorVoltage, Unit: "V", Value: 12.1, Max: f(13.2)},
+		{Channel: "in10", Label: "Vcore", Kind: SensorVoltage, Unit: "V", Value: 0.85},
+		{Channel: "curr1", Kind: SensorCurrent, Unit: "A", Value: 25.5},
+		{Channel: "power1", Kind: SensorPower, Unit: "W", Value: 275},
+		{Channel: "power2", Kind: SensorPower, Unit: "W", Value: 90},
+		{Channel: "fan1", Kind: SensorFan, Unit: "RPM", Value: 3200},
+	}, sensors)
+
+	_, err = hwmonSensors("0000:34:00.0")
+	assert.True(t, errors.Is(err, ErrNotFound), err)
+}
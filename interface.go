@@ -0,0 +1,346 @@
+/*
+ * Copyright (c) 2022, HabanaLabs Ltd.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the Lic
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gohlml
+
+import "context"
+
+//go:generate go run ./internal/mockgen -in interface.go -out mock/hlml.go
+
+// Interface is the library level HLML API. It lets consumers replace the
+// real bindings, for example with the mock package, in unit tests. It holds
+// every package function that queries HLML, sysfs or procfs, while pure
+// helpers such as LookupDeviceModel and settings such as SetSysfsRoot stay
+// package functions only
+type Interface interface {
+	Initialize() error
+	InitWithLogs() error
+	InitWithLogLevel(level LogLevel) error
+	Shutdown() error
+	IsInitialized() bool
+	Reinitialize() error
+	Generation() uint64
+	DeviceCount() (uint, error)
+	DeviceCountContext(ctx context.Context) (uint, error)
+	DeviceHandleByIndex(idx uint) (DeviceInterface, error)
+	DeviceHandleByIndexContext(ctx context.Context, idx uint) (DeviceInterface, error)
+	DeviceHandleByUUID(uuid string) (DeviceInterface, error)
+	DeviceHandleByUUIDContext(ctx context.Context, uuid string) (DeviceInterface, error)
+	DeviceHandleBySerial(serial string) (DeviceInterface, error)
+	DeviceHandleBySerialContext(ctx context.Context, serial string) (DeviceInterface, error)
+	FWVersion(idx uint) (kernel string, uboot string, err error)
+	SystemDriverVersion() (string, error)
+	GetDeviceTypeName() (string, error)
+	NewEventSet() EventSet
+	RegisterEventForDevice(es EventSet, event int, uuid string) error
+	RegisterEventForDeviceContext(ctx context.Context, es EventSet, event int, uuid string) error
+	DeleteEventSet(es EventSet)
+	WaitForEvent(es EventSet, timeout uint) (Event, error)
+	WaitForEventContext(ctx context.Context, es EventSet, timeout uint) (Event, error)
+	SnapshotAll(ctx context.Context, parallelism int) ([]Snapshot, error)
+	FirmwareVersions(dev DeviceInterface) (map[string]string, error)
+	FirmwareVersionsContext(ctx context.Context, dev DeviceInterface) (map[string]string, error)
+	Discover() ([]PCIDevice, error)
+	NumaNodeByBusID(busID string) (*uint, error)
+	ModuleInfo() (KernelModule, error)
+	ReadAccelSysfs(minor uint) (AccelSysfs, error)
+	ReadEEPROM(minor uint) (EEPROM, error)
+	Owners(ctx context.Context, devices []DeviceInterface) ([]DeviceOwner, error)
+	WatchDevices(ctx context.Context, cfg WatcherConfig) (<-chan HotplugEvent, error)
+	Stats() map[string]CallStats
+	WorkerStatus() WorkerState
+}
+
+// DeviceInterface is the per device HLML API implemented by Device
+type DeviceInterface interface {
+	MinorNumber() (uint, error)
+	MinorNumberContext(ctx context.Context) (uint, error)
+	Name() (string, error)
+	NameContext(ctx context.Context) (string, error)
+	UUID() (string, error)
+	UUIDContext(ctx context.Context) (string, error)
+	PCIDomain() (uint, error)
+	PCIDomainContext(ctx context.Context) (uint, error)
+	PCIBus() (uint, error)
+	PCIBusContext(ctx context.Context) (uint, error)
+	PCIBusID() (string, error)
+	PCIBusIDContext(ctx context.Context) (string, error)
+	PCIID() (uint, error)
+	PCIIDContext(ctx context.Context) (uint, error)
+	PCILinkSpeed() (uint, error)
+	PCILinkSpeedContext(ctx context.Context) (uint, error)
+	PCILinkWidth() (uint, error)
+	PCILinkWidthContext(ctx context.Context) (uint, error)
+	MemoryInfo() (total uint64, used uint64, free uint64, err error)
+	MemoryInfoContext(ctx context.Context) (total uint64, used uint64, free uint64, err error)
+	UtilizationInfo() (uint, error)
+	UtilizationInfoContext(ctx context.Context) (uint, error)
+	SOCClockInfo() (uint, error)
+	SOCClockInfoContext(ctx context.Context) (uint, error)
+	SOCClockMax() (uint, error)
+	SOCClockMaxContext(ctx context.Context) (uint, error)
+	ICClockMax() (uint, error)
+	ICClockMaxContext(ctx context.Context) (uint, error)
+	MMEClockMax() (uint, error)
+	MMEClockMaxContext(ctx context.Context) (uint, error)
+	TPCClockMax() (uint, error)
+	TPCClockMaxContext(ctx context.Context) (uint, error)
+	PowerUsage() (uint, error)
+	PowerUsageContext(ctx context.Context) (uint, error)
+	TemperatureOnBoard() (uint, error)
+	TemperatureOnBoardContext(ctx context.Context) (uint, error)
+	TemperatureOnChip() (uint, error)
+	TemperatureOnChipContext(ctx context.Context) (uint, error)
+	TemperatureThresholdShutdown() (uint, error)
+	TemperatureThresholdShutdownContext(ctx context.Context) (uint, error)
+	TemperatureThresholdSlowdown() (uint, error)
+	TemperatureThresholdSlowdownContext(ctx context.Context) (uint, error)
+	TemperatureThresholdMemory() (uint, error)
+	TemperatureThresholdMemoryContext(ctx context.Context) (uint, error)
+	TemperatureThresholdGPU() (uint, error)
+	TemperatureThresholdGPUContext(ctx context.Context) (uint, error)
+	PowerManagementDefaultLimit() (uint, error)
+	PowerManagementDefaultLimitContext(ctx context.Context) (uint, error)
+	ECCMode() (current uint, pending uint, err error)
+	ECCModeContext(ctx context.Context) (current uint, pending uint, err error)
+	HLRevision() (int, error)
+	HLRevisionContext(ctx context.Context) (int, error)
+	PCBVersion() (string, error)
+	PCBVersionContext(ctx context.Context) (string, error)
+	PCBAssemblyVersion() (string, error)
+	PCBAssemblyVersionContext(ctx context.Context) (string, error)
+	SerialNumber() (string, error)
+	SerialNumberContext(ctx context.Context) (string, error)
+	ModuleID() (uint, error)
+	ModuleIDContext(ctx context.Context) (uint, error)
+	BoardID() (uint, error)
+	BoardIDContext(ctx context.Context) (uint, error)
+	PCIeTX() (uint, error)
+	PCIeTXContext(ctx context.Context) (uint, error)
+	PCIeRX() (uint, error)
+	PCIeRXContext(ctx context.Context) (uint, error)
+	PCIReplayCounter() (uint, error)
+	PCIReplayCounterContext(ctx context.Context) (uint, error)
+	PCIeLinkGeneration() (uint, error)
+	PCIeLinkGenerationContext(ctx context.Context) (uint, error)
+	PCIeLinkWidth() (uint, error)
+	PCIeLinkWidthContext(ctx context.Context) (uint, error)
+	ClockThrottleReasons() (uint64, error)
+	ClockThrottleReasonsContext(ctx context.Context) (uint64, error)
+	EnergyConsumptionCounter() (uint64, error)
+	EnergyConsumptionCounterContext(ctx context.Context) (uint64, error)
+	MacAddressInfo() (map[int]string, error)
+	MacAddressInfoContext(ctx context.Context) (map[int]string, error)
+	NicLinkStatus(port uint) (uint, error)
+	NicLinkStatusContext(ctx context.Context, port uint) (uint, error)
+	ReplacedRowDoubleBitECC() (uint, error)
+	ReplacedRowDoubleBitECCContext(ctx context.Context) (uint, error)
+	ReplacedRowSingleBitECC() (uint, error)
+	ReplacedRowSingleBitECCContext(ctx context.Context) (uint, error)
+	IsReplacedRowsPendingStatus() (int, error)
+	IsReplacedRowsPendingStatusContext(ctx context.Context) (int, error)
+	NumaNode() (*uint, error)
+	NumaNodeContext(ctx context.Context) (*uint, error)
+	HwmonSensors() ([]HwmonSensor, error)
+	HwmonSensorsContext(ctx context.Context) ([]HwmonSensor, error)
+	DeviceNodes() ([]DeviceNode, error)
+	DeviceNodesContext(ctx context.Context) ([]DeviceNode, error)
+	Processes() ([]Process, error)
+	ProcessesContext(ctx context.Context) ([]Process, error)
+	Snapshot() Snapshot
+	SnapshotContext(ctx context.Context) Snapshot
+}
+
+var (
+	_ Interface       = library{}
+	_ DeviceInterface = Device{}
+)
+
+// New returns the default Interface, which delegates to the package level
+// functions
+func New() Interface {
+	return library{}
+}
+
+type library struct{}
+
+func (library) Initialize() error {
+	return Initialize()
+}
+
+func (library) InitWithLogs() error {
+	return InitWithLogs()
+}
+
+func (library) InitWithLogLevel(level LogLevel) error {
+	return InitWithLogLevel(level)
+}
+
+func (library) Shutdown() error {
+	return Shutdown()
+}
+
+func (library) IsInitialized() bool {
+	return IsInitialized()
+}
+
+func (library) Reinitialize() error {
+	return Reinitialize()
+}
+
+func (library) Generation() uint64 {
+	return Generation()
+}
+
+func (library) DeviceCount() (uint, error) {
+	return DeviceCount()
+}
+
+func (library) DeviceHandleByIndex(idx uint) (DeviceInterface, error) {
+	dev, err := DeviceHandleByIndex(idx)
+	if err != nil {
+		return nil, err
+	}
+	return dev, nil
+}
+
+func (library) DeviceHandleByUUID(uuid string) (DeviceInterface, error) {
+	dev, err := DeviceHandleByUUID(uuid)
+	if err != nil {
+		return nil, err
+	}
+	return dev, nil
+}
+
+func (library) DeviceHandleBySerial(serial string) (DeviceInterface, error) {
+	dev, err := DeviceHandleBySerial(serial)
+	if err != nil {
+		return nil, err
+	}
+	return *dev, nil
+}
+
+func (library) FWVersion(idx uint) (string, string, error) {
+	return FWVersion(idx)
+}
+
+func (library) SystemDriverVersion() (string, error) {
+	return SystemDriverVersion()
+}
+
+func (library) GetDeviceTypeName() (string, error) {
+	return GetDeviceTypeName()
+}
+
+func (library) NewEventSet() EventSet {
+	return NewEventSet()
+}
+
+func (library) RegisterEventForDevice(es EventSet, event int, uuid string) error {
+	return RegisterEventForDevice(es, event, uuid)
+}
+
+func (library) DeleteEventSet(es EventSet) {
+	DeleteEventSet(es)
+}
+
+func (library) WaitForEvent(es EventSet, timeout uint) (Event, error) {
+	return WaitForEvent(es, timeout)
+}
+
+func (library) DeviceCountContext(ctx context.Context) (uint, error) {
+	return DeviceCountContext(ctx)
+}
+
+func (library) DeviceHandleByIndexContext(ctx context.Context, idx uint) (DeviceInterface, error) {
+	dev, err := DeviceHandleByIndexContext(ctx, idx)
+	if err != nil {
+		return nil, err
+	}
+	return dev, nil
+}
+
+func (library) DeviceHandleByUUIDContext(ctx context.Context, uuid string) (DeviceInterface, error) {
+	dev, err := DeviceHandleByUUIDContext(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+	return dev, nil
+}
+
+func (library) DeviceHandleBySerialContext(ctx context.Context, serial string) (DeviceInterface, error) {
+	dev, err := DeviceHandleBySerialContext(ctx, serial)
+	if err != nil {
+		return nil, err
+	}
+	return *dev, nil
+}
+
+func (library) RegisterEventForDeviceContext(ctx context.Context, es EventSet, event int, uuid string) error {
+	return RegisterEventForDeviceContext(ctx, es, event, uuid)
+}
+
+func (library) WaitForEventContext(ctx context.Context, es EventSet, timeout uint) (Event, error) {
+	return WaitForEventContext(ctx, es, timeout)
+}
+
+func (library) SnapshotAll(ctx context.Context, parallelism int) ([]Snapshot, error) {
+	return SnapshotAll(ctx, parallelism)
+}
+
+func (library) FirmwareVersions(dev DeviceInterface) (map[string]string, error) {
+	return FirmwareVersions(dev)
+}
+
+func (library) FirmwareVersionsContext(ctx context.Context, dev DeviceInterface) (map[string]string, error) {
+	return FirmwareVersionsContext(ctx, dev)
+}
+
+func (library) Discover() ([]PCIDevice, error) {
+	return Discover()
+}
+
+func (library) NumaNodeByBusID(busID string) (*uint, error) {
+	return NumaNodeByBusID(busID)
+}
+
+func (library) ModuleInfo() (KernelModule, error) {
+	return ModuleInfo()
+}
+
+func (library) ReadAccelSysfs(minor uint) (AccelSysfs, error) {
+	return ReadAccelSysfs(minor)
+}
+
+func (library) ReadEEPROM(minor uint) (EEPROM, error) {
+	return ReadEEPROM(minor)
+}
+
+func (library) Owners(ctx context.Context, devices []DeviceInterface) ([]DeviceOwner, error) {
+	return Owners(ctx, devices)
+}
+
+func (library) WatchDevices(ctx context.Context, cfg WatcherConfig) (<-chan HotplugEvent, error) {
+	return WatchDevices(ctx, cfg)
+}
+
+func (library) Stats() map[string]CallStats {
+	return Stats()
+}
+
+func (library) WorkerStatus() WorkerState {
+	return WorkerStatus()
+}
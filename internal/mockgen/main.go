@@ -0,0 +1,302 @@
+/*
+ * Copyright (c) 2022, HabanaLabs Ltd.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the Lic
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Command mockgen writes call-recording mocks for the interfaces declared in
+// a gohlml source file. It is run through go generate from the package root.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	in         = flag.String("in", "interface.go", "source file declaring the interfaces")
+	out        = flag.String("out", "mock/hlml.go", "generated file")
+	pkg        = flag.String("pkg", "mock", "package name of the generated file")
+	importPath = flag.String("import", "github.com/HabanaAI/gohlml", "import path of the source package")
+)
+
+type param struct {
+	name     string
+	typ      string
+	variadic bool
+}
+
+type method struct {
+	name    string
+	params  []param
+	results []string
+}
+
+type mockType struct {
+	name  string
+	iface string
+	meths []method
+}
+
+func main() {
+	flag.Parse()
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, *in, nil, 0)
+	if err != nil {
+		log.Fatalf("parse %s: %v", *in, err)
+	}
+	srcPkg := file.Name.Name
+
+	imports := map[string]string{"sync": "sync", *importPath: srcPkg}
+	for _, imp := range file.Imports {
+		p, _ := strconv.Unquote(imp.Path.Value)
+		name := p[strings.LastIndex(p, "/")+1:]
+		if imp.Name != nil {
+			name = imp.Name.Name
+		}
+		imports[p] = name
+	}
+
+	var mocks []mockType
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			ts := spec.(*ast.TypeSpec)
+			it, ok := ts.Type.(*ast.InterfaceType)
+			if !ok || !ts.Name.IsExported() {
+				continue
+			}
+			m := mockType{name: mockName(ts.Name.Name), iface: srcPkg + "." + ts.Name.Name}
+			for _, field := range it.Methods.List {
+				ft, ok := field.Type.(*ast.FuncType)
+				if !ok {
+					log.Fatalf("%s: embedded interfaces are not supported", ts.Name.Name)
+				}
+				m.meths = append(m.meths, newMethod(srcPkg, field.Names[0].Name, ft))
+			}
+			sort.Slice(m.meths, func(i, j int) bool { return m.meths[i].name < m.meths[j].name })
+			mocks = append(mocks, m)
+		}
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by internal/mockgen from %s; DO NOT EDIT.\n\n", *in)
+	fmt.Fprintf(&buf, "// Package %s provides call-recording mocks of the %s interfaces.\n", *pkg, srcPkg)
+	fmt.Fprintf(&buf, "// Every method returns zero values unless its Func field is set.\n")
+	fmt.Fprintf(&buf, "//\n// The package imports %s, which links against libhlml when built with cgo\n", srcPkg)
+	fmt.Fprintf(&buf, "// on Linux. Build tests that use it with CGO_ENABLED=0 where libhlml is\n")
+	fmt.Fprintf(&buf, "// not installed.\n")
+	fmt.Fprintf(&buf, "package %s\n\nimport (\n", *pkg)
+	paths := make([]string, 0, len(imports))
+	for p := range imports {
+		paths = append(paths, p)
+	}
+	sort.Slice(paths, func(i, j int) bool {
+		if std(paths[i]) != std(paths[j]) {
+			return std(paths[i])
+		}
+		return paths[i] < paths[j]
+	})
+	for i, p := range paths {
+		if i > 0 && std(p) != std(paths[i-1]) {
+			buf.WriteString("\n")
+		}
+		fmt.Fprintf(&buf, "\t%q\n", p)
+	}
+	buf.WriteString(")\n\n")
+	for _, m := range mocks {
+		writeMock(&buf, m)
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatalf("format generated code: %v\n%s", err, buf.String())
+	}
+	if err := os.WriteFile(*out, src, 0o644); err != nil {
+		log.Fatalf("write %s: %v", *out, err)
+	}
+}
+
+// std reports whether an import path belongs to the standard library
+func std(path string) bool {
+	return !strings.Contains(strings.Split(path, "/")[0], ".")
+}
+
+// mockName strips the Interface suffix so that DeviceInterface is mocked by
+// mock.Device while Interface keeps its name
+func mockName(iface string) string {
+	if name := strings.TrimSuffix(iface, "Interface"); name != "" {
+		return name
+	}
+	return iface
+}
+
+func newMethod(srcPkg, name string, ft *ast.FuncType) method {
+	m := method{name: name}
+	for _, field := range ft.Params.List {
+		_, variadic := field.Type.(*ast.Ellipsis)
+		typ := typeString(srcPkg, field.Type)
+		if len(field.Names) == 0 {
+			m.params = append(m.params, param{name: fmt.Sprintf("in%d", len(m.params)+1), typ: typ, variadic: variadic})
+		}
+		for _, n := range field.Names {
+			m.params = append(m.params, param{name: n.Name, typ: typ, variadic: variadic})
+		}
+	}
+	if ft.Results != nil {
+		for _, field := range ft.Results.List {
+			typ := typeString(srcPkg, field.Type)
+			for n := 0; n < len(field.Names) || n == 0 && len(field.Names) == 0; n++ {
+				m.results = append(m.results, typ)
+			}
+		}
+	}
+	return m
+}
+
+// typeString prints a type expression, qualifying identifiers declared by the
+// source package
+func typeString(srcPkg string, expr ast.Expr) string {
+	return types.ExprString(qualify(srcPkg, expr))
+}
+
+func qualify(srcPkg string, expr ast.Expr) ast.Expr {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		if t.IsExported() {
+			return &ast.SelectorExpr{X: ast.NewIdent(srcPkg), Sel: t}
+		}
+	case *ast.StarExpr:
+		t.X = qualify(srcPkg, t.X)
+	case *ast.ArrayType:
+		t.Elt = qualify(srcPkg, t.Elt)
+	case *ast.MapType:
+		t.Key = qualify(srcPkg, t.Key)
+		t.Value = qualify(srcPkg, t.Value)
+	case *ast.Ellipsis:
+		t.Elt = qualify(srcPkg, t.Elt)
+	case *ast.ChanType:
+		t.Value = qualify(srcPkg, t.Value)
+	case *ast.FuncType:
+		for _, f := range t.Params.List {
+			f.Type = qualify(srcPkg, f.Type)
+		}
+		if t.Results != nil {
+			for _, f := range t.Results.List {
+				f.Type = qualify(srcPkg, f.Type)
+			}
+		}
+	}
+	return expr
+}
+
+func writeMock(buf *bytes.Buffer, m mockType) {
+	fmt.Fprintf(buf, "// Ensure that %s implements %s.\nvar _ %s = &%s{}\n\n", m.name, m.iface, m.iface, m.name)
+	fmt.Fprintf(buf, "// %s is a mock implementation of %s.\ntype %s struct {\n", m.name, m.iface, m.name)
+	for _, f := range m.meths {
+		fmt.Fprintf(buf, "\t// %sFunc mocks the %s method.\n\t%sFunc %s\n\n", f.name, f.name, f.name, f.funcType())
+	}
+	buf.WriteString("\t// calls tracks calls to the methods.\n\tcalls struct {\n")
+	for _, f := range m.meths {
+		fmt.Fprintf(buf, "\t\t// %s holds details about calls to the %s method.\n\t\t%s []%s\n", f.name, f.name, f.name, f.callStruct())
+	}
+	buf.WriteString("\t}\n")
+	for _, f := range m.meths {
+		fmt.Fprintf(buf, "\tlock%s sync.RWMutex\n", f.name)
+	}
+	buf.WriteString("}\n\n")
+
+	for _, f := range m.meths {
+		var sig, args, info []string
+		for _, p := range f.params {
+			typ, arg := p.typ, p.name
+			if p.variadic {
+				arg += "..."
+			}
+			sig = append(sig, p.name+" "+typ)
+			args = append(args, arg)
+			info = append(info, fmt.Sprintf("%s: %s,", field(p.name), p.name))
+		}
+		fmt.Fprintf(buf, "// %s calls %sFunc.\nfunc (mock *%s) %s(%s) %s {\n", f.name, f.name, m.name, f.name, strings.Join(sig, ", "), f.resultList())
+		fmt.Fprintf(buf, "\tcallInfo := %s{\n%s\n}\n", f.callStruct(), strings.Join(info, "\n"))
+		fmt.Fprintf(buf, "\tmock.lock%s.Lock()\n\tmock.calls.%s = append(mock.calls.%s, callInfo)\n\tmock.lock%s.Unlock()\n", f.name, f.name, f.name, f.name)
+		fmt.Fprintf(buf, "\tif mock.%sFunc == nil {\n", f.name)
+		if len(f.results) > 0 {
+			var vars, names []string
+			for i, r := range f.results {
+				vars = append(vars, fmt.Sprintf("r%d %s", i, r))
+				names = append(names, fmt.Sprintf("r%d", i))
+			}
+			fmt.Fprintf(buf, "\t\tvar (\n%s\n)\n\t\treturn %s\n", strings.Join(vars, "\n"), strings.Join(names, ", "))
+		} else {
+			buf.WriteString("\t\treturn\n")
+		}
+		buf.WriteString("\t}\n\t")
+		if len(f.results) > 0 {
+			buf.WriteString("return ")
+		}
+		fmt.Fprintf(buf, "mock.%sFunc(%s)\n}\n\n", f.name, strings.Join(args, ", "))
+
+		fmt.Fprintf(buf, "// %sCalls gets all the calls that were made to %s.\nfunc (mock *%s) %sCalls() []%s {\n", f.name, f.name, m.name, f.name, f.callStruct())
+		fmt.Fprintf(buf, "\tvar calls []%s\n\tmock.lock%s.RLock()\n\tcalls = mock.calls.%s\n\tmock.lock%s.RUnlock()\n\treturn calls\n}\n\n", f.callStruct(), f.name, f.name, f.name)
+	}
+}
+
+func (f method) funcType() string {
+	var ps []string
+	for _, p := range f.params {
+		ps = append(ps, p.name+" "+p.typ)
+	}
+	return fmt.Sprintf("func(%s) %s", strings.Join(ps, ", "), f.resultList())
+}
+
+func (f method) resultList() string {
+	switch len(f.results) {
+	case 0:
+		return ""
+	case 1:
+		return f.results[0]
+	}
+	return "(" + strings.Join(f.results, ", ") + ")"
+}
+
+func (f method) callStruct() string {
+	var fs []string
+	for _, p := range f.params {
+		typ := p.typ
+		if p.variadic {
+			typ = "[]" + strings.TrimPrefix(typ, "...")
+		}
+		fs = append(fs, fmt.Sprintf("\t// %s is the %s argument value.\n\t%s %s\n", field(p.name), p.name, field(p.name), typ))
+	}
+	return "struct {\n" + strings.Join(fs, "") + "}"
+}
+
+func field(name string) string {
+	return strings.ToUpper(name[:1]) + name[1:]
+}
@@ -0,0 +1,92 @@
+/*
+ * Copyright (c) 2022, HabanaLabs Ltd.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the Lic
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gohlml
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+	"time"
+)
+
+// LogLevel is the flags argument of hlml_init_with_flags, which selects how
+// verbosely libhlml itself logs. Values are passed to libhlml unchanged, so
+// levels are built by or-ing the LogFlag bits
+type LogLevel uint
+
+// The libhlml log flag bits. hlml.h declares hlml_init_with_flags without
+// naming its bits, so they are named by position
+const (
+	// LogFlagBit1 is bit 1 of the flags, 0x2
+	LogFlagBit1 LogLevel = 1 << 1
+	// LogFlagBit2 is bit 2 of the flags, 0x4
+	LogFlagBit2 LogLevel = 1 << 2
+)
+
+const (
+	// LogLevelDefault initializes HLML through hlml_init
+	LogLevelDefault LogLevel = 0
+	// LogLevelVerbose is the level InitWithLogs initializes HLML with. It
+	// sets bits 1 and 2, 0x6, the flags the bindings have always passed
+	LogLevelVerbose = LogFlagBit1 | LogFlagBit2
+)
+
+var (
+	pkgLogger atomic.Pointer[slog.Logger]
+	tracing   atomic.Bool
+)
+
+// SetLogger sets the logger the package reports through. A nil logger
+// restores the default, which discards everything
+func SetLogger(l *slog.Logger) {
+	pkgLogger.Store(l)
+}
+
+// SetCallTracing turns on or off logging every HLML call, with its device,
+// return code and latency, at debug level
+func SetCallTracing(on bool) {
+	tracing.Store(on)
+}
+
+func logger() *slog.Logger {
+	if l := pkgLogger.Load(); l != nil {
+		return l
+	}
+	return discardLogger
+}
+
+var discardLogger = slog.New(discardHandler{})
+
+// discardHandler is a slog.Handler that drops every record
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (h discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return h }
+func (h discardHandler) WithGroup(string) slog.Handler           { return h }
+
+// traceCall logs a completed HLML call when call tracing is on
+func traceCall(op, device string, rc Return, latency time.Duration) {
+	if !tracing.Load() {
+		return
+	}
+	logger().Debug("hlml call",
+		slog.String("op", op),
+		slog.String("device", device),
+		slog.String("rc", rc.String()),
+		slog.Duration("latency", latency))
+}
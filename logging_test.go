@@ -0,0 +1,64 @@
+//go:build linux && cgo && fakehlml
+
+/*
+ * Copyright (c) 2022, HabanaLabs Ltd.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the Lic
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package gohlml
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeCallTracing(t *testing.T) {
+	var buf bytes.Buffer
+	SetLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	defer SetLogger(nil)
+
+	assert.Equal(t, LogLevel(0x6), LogLevelVerbose)
+	err := InitWithLogLevel(LogLevelVerbose)
+	assert.Nil(t, err, err)
+
+	dev, err := DeviceHandleByIndex(1)
+	assert.Nil(t, err, err)
+
+	_, err = dev.PowerUsage()
+	assert.ErrorIs(t, err, ErrAipIsLost)
+	assert.Empty(t, buf.String(), "Calls should not be traced by default")
+
+	SetCallTracing(true)
+	defer SetCallTracing(false)
+
+	_, err = dev.PowerUsage()
+	assert.ErrorIs(t, err, ErrAipIsLost)
+	_, err = dev.SerialNumber()
+	assert.Nil(t, err, err)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if assert.Len(t, lines, 2) {
+		assert.Contains(t, lines[0], "op=hlml_device_get_power_usage")
+		assert.Contains(t, lines[0], "device=0000:1a:00.0")
+		assert.Contains(t, lines[0], "rc=HLML_ERROR_AIP_IS_LOST")
+		assert.Contains(t, lines[0], "latency=")
+		assert.Contains(t, lines[1], "rc=HLML_SUCCESS")
+	}
+
+	err = Shutdown()
+	assert.Nil(t, err, err)
+}
@@ -0,0 +1,702 @@
+/*
+ * Copyright (c) 2022, HabanaLabs Ltd.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the Lic
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gohlml
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Default bounds of the delay between two reinitializations by a Manager
+const (
+	DefaultMinRecoveryBackoff = time.Second
+	DefaultMaxRecoveryBackoff = time.Minute
+)
+
+// Recovery describes a reinitialization of HLML by a Manager
+type Recovery struct {
+	// Cause is the error that triggered the recovery
+	Cause error
+	// Devices are the UUIDs of the managed devices resolved again
+	Devices []string
+	// Missing are the UUIDs of the managed devices no longer found
+	Missing []string
+	// Err is set when the recovery failed, in which case a failing call
+	// after the backoff triggers another attempt
+	Err error
+}
+
+// Manager hands out ManagedDevices, which survive device resets and driver
+// reloads. When a call fails with ErrAipIsLost, ErrDriverNotLoaded or
+// ErrNotIntialized, the Manager reinitializes HLML, resolves every managed
+// device again by UUID and retries the call once.
+//
+// Reinitializations are spaced by a backoff that starts at the minimum and
+// doubles, up to the maximum, for every recovery not followed by a
+// successful call, so a device that stays lost does not restart HLML on
+// every call. Failing calls within the backoff return their error without
+// recovering.
+//
+// Handles are also resolved again, without reinitializing, when the
+// Generation of lib moved since they were resolved, e.g. after another user
+// of the library called Reinitialize.
+//
+// The Manager never initializes HLML itself. Once the last user shut it
+// down, Reinitialize fails with ErrNotIntialized, which recoveries report in
+// Err, until HLML is initialized again
+type Manager struct {
+	lib Interface
+
+	mu        sync.Mutex
+	devices   map[string]*ManagedDevice
+	callbacks []func(Recovery)
+
+	minBackoff, maxBackoff time.Duration
+	backoff                time.Duration
+	// next is the earliest time of the next reinitialization
+	next time.Time
+	// failing is set from a recovery until a call succeeds
+	failing atomic.Bool
+}
+
+// NewManager returns a Manager on top of lib, which must be initialized
+// before the Manager is used
+func NewManager(lib Interface) *Manager {
+	return &Manager{
+		lib:        lib,
+		devices:    map[string]*ManagedDevice{},
+		minBackoff: DefaultMinRecoveryBackoff,
+		maxBackoff: DefaultMaxRecoveryBackoff,
+	}
+}
+
+// SetRecoveryBackoff sets the bounds of the delay between two
+// reinitializations. A zero min disables the backoff
+func (m *Manager) SetRecoveryBackoff(min, max time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.minBackoff, m.maxBackoff = min, max
+	m.backoff, m.next = 0, time.Time{}
+}
+
+// OnRecovery registers fn to be called after every recovery attempt
+func (m *Manager) OnRecovery(fn func(Recovery)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.callbacks = append(m.callbacks, fn)
+}
+
+// Device returns the managed device with the given UUID
+func (m *Manager) Device(ctx context.Context, uuid string) (*ManagedDevice, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if d, ok := m.devices[uuid]; ok {
+		return d, nil
+	}
+	gen := m.lib.Generation()
+	dev, err := m.lib.DeviceHandleByUUIDContext(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
+	d := &ManagedDevice{m: m, uuid: uuid}
+	d.set(dev, gen)
+	m.devices[uuid] = d
+	return d, nil
+}
+
+// Devices returns a managed device for every device in the system
+func (m *Manager) Devices(ctx context.Context) ([]*ManagedDevice, error) {
+	count, err := m.lib.DeviceCountContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	devices := make([]*ManagedDevice, 0, count)
+	for i := uint(0); i < count; i++ {
+		dev, err := m.lib.DeviceHandleByIndexContext(ctx, i)
+		if err != nil {
+			return nil, err
+		}
+		uuid, err := dev.UUIDContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+		d, err := m.Device(ctx, uuid)
+		if err != nil {
+			return nil, err
+		}
+		devices = append(devices, d)
+	}
+	return devices, nil
+}
+
+// recoverable reports whether err means the handles went stale
+func recoverable(err error) bool {
+	return errors.Is(err, ErrAipIsLost) ||
+		errors.Is(err, ErrDriverNotLoaded) ||
+		errors.Is(err, ErrNotIntialized)
+}
+
+// errBackoff is returned by recover within the backoff
+var errBackoff = errors.New("recovery backoff")
+
+// recover returns a fresh handle for d after a call with the handle of the
+// given version failed with cause. If the handle was replaced meanwhile, or
+// HLML reinitialized since it was resolved, the device is only resolved
+// again. Otherwise HLML is reinitialized, unless within the backoff
+func (m *Manager) recover(ctx context.Context, d *ManagedDevice, version uint64, cause error) (DeviceInterface, error) {
+	m.mu.Lock()
+	m.failing.Store(true)
+
+	dev, gen, cur := d.get()
+	if cur != version {
+		m.mu.Unlock()
+		return dev, nil
+	}
+	if g := m.lib.Generation(); g != gen {
+		defer m.mu.Unlock()
+		dev, _, err := d.resolve(ctx, g)
+		return dev, err
+	}
+
+	now := time.Now()
+	if now.Before(m.next) {
+		m.mu.Unlock()
+		return nil, errBackoff
+	}
+	m.backoff = min(max(2*m.backoff, m.minBackoff), m.maxBackoff)
+	m.next = now.Add(m.backoff)
+
+	r := Recovery{Cause: cause}
+	if r.Err = m.lib.Reinitialize(); r.Err == nil {
+		g := m.lib.Generation()
+		for uuid, md := range m.devices {
+			if _, _, err := md.resolve(ctx, g); err != nil {
+				r.Missing = append(r.Missing, uuid)
+				continue
+			}
+			r.Devices = append(r.Devices, uuid)
+		}
+	}
+	callbacks := m.callbacks
+	m.mu.Unlock()
+
+	for _, fn := range callbacks {
+		fn(r)
+	}
+	if r.Err != nil {
+		return nil, r.Err
+	}
+	next, _, cur := d.get()
+	if cur == version {
+		return nil, ErrNotFound
+	}
+	return next, nil
+}
+
+// succeeded resets the backoff after a successful call
+func (m *Manager) succeeded() {
+	if !m.failing.Load() {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.failing.Store(false)
+	m.backoff, m.next = 0, time.Time{}
+}
+
+// ManagedDevice is a DeviceInterface whose handle is resolved again by UUID
+// after HLML is reinitialized
+type ManagedDevice struct {
+	m    *Manager
+	uuid string
+
+	mu  sync.RWMutex
+	dev DeviceInterface
+	// gen is the library Generation the handle was resolved in
+	gen uint64
+	// version counts the handles resolved for the device
+	version uint64
+}
+
+var _ DeviceInterface = (*ManagedDevice)(nil)
+
+func (d *ManagedDevice) get() (DeviceInterface, uint64, uint64) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	return d.dev, d.gen, d.version
+}
+
+func (d *ManagedDevice) set(dev DeviceInterface, gen uint64) uint64 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.dev, d.gen = dev, gen
+	d.version++
+	return d.version
+}
+
+// resolve looks the device up again in library generation gen, returning
+// the handle and its version
+func (d *ManagedDevice) resolve(ctx context.Context, gen uint64) (DeviceInterface, uint64, error) {
+	dev, err := d.m.lib.DeviceHandleByUUIDContext(ctx, d.uuid)
+	if err != nil {
+		return nil, 0, err
+	}
+	return dev, d.set(dev, gen), nil
+}
+
+// run calls fn with the device handle, resolved again first if HLML was
+// reinitialized since. If fn fails because the handle went stale, run
+// recovers HLML and calls fn once more with the new handle
+func (d *ManagedDevice) run(ctx context.Context, fn func(DeviceInterface) error) error {
+	dev, gen, version := d.get()
+	if g := d.m.lib.Generation(); g != gen {
+		var err error
+		if dev, version, err = d.resolve(ctx, g); err != nil {
+			return err
+		}
+	}
+
+	err := fn(dev)
+	if !recoverable(err) {
+		if err == nil {
+			d.m.succeeded()
+		}
+		return err
+	}
+	next, rerr := d.m.recover(ctx, d, version, err)
+	if rerr != nil {
+		return err
+	}
+	if err = fn(next); err == nil {
+		d.m.succeeded()
+	}
+	return err
+}
+
+// managed runs a single valued device query through d.run
+func managed[T any](ctx context.Context, d *ManagedDevice, fn func(DeviceInterface, context.Context) (T, error)) (T, error) {
+	var v T
+	err := d.run(ctx, func(dev DeviceInterface) (err error) {
+		v, err = fn(dev, ctx)
+		return err
+	})
+	return v, err
+}
+
+func (d *ManagedDevice) MinorNumber() (uint, error) {
+	return d.MinorNumberContext(context.Background())
+}
+
+func (d *ManagedDevice) MinorNumberContext(ctx context.Context) (uint, error) {
+	return managed(ctx, d, DeviceInterface.MinorNumberContext)
+}
+
+func (d *ManagedDevice) Name() (string, error) {
+	return d.NameContext(context.Background())
+}
+
+func (d *ManagedDevice) NameContext(ctx context.Context) (string, error) {
+	return managed(ctx, d, DeviceInterface.NameContext)
+}
+
+func (d *ManagedDevice) UUID() (string, error) {
+	return d.UUIDContext(context.Background())
+}
+
+func (d *ManagedDevice) UUIDContext(ctx context.Context) (string, error) {
+	return managed(ctx, d, DeviceInterface.UUIDContext)
+}
+
+func (d *ManagedDevice) PCIDomain() (uint, error) {
+	return d.PCIDomainContext(context.Background())
+}
+
+func (d *ManagedDevice) PCIDomainContext(ctx context.Context) (uint, error) {
+	return managed(ctx, d, DeviceInterface.PCIDomainContext)
+}
+
+func (d *ManagedDevice) PCIBus() (uint, error) {
+	return d.PCIBusContext(context.Background())
+}
+
+func (d *ManagedDevice) PCIBusContext(ctx context.Context) (uint, error) {
+	return managed(ctx, d, DeviceInterface.PCIBusContext)
+}
+
+func (d *ManagedDevice) PCIBusID() (string, error) {
+	return d.PCIBusIDContext(context.Background())
+}
+
+func (d *ManagedDevice) PCIBusIDContext(ctx context.Context) (string, error) {
+	return managed(ctx, d, DeviceInterface.PCIBusIDContext)
+}
+
+func (d *ManagedDevice) PCIID() (uint, error) {
+	return d.PCIIDContext(context.Background())
+}
+
+func (d *ManagedDevice) PCIIDContext(ctx context.Context) (uint, error) {
+	return managed(ctx, d, DeviceInterface.PCIIDContext)
+}
+
+func (d *ManagedDevice) PCILinkSpeed() (uint, error) {
+	return d.PCILinkSpeedContext(context.Background())
+}
+
+func (d *ManagedDevice) PCILinkSpeedContext(ctx context.Context) (uint, error) {
+	return managed(ctx, d, DeviceInterface.PCILinkSpeedContext)
+}
+
+func (d *ManagedDevice) PCILinkWidth() (uint, error) {
+	return d.PCILinkWidthContext(context.Background())
+}
+
+func (d *ManagedDevice) PCILinkWidthContext(ctx context.Context) (uint, error) {
+	return managed(ctx, d, DeviceInterface.PCILinkWidthContext)
+}
+
+func (d *ManagedDevice) MemoryInfo() (total uint64, used uint64, free uint64, err error) {
+	return d.MemoryInfoContext(context.Background())
+}
+
+func (d *ManagedDevice) MemoryInfoContext(ctx context.Context) (total uint64, used uint64, free uint64, err error) {
+	err = d.run(ctx, func(dev DeviceInterface) (err error) {
+		total, used, free, err = dev.MemoryInfoContext(ctx)
+		return err
+	})
+	return total, used, free, err
+}
+
+func (d *ManagedDevice) UtilizationInfo() (uint, error) {
+	return d.UtilizationInfoContext(context.Background())
+}
+
+func (d *ManagedDevice) UtilizationInfoContext(ctx context.Context) (uint, error) {
+	return managed(ctx, d, DeviceInterface.UtilizationInfoContext)
+}
+
+func (d *ManagedDevice) SOCClockInfo() (uint, error) {
+	return d.SOCClockInfoContext(context.Background())
+}
+
+func (d *ManagedDevice) SOCClockInfoContext(ctx context.Context) (uint, error) {
+	return managed(ctx, d, DeviceInterface.SOCClockInfoContext)
+}
+
+func (d *ManagedDevice) SOCClockMax() (uint, error) {
+	return d.SOCClockMaxContext(context.Background())
+}
+
+func (d *ManagedDevice) SOCClockMaxContext(ctx context.Context) (uint, error) {
+	return managed(ctx, d, DeviceInterface.SOCClockMaxContext)
+}
+
+func (d *ManagedDevice) ICClockMax() (uint, error) {
+	return d.ICClockMaxContext(context.Background())
+}
+
+func (d *ManagedDevice) ICClockMaxContext(ctx context.Context) (uint, error) {
+	return managed(ctx, d, DeviceInterface.ICClockMaxContext)
+}
+
+func (d *ManagedDevice) MMEClockMax() (uint, error) {
+	return d.MMEClockMaxContext(context.Background())
+}
+
+func (d *ManagedDevice) MMEClockMaxContext(ctx context.Context) (uint, error) {
+	return managed(ctx, d, DeviceInterface.MMEClockMaxContext)
+}
+
+func (d *ManagedDevice) TPCClockMax() (uint, error) {
+	return d.TPCClockMaxContext(context.Background())
+}
+
+func (d *ManagedDevice) TPCClockMaxContext(ctx context.Context) (uint, error) {
+	return managed(ctx, d, DeviceInterface.TPCClockMaxContext)
+}
+
+func (d *ManagedDevice) PowerUsage() (uint, error) {
+	return d.PowerUsageContext(context.Background())
+}
+
+func (d *ManagedDevice) PowerUsageContext(ctx context.Context) (uint, error) {
+	return managed(ctx, d, DeviceInterface.PowerUsageContext)
+}
+
+func (d *ManagedDevice) TemperatureOnBoard() (uint, error) {
+	return d.TemperatureOnBoardContext(context.Background())
+}
+
+func (d *ManagedDevice) TemperatureOnBoardContext(ctx context.Context) (uint, error) {
+	return managed(ctx, d, DeviceInterface.TemperatureOnBoardContext)
+}
+
+func (d *ManagedDevice) TemperatureOnChip() (uint, error) {
+	return d.TemperatureOnChipContext(context.Background())
+}
+
+func (d *ManagedDevice) TemperatureOnChipContext(ctx context.Context) (uint, error) {
+	return managed(ctx, d, DeviceInterface.TemperatureOnChipContext)
+}
+
+func (d *ManagedDevice) TemperatureThresholdShutdown() (uint, error) {
+	return d.TemperatureThresholdShutdownContext(context.Background())
+}
+
+func (d *ManagedDevice) TemperatureThresholdShutdownContext(ctx context.Context) (uint, error) {
+	return managed(ctx, d, DeviceInterface.TemperatureThresholdShutdownContext)
+}
+
+func (d *ManagedDevice) TemperatureThresholdSlowdown() (uint, error) {
+	return d.TemperatureThresholdSlowdownContext(context.Background())
+}
+
+func (d *ManagedDevice) TemperatureThresholdSlowdownContext(ctx context.Context) (uint, error) {
+	return managed(ctx, d, DeviceInterface.TemperatureThresholdSlowdownContext)
+}
+
+func (d *ManagedDevice) TemperatureThresholdMemory() (uint, error) {
+	return d.TemperatureThresholdMemoryContext(context.Background())
+}
+
+func (d *ManagedDevice) TemperatureThresholdMemoryContext(ctx context.Context) (uint, error) {
+	return managed(ctx, d, DeviceInterface.TemperatureThresholdMemoryContext)
+}
+
+func (d *ManagedDevice) TemperatureThresholdGPU() (uint, error) {
+	return d.TemperatureThresholdGPUContext(context.Background())
+}
+
+func (d *ManagedDevice) TemperatureThresholdGPUContext(ctx context.Context) (uint, error) {
+	return managed(ctx, d, DeviceInterface.TemperatureThresholdGPUContext)
+}
+
+func (d *ManagedDevice) PowerManagementDefaultLimit() (uint, error) {
+	return d.PowerManagementDefaultLimitContext(context.Background())
+}
+
+func (d *ManagedDevice) PowerManagementDefaultLimitContext(ctx context.Context) (uint, error) {
+	return managed(ctx, d, DeviceInterface.PowerManagementDefaultLimitContext)
+}
+
+func (d *ManagedDevice) ECCMode() (current uint, pending uint, err error) {
+	return d.ECCModeContext(context.Background())
+}
+
+func (d *ManagedDevice) ECCModeContext(ctx context.Context) (current uint, pending uint, err error) {
+	err = d.run(ctx, func(dev DeviceInterface) (err error) {
+		current, pending, err = dev.ECCModeContext(ctx)
+		return err
+	})
+	return current, pending, err
+}
+
+func (d *ManagedDevice) HLRevision() (int, error) {
+	return d.HLRevisionContext(context.Background())
+}
+
+func (d *ManagedDevice) HLRevisionContext(ctx context.Context) (int, error) {
+	return managed(ctx, d, DeviceInterface.HLRevisionContext)
+}
+
+func (d *ManagedDevice) PCBVersion() (string, error) {
+	return d.PCBVersionContext(context.Background())
+}
+
+func (d *ManagedDevice) PCBVersionContext(ctx context.Context) (string, error) {
+	return managed(ctx, d, DeviceInterface.PCBVersionContext)
+}
+
+func (d *ManagedDevice) PCBAssemblyVersion() (string, error) {
+	return d.PCBAssemblyVersionContext(context.Background())
+}
+
+func (d *ManagedDevice) PCBAssemblyVersionContext(ctx context.Context) (string, error) {
+	return managed(ctx, d, DeviceInterface.PCBAssemblyVersionContext)
+}
+
+func (d *ManagedDevice) SerialNumber() (string, error) {
+	return d.SerialNumberContext(context.Background())
+}
+
+func (d *ManagedDevice) SerialNumberContext(ctx context.Context) (string, error) {
+	return managed(ctx, d, DeviceInterface.SerialNumberContext)
+}
+
+func (d *ManagedDevice) ModuleID() (uint, error) {
+	return d.ModuleIDContext(context.Background())
+}
+
+func (d *ManagedDevice) ModuleIDContext(ctx context.Context) (uint, error) {
+	return managed(ctx, d, DeviceInterface.ModuleIDContext)
+}
+
+func (d *ManagedDevice) BoardID() (uint, error) {
+	return d.BoardIDContext(context.Background())
+}
+
+func (d *ManagedDevice) BoardIDContext(ctx context.Context) (uint, error) {
+	return managed(ctx, d, DeviceInterface.BoardIDContext)
+}
+
+func (d *ManagedDevice) PCIeTX() (uint, error) {
+	return d.PCIeTXContext(context.Background())
+}
+
+func (d *ManagedDevice) PCIeTXContext(ctx context.Context) (uint, error) {
+	return managed(ctx, d, DeviceInterface.PCIeTXContext)
+}
+
+func (d *ManagedDevice) PCIeRX() (uint, error) {
+	return d.PCIeRXContext(context.Background())
+}
+
+func (d *ManagedDevice) PCIeRXContext(ctx context.Context) (uint, error) {
+	return managed(ctx, d, DeviceInterface.PCIeRXContext)
+}
+
+func (d *ManagedDevice) PCIReplayCounter() (uint, error) {
+	return d.PCIReplayCounterContext(context.Background())
+}
+
+func (d *ManagedDevice) PCIReplayCounterContext(ctx context.Context) (uint, error) {
+	return managed(ctx, d, DeviceInterface.PCIReplayCounterContext)
+}
+
+func (d *ManagedDevice) PCIeLinkGeneration() (uint, error) {
+	return d.PCIeLinkGenerationContext(context.Background())
+}
+
+func (d *ManagedDevice) PCIeLinkGenerationContext(ctx context.Context) (uint, error) {
+	return managed(ctx, d, DeviceInterface.PCIeLinkGenerationContext)
+}
+
+func (d *ManagedDevice) PCIeLinkWidth() (uint, error) {
+	return d.PCIeLinkWidthContext(context.Background())
+}
+
+func (d *ManagedDevice) PCIeLinkWidthContext(ctx context.Context) (uint, error) {
+	return managed(ctx, d, DeviceInterface.PCIeLinkWidthContext)
+}
+
+func (d *ManagedDevice) ClockThrottleReasons() (uint64, error) {
+	return d.ClockThrottleReasonsContext(context.Background())
+}
+
+func (d *ManagedDevice) ClockThrottleReasonsContext(ctx context.Context) (uint64, error) {
+	return managed(ctx, d, DeviceInterface.ClockThrottleReasonsContext)
+}
+
+func (d *ManagedDevice) EnergyConsumptionCounter() (uint64, error) {
+	return d.EnergyConsumptionCounterContext(context.Background())
+}
+
+func (d *ManagedDevice) EnergyConsumptionCounterContext(ctx context.Context) (uint64, error) {
+	return managed(ctx, d, DeviceInterface.EnergyConsumptionCounterContext)
+}
+
+func (d *ManagedDevice) MacAddressInfo() (map[int]string, error) {
+	return d.MacAddressInfoContext(context.Background())
+}
+
+func (d *ManagedDevice) MacAddressInfoContext(ctx context.Context) (map[int]string, error) {
+	return managed(ctx, d, DeviceInterface.MacAddressInfoContext)
+}
+
+func (d *ManagedDevice) NicLinkStatus(port uint) (uint, error) {
+	return d.NicLinkStatusContext(context.Background(), port)
+}
+
+func (d *ManagedDevice) NicLinkStatusContext(ctx context.Context, port uint) (uint, error) {
+	return managed(ctx, d, func(dev DeviceInterface, ctx context.Context) (uint, error) {
+		return dev.NicLinkStatusContext(ctx, port)
+	})
+}
+
+func (d *ManagedDevice) ReplacedRowDoubleBitECC() (uint, error) {
+	return d.ReplacedRowDoubleBitECCContext(context.Background())
+}
+
+func (d *ManagedDevice) ReplacedRowDoubleBitECCContext(ctx context.Context) (uint, error) {
+	return managed(ctx, d, DeviceInterface.ReplacedRowDoubleBitECCContext)
+}
+
+func (d *ManagedDevice) ReplacedRowSingleBitECC() (uint, error) {
+	return d.ReplacedRowSingleBitECCContext(context.Background())
+}
+
+func (d *ManagedDevice) ReplacedRowSingleBitECCContext(ctx context.Context) (uint, error) {
+	return managed(ctx, d, DeviceInterface.ReplacedRowSingleBitECCContext)
+}
+
+func (d *ManagedDevice) IsReplacedRowsPendingStatus() (int, error) {
+	return d.IsReplacedRowsPendingStatusContext(context.Background())
+}
+
+func (d *ManagedDevice) IsReplacedRowsPendingStatusContext(ctx context.Context) (int, error) {
+	return managed(ctx, d, DeviceInterface.IsReplacedRowsPendingStatusContext)
+}
+
+func (d *ManagedDevice) NumaNode() (*uint, error) {
+	return d.NumaNodeContext(context.Background())
+}
+
+func (d *ManagedDevice) NumaNodeContext(ctx context.Context) (*uint, error) {
+	return managed(ctx, d, DeviceInterface.NumaNodeContext)
+}
+
+func (d *ManagedDevice) HwmonSensors() ([]HwmonSensor, error) {
+	return d.HwmonSensorsContext(context.Background())
+}
+
+func (d *ManagedDevice) HwmonSensorsContext(ctx context.Context) ([]HwmonSensor, error) {
+	return managed(ctx, d, DeviceInterface.HwmonSensorsContext)
+}
+
+func (d *ManagedDevice) DeviceNodes() ([]DeviceNode, error) {
+	return d.DeviceNodesContext(context.Background())
+}
+
+func (d *ManagedDevice) DeviceNodesContext(ctx context.Context) ([]DeviceNode, error) {
+	return managed(ctx, d, DeviceInterface.DeviceNodesContext)
+}
+
+func (d *ManagedDevice) Processes() ([]Process, error) {
+	return d.ProcessesContext(context.Background())
+}
+
+func (d *ManagedDevice) ProcessesContext(ctx context.Context) ([]Process, error) {
+	return managed(ctx, d, DeviceInterface.ProcessesContext)
+}
+
+func (d *ManagedDevice) Snapshot() Snapshot {
+	return d.SnapshotContext(context.Background())
+}
+
+func (d *ManagedDevice) SnapshotContext(ctx context.Context) Snapshot {
+	return snapshot(ctx, d)
+}
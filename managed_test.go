@@ -0,0 +1,200 @@
+/*
+ * Copyright (c) 2022, HabanaLabs Ltd.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the Lic
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package gohlml_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/HabanaAI/gohlml"
+	"github.com/HabanaAI/gohlml/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestManagedDeviceRecovery(t *testing.T) {
+	stale := &mock.Device{
+		PowerUsageContextFunc: func(ctx context.Context) (uint, error) {
+			return 0, gohlml.ErrAipIsLost
+		},
+	}
+	fresh := &mock.Device{
+		PowerUsageContextFunc: func(ctx context.Context) (uint, error) {
+			return 150000, nil
+		},
+	}
+	handles := []gohlml.DeviceInterface{stale, fresh}
+	lib := &mock.Interface{
+		DeviceHandleByUUIDContextFunc: func(ctx context.Context, uuid string) (gohlml.DeviceInterface, error) {
+			dev := handles[0]
+			handles = handles[1:]
+			return dev, nil
+		},
+	}
+
+	var recoveries []gohlml.Recovery
+	m := gohlml.NewManager(lib)
+	m.OnRecovery(func(r gohlml.Recovery) {
+		recoveries = append(recoveries, r)
+	})
+
+	dev, err := m.Device(context.Background(), "uuid0")
+	assert.Nil(t, err, err)
+
+	power, err := dev.PowerUsage()
+	assert.Nil(t, err, err)
+	assert.Equal(t, uint(150000), power)
+	assert.Len(t, lib.ReinitializeCalls(), 1)
+	assert.Len(t, stale.PowerUsageContextCalls(), 1)
+	assert.Len(t, fresh.PowerUsageContextCalls(), 1)
+
+	if assert.Len(t, recoveries, 1) {
+		assert.ErrorIs(t, recoveries[0].Cause, gohlml.ErrAipIsLost)
+		assert.Equal(t, []string{"uuid0"}, recoveries[0].Devices)
+		assert.Nil(t, recoveries[0].Err)
+	}
+
+	power, err = dev.PowerUsage()
+	assert.Nil(t, err, err)
+	assert.Equal(t, uint(150000), power)
+	assert.Len(t, lib.ReinitializeCalls(), 1, "Healthy handles should not recover")
+}
+
+func TestManagedDeviceRecoveryFailure(t *testing.T) {
+	dev := &mock.Device{
+		TemperatureOnChipContextFunc: func(ctx context.Context) (uint, error) {
+			return 0, gohlml.ErrDriverNotLoaded
+		},
+		SerialNumberContextFunc: func(ctx context.Context) (string, error) {
+			return "", gohlml.ErrNotSupported
+		},
+	}
+	reinitErr := errors.New("driver still loading")
+	lib := &mock.Interface{
+		ReinitializeFunc: func() error { return reinitErr },
+		DeviceHandleByUUIDContextFunc: func(ctx context.Context, uuid string) (gohlml.DeviceInterface, error) {
+			return dev, nil
+		},
+	}
+
+	var recoveries []gohlml.Recovery
+	m := gohlml.NewManager(lib)
+	m.OnRecovery(func(r gohlml.Recovery) {
+		recoveries = append(recoveries, r)
+	})
+
+	d, err := m.Device(context.Background(), "uuid0")
+	assert.Nil(t, err, err)
+
+	_, err = d.SerialNumber()
+	assert.ErrorIs(t, err, gohlml.ErrNotSupported)
+	assert.Len(t, lib.ReinitializeCalls(), 0, "Other errors should not recover")
+
+	_, err = d.TemperatureOnChip()
+	assert.ErrorIs(t, err, gohlml.ErrDriverNotLoaded)
+	assert.Len(t, dev.TemperatureOnChipContextCalls(), 1)
+	if assert.Len(t, recoveries, 1) {
+		assert.Equal(t, reinitErr, recoveries[0].Err)
+	}
+
+	_, err = d.TemperatureOnChip()
+	assert.ErrorIs(t, err, gohlml.ErrDriverNotLoaded)
+	assert.Len(t, lib.ReinitializeCalls(), 1, "Recovery should back off")
+
+	m.SetRecoveryBackoff(0, 0)
+	_, err = d.TemperatureOnChip()
+	assert.ErrorIs(t, err, gohlml.ErrDriverNotLoaded)
+	assert.Len(t, lib.ReinitializeCalls(), 2, "A failed recovery should be attempted again")
+}
+
+func TestManagedDeviceBackoff(t *testing.T) {
+	lost := true
+	dev := &mock.Device{
+		PowerUsageContextFunc: func(ctx context.Context) (uint, error) {
+			if lost {
+				return 0, gohlml.ErrAipIsLost
+			}
+			return 150000, nil
+		},
+	}
+	lib := &mock.Interface{
+		DeviceHandleByUUIDContextFunc: func(ctx context.Context, uuid string) (gohlml.DeviceInterface, error) {
+			return dev, nil
+		},
+	}
+	m := gohlml.NewManager(lib)
+	m.SetRecoveryBackoff(50*time.Millisecond, 200*time.Millisecond)
+
+	d, err := m.Device(context.Background(), "uuid0")
+	assert.Nil(t, err, err)
+
+	_, err = d.PowerUsage()
+	assert.ErrorIs(t, err, gohlml.ErrAipIsLost)
+	_, err = d.PowerUsage()
+	assert.ErrorIs(t, err, gohlml.ErrAipIsLost)
+	assert.Len(t, lib.ReinitializeCalls(), 1, "A lost device should not reinitialize on every call")
+
+	time.Sleep(60 * time.Millisecond)
+	_, err = d.PowerUsage()
+	assert.ErrorIs(t, err, gohlml.ErrAipIsLost)
+	assert.Len(t, lib.ReinitializeCalls(), 2)
+
+	time.Sleep(60 * time.Millisecond)
+	_, err = d.PowerUsage()
+	assert.ErrorIs(t, err, gohlml.ErrAipIsLost)
+	assert.Len(t, lib.ReinitializeCalls(), 2, "The backoff should double")
+
+	lost = false
+	power, err := d.PowerUsage()
+	assert.Nil(t, err, err)
+	assert.Equal(t, uint(150000), power)
+
+	lost = true
+	_, err = d.PowerUsage()
+	assert.ErrorIs(t, err, gohlml.ErrAipIsLost)
+	assert.Len(t, lib.ReinitializeCalls(), 3, "A successful call should reset the backoff")
+}
+
+func TestManagedDeviceGeneration(t *testing.T) {
+	var gen uint64
+	handles := 0
+	lib := &mock.Interface{
+		GenerationFunc: func() uint64 { return gen },
+		DeviceHandleByUUIDContextFunc: func(ctx context.Context, uuid string) (gohlml.DeviceInterface, error) {
+			handles++
+			return &mock.Device{
+				PowerUsageContextFunc: func(ctx context.Context) (uint, error) {
+					return 150000, nil
+				},
+			}, nil
+		},
+	}
+	m := gohlml.NewManager(lib)
+
+	d, err := m.Device(context.Background(), "uuid0")
+	assert.Nil(t, err, err)
+	_, err = d.PowerUsage()
+	assert.Nil(t, err, err)
+	assert.Equal(t, 1, handles)
+
+	// HLML reinitialized by another user of the library
+	gen++
+	_, err = d.PowerUsage()
+	assert.Nil(t, err, err)
+	assert.Equal(t, 2, handles, "Handles of an older generation should be resolved again")
+	assert.Len(t, lib.ReinitializeCalls(), 0)
+}
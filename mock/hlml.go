@@ -0,0 +1,5499 @@
+// Code generated by internal/mockgen from interface.go; DO NOT EDIT.
+
+// Package mock provides call-recording mocks of the gohlml interfaces.
+// Every method returns zero values unless its Func field is set.
+//
+// The package imports gohlml, which links against libhlml when built with cgo
+// on Linux. Build tests that use it with CGO_ENABLED=0 where libhlml is
+// not installed.
+package mock
+
+import (
+	"context"
+	"sync"
+
+	"github.com/HabanaAI/gohlml"
+)
+
+// Ensure that Interface implements gohlml.Interface.
+var _ gohlml.Interface = &Interface{}
+
+// Interface is a mock implementation of gohlml.Interface.
+type Interface struct {
+	// DeleteEventSetFunc mocks the DeleteEventSet method.
+	DeleteEventSetFunc func(es gohlml.EventSet)
+
+	// DeviceCountFunc mocks the DeviceCount method.
+	DeviceCountFunc func() (uint, error)
+
+	// DeviceCountContextFunc mocks the DeviceCountContext method.
+	DeviceCountContextFunc func(ctx context.Context) (uint, error)
+
+	// DeviceHandleByIndexFunc mocks the DeviceHandleByIndex method.
+	DeviceHandleByIndexFunc func(idx uint) (gohlml.DeviceInterface, error)
+
+	// DeviceHandleByIndexContextFunc mocks the DeviceHandleByIndexContext method.
+	DeviceHandleByIndexContextFunc func(ctx context.Context, idx uint) (gohlml.DeviceInterface, error)
+
+	// DeviceHandleBySerialFunc mocks the DeviceHandleBySerial method.
+	DeviceHandleBySerialFunc func(serial string) (gohlml.DeviceInterface, error)
+
+	// DeviceHandleBySerialContextFunc mocks the DeviceHandleBySerialContext method.
+	DeviceHandleBySerialContextFunc func(ctx context.Context, serial string) (gohlml.DeviceInterface, error)
+
+	// DeviceHandleByUUIDFunc mocks the DeviceHandleByUUID method.
+	DeviceHandleByUUIDFunc func(uuid string) (gohlml.DeviceInterface, error)
+
+	// DeviceHandleByUUIDContextFunc mocks the DeviceHandleByUUIDContext method.
+	DeviceHandleByUUIDContextFunc func(ctx context.Context, uuid string) (gohlml.DeviceInterface, error)
+
+	// DiscoverFunc mocks the Discover method.
+	DiscoverFunc func() ([]gohlml.PCIDevice, error)
+
+	// FWVersionFunc mocks the FWVersion method.
+	FWVersionFunc func(idx uint) (string, string, error)
+
+	// FirmwareVersionsFunc mocks the FirmwareVersions method.
+	FirmwareVersionsFunc func(dev gohlml.DeviceInterface) (map[string]string, error)
+
+	// FirmwareVersionsContextFunc mocks the FirmwareVersionsContext method.
+	FirmwareVersionsContextFunc func(ctx context.Context, dev gohlml.DeviceInterface) (map[string]string, error)
+
+	// GenerationFunc mocks the Generation method.
+	GenerationFunc func() uint64
+
+	// GetDeviceTypeNameFunc mocks the GetDeviceTypeName method.
+	GetDeviceTypeNameFunc func() (string, error)
+
+	// InitWithLogLevelFunc mocks the InitWithLogLevel method.
+	InitWithLogLevelFunc func(level gohlml.LogLevel) error
+
+	// InitWithLogsFunc mocks the InitWithLogs method.
+	InitWithLogsFunc func() error
+
+	// InitializeFunc mocks the Initialize method.
+	InitializeFunc func() error
+
+	// IsInitializedFunc mocks the IsInitialized method.
+	IsInitializedFunc func() bool
+
+	// ModuleInfoFunc mocks the ModuleInfo method.
+	ModuleInfoFunc func() (gohlml.KernelModule, error)
+
+	// NewEventSetFunc mocks the NewEventSet method.
+	NewEventSetFunc func() gohlml.EventSet
+
+	// NumaNodeByBusIDFunc mocks the NumaNodeByBusID method.
+	NumaNodeByBusIDFunc func(busID string) (*uint, error)
+
+	// OwnersFunc mocks the Owners method.
+	OwnersFunc func(ctx context.Context, devices []gohlml.DeviceInterface) ([]gohlml.DeviceOwner, error)
+
+	// ReadAccelSysfsFunc mocks the ReadAccelSysfs method.
+	ReadAccelSysfsFunc func(minor uint) (gohlml.AccelSysfs, error)
+
+	// ReadEEPROMFunc mocks the ReadEEPROM method.
+	ReadEEPROMFunc func(minor uint) (gohlml.EEPROM, error)
+
+	// RegisterEventForDeviceFunc mocks the RegisterEventForDevice method.
+	RegisterEventForDeviceFunc func(es gohlml.EventSet, event int, uuid string) error
+
+	// RegisterEventForDeviceContextFunc mocks the RegisterEventForDeviceContext method.
+	RegisterEventForDeviceContextFunc func(ctx context.Context, es gohlml.EventSet, event int, uuid string) error
+
+	// ReinitializeFunc mocks the Reinitialize method.
+	ReinitializeFunc func() error
+
+	// ShutdownFunc mocks the Shutdown method.
+	ShutdownFunc func() error
+
+	// SnapshotAllFunc mocks the SnapshotAll method.
+	SnapshotAllFunc func(ctx context.Context, parallelism int) ([]gohlml.Snapshot, error)
+
+	// StatsFunc mocks the Stats method.
+	StatsFunc func() map[string]gohlml.CallStats
+
+	// SystemDriverVersionFunc mocks the SystemDriverVersion method.
+	SystemDriverVersionFunc func() (string, error)
+
+	// WaitForEventFunc mocks the WaitForEvent method.
+	WaitForEventFunc func(es gohlml.EventSet, timeout uint) (gohlml.Event, error)
+
+	// WaitForEventContextFunc mocks the WaitForEventContext method.
+	WaitForEventContextFunc func(ctx context.Context, es gohlml.EventSet, timeout uint) (gohlml.Event, error)
+
+	// WatchDevicesFunc mocks the WatchDevices method.
+	WatchDevicesFunc func(ctx context.Context, cfg gohlml.WatcherConfig) (<-chan gohlml.HotplugEvent, error)
+
+	// WorkerStatusFunc mocks the WorkerStatus method.
+	WorkerStatusFunc func() gohlml.WorkerState
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// DeleteEventSet holds details about calls to the DeleteEventSet method.
+		DeleteEventSet []struct {
+			// Es is the es argument value.
+			Es gohlml.EventSet
+		}
+		// DeviceCount holds details about calls to the DeviceCount method.
+		DeviceCount []struct {
+		}
+		// DeviceCountContext holds details about calls to the DeviceCountContext method.
+		DeviceCountContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// DeviceHandleByIndex holds details about calls to the DeviceHandleByIndex method.
+		DeviceHandleByIndex []struct {
+			// Idx is the idx argument value.
+			Idx uint
+		}
+		// DeviceHandleByIndexContext holds details about calls to the DeviceHandleByIndexContext method.
+		DeviceHandleByIndexContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Idx is the idx argument value.
+			Idx uint
+		}
+		// DeviceHandleBySerial holds details about calls to the DeviceHandleBySerial method.
+		DeviceHandleBySerial []struct {
+			// Serial is the serial argument value.
+			Serial string
+		}
+		// DeviceHandleBySerialContext holds details about calls to the DeviceHandleBySerialContext method.
+		DeviceHandleBySerialContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Serial is the serial argument value.
+			Serial string
+		}
+		// DeviceHandleByUUID holds details about calls to the DeviceHandleByUUID method.
+		DeviceHandleByUUID []struct {
+			// Uuid is the uuid argument value.
+			Uuid string
+		}
+		// DeviceHandleByUUIDContext holds details about calls to the DeviceHandleByUUIDContext method.
+		DeviceHandleByUUIDContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Uuid is the uuid argument value.
+			Uuid string
+		}
+		// Discover holds details about calls to the Discover method.
+		Discover []struct {
+		}
+		// FWVersion holds details about calls to the FWVersion method.
+		FWVersion []struct {
+			// Idx is the idx argument value.
+			Idx uint
+		}
+		// FirmwareVersions holds details about calls to the FirmwareVersions method.
+		FirmwareVersions []struct {
+			// Dev is the dev argument value.
+			Dev gohlml.DeviceInterface
+		}
+		// FirmwareVersionsContext holds details about calls to the FirmwareVersionsContext method.
+		FirmwareVersionsContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Dev is the dev argument value.
+			Dev gohlml.DeviceInterface
+		}
+		// Generation holds details about calls to the Generation method.
+		Generation []struct {
+		}
+		// GetDeviceTypeName holds details about calls to the GetDeviceTypeName method.
+		GetDeviceTypeName []struct {
+		}
+		// InitWithLogLevel holds details about calls to the InitWithLogLevel method.
+		InitWithLogLevel []struct {
+			// Level is the level argument value.
+			Level gohlml.LogLevel
+		}
+		// InitWithLogs holds details about calls to the InitWithLogs method.
+		InitWithLogs []struct {
+		}
+		// Initialize holds details about calls to the Initialize method.
+		Initialize []struct {
+		}
+		// IsInitialized holds details about calls to the IsInitialized method.
+		IsInitialized []struct {
+		}
+		// ModuleInfo holds details about calls to the ModuleInfo method.
+		ModuleInfo []struct {
+		}
+		// NewEventSet holds details about calls to the NewEventSet method.
+		NewEventSet []struct {
+		}
+		// NumaNodeByBusID holds details about calls to the NumaNodeByBusID method.
+		NumaNodeByBusID []struct {
+			// BusID is the busID argument value.
+			BusID string
+		}
+		// Owners holds details about calls to the Owners method.
+		Owners []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Devices is the devices argument value.
+			Devices []gohlml.DeviceInterface
+		}
+		// ReadAccelSysfs holds details about calls to the ReadAccelSysfs method.
+		ReadAccelSysfs []struct {
+			// Minor is the minor argument value.
+			Minor uint
+		}
+		// ReadEEPROM holds details about calls to the ReadEEPROM method.
+		ReadEEPROM []struct {
+			// Minor is the minor argument value.
+			Minor uint
+		}
+		// RegisterEventForDevice holds details about calls to the RegisterEventForDevice method.
+		RegisterEventForDevice []struct {
+			// Es is the es argument value.
+			Es gohlml.EventSet
+			// Event is the event argument value.
+			Event int
+			// Uuid is the uuid argument value.
+			Uuid string
+		}
+		// RegisterEventForDeviceContext holds details about calls to the RegisterEventForDeviceContext method.
+		RegisterEventForDeviceContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Es is the es argument value.
+			Es gohlml.EventSet
+			// Event is the event argument value.
+			Event int
+			// Uuid is the uuid argument value.
+			Uuid string
+		}
+		// Reinitialize holds details about calls to the Reinitialize method.
+		Reinitialize []struct {
+		}
+		// Shutdown holds details about calls to the Shutdown method.
+		Shutdown []struct {
+		}
+		// SnapshotAll holds details about calls to the SnapshotAll method.
+		SnapshotAll []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Parallelism is the parallelism argument value.
+			Parallelism int
+		}
+		// Stats holds details about calls to the Stats method.
+		Stats []struct {
+		}
+		// SystemDriverVersion holds details about calls to the SystemDriverVersion method.
+		SystemDriverVersion []struct {
+		}
+		// WaitForEvent holds details about calls to the WaitForEvent method.
+		WaitForEvent []struct {
+			// Es is the es argument value.
+			Es gohlml.EventSet
+			// Timeout is the timeout argument value.
+			Timeout uint
+		}
+		// WaitForEventContext holds details about calls to the WaitForEventContext method.
+		WaitForEventContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Es is the es argument value.
+			Es gohlml.EventSet
+			// Timeout is the timeout argument value.
+			Timeout uint
+		}
+		// WatchDevices holds details about calls to the WatchDevices method.
+		WatchDevices []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Cfg is the cfg argument value.
+			Cfg gohlml.WatcherConfig
+		}
+		// WorkerStatus holds details about calls to the WorkerStatus method.
+		WorkerStatus []struct {
+		}
+	}
+	lockDeleteEventSet                sync.RWMutex
+	lockDeviceCount                   sync.RWMutex
+	lockDeviceCountContext            sync.RWMutex
+	lockDeviceHandleByIndex           sync.RWMutex
+	lockDeviceHandleByIndexContext    sync.RWMutex
+	lockDeviceHandleBySerial          sync.RWMutex
+	lockDeviceHandleBySerialContext   sync.RWMutex
+	lockDeviceHandleByUUID            sync.RWMutex
+	lockDeviceHandleByUUIDContext     sync.RWMutex
+	lockDiscover                      sync.RWMutex
+	lockFWVersion                     sync.RWMutex
+	lockFirmwareVersions              sync.RWMutex
+	lockFirmwareVersionsContext       sync.RWMutex
+	lockGeneration                    sync.RWMutex
+	lockGetDeviceTypeName             sync.RWMutex
+	lockInitWithLogLevel              sync.RWMutex
+	lockInitWithLogs                  sync.RWMutex
+	lockInitialize                    sync.RWMutex
+	lockIsInitialized                 sync.RWMutex
+	lockModuleInfo                    sync.RWMutex
+	lockNewEventSet                   sync.RWMutex
+	lockNumaNodeByBusID               sync.RWMutex
+	lockOwners                        sync.RWMutex
+	lockReadAccelSysfs                sync.RWMutex
+	lockReadEEPROM                    sync.RWMutex
+	lockRegisterEventForDevice        sync.RWMutex
+	lockRegisterEventForDeviceContext sync.RWMutex
+	lockReinitialize                  sync.RWMutex
+	lockShutdown                      sync.RWMutex
+	lockSnapshotAll                   sync.RWMutex
+	lockStats                         sync.RWMutex
+	lockSystemDriverVersion           sync.RWMutex
+	lockWaitForEvent                  sync.RWMutex
+	lockWaitForEventContext           sync.RWMutex
+	lockWatchDevices                  sync.RWMutex
+	lockWorkerStatus                  sync.RWMutex
+}
+
+// DeleteEventSet calls DeleteEventSetFunc.
+func (mock *Interface) DeleteEventSet(es gohlml.EventSet) {
+	callInfo := struct {
+		// Es is the es argument value.
+		Es gohlml.EventSet
+	}{
+		Es: es,
+	}
+	mock.lockDeleteEventSet.Lock()
+	mock.calls.DeleteEventSet = append(mock.calls.DeleteEventSet, callInfo)
+	mock.lockDeleteEventSet.Unlock()
+	if mock.DeleteEventSetFunc == nil {
+		return
+	}
+	mock.DeleteEventSetFunc(es)
+}
+
+// DeleteEventSetCalls gets all the calls that were made to DeleteEventSet.
+func (mock *Interface) DeleteEventSetCalls() []struct {
+	// Es is the es argument value.
+	Es gohlml.EventSet
+} {
+	var calls []struct {
+		// Es is the es argument value.
+		Es gohlml.EventSet
+	}
+	mock.lockDeleteEventSet.RLock()
+	calls = mock.calls.DeleteEventSet
+	mock.lockDeleteEventSet.RUnlock()
+	return calls
+}
+
+// DeviceCount calls DeviceCountFunc.
+func (mock *Interface) DeviceCount() (uint, error) {
+	callInfo := struct {
+	}{}
+	mock.lockDeviceCount.Lock()
+	mock.calls.DeviceCount = append(mock.calls.DeviceCount, callInfo)
+	mock.lockDeviceCount.Unlock()
+	if mock.DeviceCountFunc == nil {
+		var (
+			r0 uint
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.DeviceCountFunc()
+}
+
+// DeviceCountCalls gets all the calls that were made to DeviceCount.
+func (mock *Interface) DeviceCountCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockDeviceCount.RLock()
+	calls = mock.calls.DeviceCount
+	mock.lockDeviceCount.RUnlock()
+	return calls
+}
+
+// DeviceCountContext calls DeviceCountContextFunc.
+func (mock *Interface) DeviceCountContext(ctx context.Context) (uint, error) {
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockDeviceCountContext.Lock()
+	mock.calls.DeviceCountContext = append(mock.calls.DeviceCountContext, callInfo)
+	mock.lockDeviceCountContext.Unlock()
+	if mock.DeviceCountContextFunc == nil {
+		var (
+			r0 uint
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.DeviceCountContextFunc(ctx)
+}
+
+// DeviceCountContextCalls gets all the calls that were made to DeviceCountContext.
+func (mock *Interface) DeviceCountContextCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}
+	mock.lockDeviceCountContext.RLock()
+	calls = mock.calls.DeviceCountContext
+	mock.lockDeviceCountContext.RUnlock()
+	return calls
+}
+
+// DeviceHandleByIndex calls DeviceHandleByIndexFunc.
+func (mock *Interface) DeviceHandleByIndex(idx uint) (gohlml.DeviceInterface, error) {
+	callInfo := struct {
+		// Idx is the idx argument value.
+		Idx uint
+	}{
+		Idx: idx,
+	}
+	mock.lockDeviceHandleByIndex.Lock()
+	mock.calls.DeviceHandleByIndex = append(mock.calls.DeviceHandleByIndex, callInfo)
+	mock.lockDeviceHandleByIndex.Unlock()
+	if mock.DeviceHandleByIndexFunc == nil {
+		var (
+			r0 gohlml.DeviceInterface
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.DeviceHandleByIndexFunc(idx)
+}
+
+// DeviceHandleByIndexCalls gets all the calls that were made to DeviceHandleByIndex.
+func (mock *Interface) DeviceHandleByIndexCalls() []struct {
+	// Idx is the idx argument value.
+	Idx uint
+} {
+	var calls []struct {
+		// Idx is the idx argument value.
+		Idx uint
+	}
+	mock.lockDeviceHandleByIndex.RLock()
+	calls = mock.calls.DeviceHandleByIndex
+	mock.lockDeviceHandleByIndex.RUnlock()
+	return calls
+}
+
+// DeviceHandleByIndexContext calls DeviceHandleByIndexContextFunc.
+func (mock *Interface) DeviceHandleByIndexContext(ctx context.Context, idx uint) (gohlml.DeviceInterface, error) {
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Idx is the idx argument value.
+		Idx uint
+	}{
+		Ctx: ctx,
+		Idx: idx,
+	}
+	mock.lockDeviceHandleByIndexContext.Lock()
+	mock.calls.DeviceHandleByIndexContext = append(mock.calls.DeviceHandleByIndexContext, callInfo)
+	mock.lockDeviceHandleByIndexContext.Unlock()
+	if mock.DeviceHandleByIndexContextFunc == nil {
+		var (
+			r0 gohlml.DeviceInterface
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.DeviceHandleByIndexContextFunc(ctx, idx)
+}
+
+// DeviceHandleByIndexContextCalls gets all the calls that were made to DeviceHandleByIndexContext.
+func (mock *Interface) DeviceHandleByIndexContextCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// Idx is the idx argument value.
+	Idx uint
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Idx is the idx argument value.
+		Idx uint
+	}
+	mock.lockDeviceHandleByIndexContext.RLock()
+	calls = mock.calls.DeviceHandleByIndexContext
+	mock.lockDeviceHandleByIndexContext.RUnlock()
+	return calls
+}
+
+// DeviceHandleBySerial calls DeviceHandleBySerialFunc.
+func (mock *Interface) DeviceHandleBySerial(serial string) (gohlml.DeviceInterface, error) {
+	callInfo := struct {
+		// Serial is the serial argument value.
+		Serial string
+	}{
+		Serial: serial,
+	}
+	mock.lockDeviceHandleBySerial.Lock()
+	mock.calls.DeviceHandleBySerial = append(mock.calls.DeviceHandleBySerial, callInfo)
+	mock.lockDeviceHandleBySerial.Unlock()
+	if mock.DeviceHandleBySerialFunc == nil {
+		var (
+			r0 gohlml.DeviceInterface
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.DeviceHandleBySerialFunc(serial)
+}
+
+// DeviceHandleBySerialCalls gets all the calls that were made to DeviceHandleBySerial.
+func (mock *Interface) DeviceHandleBySerialCalls() []struct {
+	// Serial is the serial argument value.
+	Serial string
+} {
+	var calls []struct {
+		// Serial is the serial argument value.
+		Serial string
+	}
+	mock.lockDeviceHandleBySerial.RLock()
+	calls = mock.calls.DeviceHandleBySerial
+	mock.lockDeviceHandleBySerial.RUnlock()
+	return calls
+}
+
+// DeviceHandleBySerialContext calls DeviceHandleBySerialContextFunc.
+func (mock *Interface) DeviceHandleBySerialContext(ctx context.Context, serial string) (gohlml.DeviceInterface, error) {
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Serial is the serial argument value.
+		Serial string
+	}{
+		Ctx:    ctx,
+		Serial: serial,
+	}
+	mock.lockDeviceHandleBySerialContext.Lock()
+	mock.calls.DeviceHandleBySerialContext = append(mock.calls.DeviceHandleBySerialContext, callInfo)
+	mock.lockDeviceHandleBySerialContext.Unlock()
+	if mock.DeviceHandleBySerialContextFunc == nil {
+		var (
+			r0 gohlml.DeviceInterface
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.DeviceHandleBySerialContextFunc(ctx, serial)
+}
+
+// DeviceHandleBySerialContextCalls gets all the calls that were made to DeviceHandleBySerialContext.
+func (mock *Interface) DeviceHandleBySerialContextCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// Serial is the serial argument value.
+	Serial string
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Serial is the serial argument value.
+		Serial string
+	}
+	mock.lockDeviceHandleBySerialContext.RLock()
+	calls = mock.calls.DeviceHandleBySerialContext
+	mock.lockDeviceHandleBySerialContext.RUnlock()
+	return calls
+}
+
+// DeviceHandleByUUID calls DeviceHandleByUUIDFunc.
+func (mock *Interface) DeviceHandleByUUID(uuid string) (gohlml.DeviceInterface, error) {
+	callInfo := struct {
+		// Uuid is the uuid argument value.
+		Uuid string
+	}{
+		Uuid: uuid,
+	}
+	mock.lockDeviceHandleByUUID.Lock()
+	mock.calls.DeviceHandleByUUID = append(mock.calls.DeviceHandleByUUID, callInfo)
+	mock.lockDeviceHandleByUUID.Unlock()
+	if mock.DeviceHandleByUUIDFunc == nil {
+		var (
+			r0 gohlml.DeviceInterface
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.DeviceHandleByUUIDFunc(uuid)
+}
+
+// DeviceHandleByUUIDCalls gets all the calls that were made to DeviceHandleByUUID.
+func (mock *Interface) DeviceHandleByUUIDCalls() []struct {
+	// Uuid is the uuid argument value.
+	Uuid string
+} {
+	var calls []struct {
+		// Uuid is the uuid argument value.
+		Uuid string
+	}
+	mock.lockDeviceHandleByUUID.RLock()
+	calls = mock.calls.DeviceHandleByUUID
+	mock.lockDeviceHandleByUUID.RUnlock()
+	return calls
+}
+
+// DeviceHandleByUUIDContext calls DeviceHandleByUUIDContextFunc.
+func (mock *Interface) DeviceHandleByUUIDContext(ctx context.Context, uuid string) (gohlml.DeviceInterface, error) {
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Uuid is the uuid argument value.
+		Uuid string
+	}{
+		Ctx:  ctx,
+		Uuid: uuid,
+	}
+	mock.lockDeviceHandleByUUIDContext.Lock()
+	mock.calls.DeviceHandleByUUIDContext = append(mock.calls.DeviceHandleByUUIDContext, callInfo)
+	mock.lockDeviceHandleByUUIDContext.Unlock()
+	if mock.DeviceHandleByUUIDContextFunc == nil {
+		var (
+			r0 gohlml.DeviceInterface
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.DeviceHandleByUUIDContextFunc(ctx, uuid)
+}
+
+// DeviceHandleByUUIDContextCalls gets all the calls that were made to DeviceHandleByUUIDContext.
+func (mock *Interface) DeviceHandleByUUIDContextCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// Uuid is the uuid argument value.
+	Uuid string
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Uuid is the uuid argument value.
+		Uuid string
+	}
+	mock.lockDeviceHandleByUUIDContext.RLock()
+	calls = mock.calls.DeviceHandleByUUIDContext
+	mock.lockDeviceHandleByUUIDContext.RUnlock()
+	return calls
+}
+
+// Discover calls DiscoverFunc.
+func (mock *Interface) Discover() ([]gohlml.PCIDevice, error) {
+	callInfo := struct {
+	}{}
+	mock.lockDiscover.Lock()
+	mock.calls.Discover = append(mock.calls.Discover, callInfo)
+	mock.lockDiscover.Unlock()
+	if mock.DiscoverFunc == nil {
+		var (
+			r0 []gohlml.PCIDevice
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.DiscoverFunc()
+}
+
+// DiscoverCalls gets all the calls that were made to Discover.
+func (mock *Interface) DiscoverCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockDiscover.RLock()
+	calls = mock.calls.Discover
+	mock.lockDiscover.RUnlock()
+	return calls
+}
+
+// FWVersion calls FWVersionFunc.
+func (mock *Interface) FWVersion(idx uint) (string, string, error) {
+	callInfo := struct {
+		// Idx is the idx argument value.
+		Idx uint
+	}{
+		Idx: idx,
+	}
+	mock.lockFWVersion.Lock()
+	mock.calls.FWVersion = append(mock.calls.FWVersion, callInfo)
+	mock.lockFWVersion.Unlock()
+	if mock.FWVersionFunc == nil {
+		var (
+			r0 string
+			r1 string
+			r2 error
+		)
+		return r0, r1, r2
+	}
+	return mock.FWVersionFunc(idx)
+}
+
+// FWVersionCalls gets all the calls that were made to FWVersion.
+func (mock *Interface) FWVersionCalls() []struct {
+	// Idx is the idx argument value.
+	Idx uint
+} {
+	var calls []struct {
+		// Idx is the idx argument value.
+		Idx uint
+	}
+	mock.lockFWVersion.RLock()
+	calls = mock.calls.FWVersion
+	mock.lockFWVersion.RUnlock()
+	return calls
+}
+
+// FirmwareVersions calls FirmwareVersionsFunc.
+func (mock *Interface) FirmwareVersions(dev gohlml.DeviceInterface) (map[string]string, error) {
+	callInfo := struct {
+		// Dev is the dev argument value.
+		Dev gohlml.DeviceInterface
+	}{
+		Dev: dev,
+	}
+	mock.lockFirmwareVersions.Lock()
+	mock.calls.FirmwareVersions = append(mock.calls.FirmwareVersions, callInfo)
+	mock.lockFirmwareVersions.Unlock()
+	if mock.FirmwareVersionsFunc == nil {
+		var (
+			r0 map[string]string
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.FirmwareVersionsFunc(dev)
+}
+
+// FirmwareVersionsCalls gets all the calls that were made to FirmwareVersions.
+func (mock *Interface) FirmwareVersionsCalls() []struct {
+	// Dev is the dev argument value.
+	Dev gohlml.DeviceInterface
+} {
+	var calls []struct {
+		// Dev is the dev argument value.
+		Dev gohlml.DeviceInterface
+	}
+	mock.lockFirmwareVersions.RLock()
+	calls = mock.calls.FirmwareVersions
+	mock.lockFirmwareVersions.RUnlock()
+	return calls
+}
+
+// FirmwareVersionsContext calls FirmwareVersionsContextFunc.
+func (mock *Interface) FirmwareVersionsContext(ctx context.Context, dev gohlml.DeviceInterface) (map[string]string, error) {
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Dev is the dev argument value.
+		Dev gohlml.DeviceInterface
+	}{
+		Ctx: ctx,
+		Dev: dev,
+	}
+	mock.lockFirmwareVersionsContext.Lock()
+	mock.calls.FirmwareVersionsContext = append(mock.calls.FirmwareVersionsContext, callInfo)
+	mock.lockFirmwareVersionsContext.Unlock()
+	if mock.FirmwareVersionsContextFunc == nil {
+		var (
+			r0 map[string]string
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.FirmwareVersionsContextFunc(ctx, dev)
+}
+
+// FirmwareVersionsContextCalls gets all the calls that were made to FirmwareVersionsContext.
+func (mock *Interface) FirmwareVersionsContextCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// Dev is the dev argument value.
+	Dev gohlml.DeviceInterface
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Dev is the dev argument value.
+		Dev gohlml.DeviceInterface
+	}
+	mock.lockFirmwareVersionsContext.RLock()
+	calls = mock.calls.FirmwareVersionsContext
+	mock.lockFirmwareVersionsContext.RUnlock()
+	return calls
+}
+
+// Generation calls GenerationFunc.
+func (mock *Interface) Generation() uint64 {
+	callInfo := struct {
+	}{}
+	mock.lockGeneration.Lock()
+	mock.calls.Generation = append(mock.calls.Generation, callInfo)
+	mock.lockGeneration.Unlock()
+	if mock.GenerationFunc == nil {
+		var (
+			r0 uint64
+		)
+		return r0
+	}
+	return mock.GenerationFunc()
+}
+
+// GenerationCalls gets all the calls that were made to Generation.
+func (mock *Interface) GenerationCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGeneration.RLock()
+	calls = mock.calls.Generation
+	mock.lockGeneration.RUnlock()
+	return calls
+}
+
+// GetDeviceTypeName calls GetDeviceTypeNameFunc.
+func (mock *Interface) GetDeviceTypeName() (string, error) {
+	callInfo := struct {
+	}{}
+	mock.lockGetDeviceTypeName.Lock()
+	mock.calls.GetDeviceTypeName = append(mock.calls.GetDeviceTypeName, callInfo)
+	mock.lockGetDeviceTypeName.Unlock()
+	if mock.GetDeviceTypeNameFunc == nil {
+		var (
+			r0 string
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.GetDeviceTypeNameFunc()
+}
+
+// GetDeviceTypeNameCalls gets all the calls that were made to GetDeviceTypeName.
+func (mock *Interface) GetDeviceTypeNameCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockGetDeviceTypeName.RLock()
+	calls = mock.calls.GetDeviceTypeName
+	mock.lockGetDeviceTypeName.RUnlock()
+	return calls
+}
+
+// InitWithLogLevel calls InitWithLogLevelFunc.
+func (mock *Interface) InitWithLogLevel(level gohlml.LogLevel) error {
+	callInfo := struct {
+		// Level is the level argument value.
+		Level gohlml.LogLevel
+	}{
+		Level: level,
+	}
+	mock.lockInitWithLogLevel.Lock()
+	mock.calls.InitWithLogLevel = append(mock.calls.InitWithLogLevel, callInfo)
+	mock.lockInitWithLogLevel.Unlock()
+	if mock.InitWithLogLevelFunc == nil {
+		var (
+			r0 error
+		)
+		return r0
+	}
+	return mock.InitWithLogLevelFunc(level)
+}
+
+// InitWithLogLevelCalls gets all the calls that were made to InitWithLogLevel.
+func (mock *Interface) InitWithLogLevelCalls() []struct {
+	// Level is the level argument value.
+	Level gohlml.LogLevel
+} {
+	var calls []struct {
+		// Level is the level argument value.
+		Level gohlml.LogLevel
+	}
+	mock.lockInitWithLogLevel.RLock()
+	calls = mock.calls.InitWithLogLevel
+	mock.lockInitWithLogLevel.RUnlock()
+	return calls
+}
+
+// InitWithLogs calls InitWithLogsFunc.
+func (mock *Interface) InitWithLogs() error {
+	callInfo := struct {
+	}{}
+	mock.lockInitWithLogs.Lock()
+	mock.calls.InitWithLogs = append(mock.calls.InitWithLogs, callInfo)
+	mock.lockInitWithLogs.Unlock()
+	if mock.InitWithLogsFunc == nil {
+		var (
+			r0 error
+		)
+		return r0
+	}
+	return mock.InitWithLogsFunc()
+}
+
+// InitWithLogsCalls gets all the calls that were made to InitWithLogs.
+func (mock *Interface) InitWithLogsCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockInitWithLogs.RLock()
+	calls = mock.calls.InitWithLogs
+	mock.lockInitWithLogs.RUnlock()
+	return calls
+}
+
+// Initialize calls InitializeFunc.
+func (mock *Interface) Initialize() error {
+	callInfo := struct {
+	}{}
+	mock.lockInitialize.Lock()
+	mock.calls.Initialize = append(mock.calls.Initialize, callInfo)
+	mock.lockInitialize.Unlock()
+	if mock.InitializeFunc == nil {
+		var (
+			r0 error
+		)
+		return r0
+	}
+	return mock.InitializeFunc()
+}
+
+// InitializeCalls gets all the calls that were made to Initialize.
+func (mock *Interface) InitializeCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockInitialize.RLock()
+	calls = mock.calls.Initialize
+	mock.lockInitialize.RUnlock()
+	return calls
+}
+
+// IsInitialized calls IsInitializedFunc.
+func (mock *Interface) IsInitialized() bool {
+	callInfo := struct {
+	}{}
+	mock.lockIsInitialized.Lock()
+	mock.calls.IsInitialized = append(mock.calls.IsInitialized, callInfo)
+	mock.lockIsInitialized.Unlock()
+	if mock.IsInitializedFunc == nil {
+		var (
+			r0 bool
+		)
+		return r0
+	}
+	return mock.IsInitializedFunc()
+}
+
+// IsInitializedCalls gets all the calls that were made to IsInitialized.
+func (mock *Interface) IsInitializedCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockIsInitialized.RLock()
+	calls = mock.calls.IsInitialized
+	mock.lockIsInitialized.RUnlock()
+	return calls
+}
+
+// ModuleInfo calls ModuleInfoFunc.
+func (mock *Interface) ModuleInfo() (gohlml.KernelModule, error) {
+	callInfo := struct {
+	}{}
+	mock.lockModuleInfo.Lock()
+	mock.calls.ModuleInfo = append(mock.calls.ModuleInfo, callInfo)
+	mock.lockModuleInfo.Unlock()
+	if mock.ModuleInfoFunc == nil {
+		var (
+			r0 gohlml.KernelModule
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.ModuleInfoFunc()
+}
+
+// ModuleInfoCalls gets all the calls that were made to ModuleInfo.
+func (mock *Interface) ModuleInfoCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockModuleInfo.RLock()
+	calls = mock.calls.ModuleInfo
+	mock.lockModuleInfo.RUnlock()
+	return calls
+}
+
+// NewEventSet calls NewEventSetFunc.
+func (mock *Interface) NewEventSet() gohlml.EventSet {
+	callInfo := struct {
+	}{}
+	mock.lockNewEventSet.Lock()
+	mock.calls.NewEventSet = append(mock.calls.NewEventSet, callInfo)
+	mock.lockNewEventSet.Unlock()
+	if mock.NewEventSetFunc == nil {
+		var (
+			r0 gohlml.EventSet
+		)
+		return r0
+	}
+	return mock.NewEventSetFunc()
+}
+
+// NewEventSetCalls gets all the calls that were made to NewEventSet.
+func (mock *Interface) NewEventSetCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockNewEventSet.RLock()
+	calls = mock.calls.NewEventSet
+	mock.lockNewEventSet.RUnlock()
+	return calls
+}
+
+// NumaNodeByBusID calls NumaNodeByBusIDFunc.
+func (mock *Interface) NumaNodeByBusID(busID string) (*uint, error) {
+	callInfo := struct {
+		// BusID is the busID argument value.
+		BusID string
+	}{
+		BusID: busID,
+	}
+	mock.lockNumaNodeByBusID.Lock()
+	mock.calls.NumaNodeByBusID = append(mock.calls.NumaNodeByBusID, callInfo)
+	mock.lockNumaNodeByBusID.Unlock()
+	if mock.NumaNodeByBusIDFunc == nil {
+		var (
+			r0 *uint
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.NumaNodeByBusIDFunc(busID)
+}
+
+// NumaNodeByBusIDCalls gets all the calls that were made to NumaNodeByBusID.
+func (mock *Interface) NumaNodeByBusIDCalls() []struct {
+	// BusID is the busID argument value.
+	BusID string
+} {
+	var calls []struct {
+		// BusID is the busID argument value.
+		BusID string
+	}
+	mock.lockNumaNodeByBusID.RLock()
+	calls = mock.calls.NumaNodeByBusID
+	mock.lockNumaNodeByBusID.RUnlock()
+	return calls
+}
+
+// Owners calls OwnersFunc.
+func (mock *Interface) Owners(ctx context.Context, devices []gohlml.DeviceInterface) ([]gohlml.DeviceOwner, error) {
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Devices is the devices argument value.
+		Devices []gohlml.DeviceInterface
+	}{
+		Ctx:     ctx,
+		Devices: devices,
+	}
+	mock.lockOwners.Lock()
+	mock.calls.Owners = append(mock.calls.Owners, callInfo)
+	mock.lockOwners.Unlock()
+	if mock.OwnersFunc == nil {
+		var (
+			r0 []gohlml.DeviceOwner
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.OwnersFunc(ctx, devices)
+}
+
+// OwnersCalls gets all the calls that were made to Owners.
+func (mock *Interface) OwnersCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// Devices is the devices argument value.
+	Devices []gohlml.DeviceInterface
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Devices is the devices argument value.
+		Devices []gohlml.DeviceInterface
+	}
+	mock.lockOwners.RLock()
+	calls = mock.calls.Owners
+	mock.lockOwners.RUnlock()
+	return calls
+}
+
+// ReadAccelSysfs calls ReadAccelSysfsFunc.
+func (mock *Interface) ReadAccelSysfs(minor uint) (gohlml.AccelSysfs, error) {
+	callInfo := struct {
+		// Minor is the minor argument value.
+		Minor uint
+	}{
+		Minor: minor,
+	}
+	mock.lockReadAccelSysfs.Lock()
+	mock.calls.ReadAccelSysfs = append(mock.calls.ReadAccelSysfs, callInfo)
+	mock.lockReadAccelSysfs.Unlock()
+	if mock.ReadAccelSysfsFunc == nil {
+		var (
+			r0 gohlml.AccelSysfs
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.ReadAccelSysfsFunc(minor)
+}
+
+// ReadAccelSysfsCalls gets all the calls that were made to ReadAccelSysfs.
+func (mock *Interface) ReadAccelSysfsCalls() []struct {
+	// Minor is the minor argument value.
+	Minor uint
+} {
+	var calls []struct {
+		// Minor is the minor argument value.
+		Minor uint
+	}
+	mock.lockReadAccelSysfs.RLock()
+	calls = mock.calls.ReadAccelSysfs
+	mock.lockReadAccelSysfs.RUnlock()
+	return calls
+}
+
+// ReadEEPROM calls ReadEEPROMFunc.
+func (mock *Interface) ReadEEPROM(minor uint) (gohlml.EEPROM, error) {
+	callInfo := struct {
+		// Minor is the minor argument value.
+		Minor uint
+	}{
+		Minor: minor,
+	}
+	mock.lockReadEEPROM.Lock()
+	mock.calls.ReadEEPROM = append(mock.calls.ReadEEPROM, callInfo)
+	mock.lockReadEEPROM.Unlock()
+	if mock.ReadEEPROMFunc == nil {
+		var (
+			r0 gohlml.EEPROM
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.ReadEEPROMFunc(minor)
+}
+
+// ReadEEPROMCalls gets all the calls that were made to ReadEEPROM.
+func (mock *Interface) ReadEEPROMCalls() []struct {
+	// Minor is the minor argument value.
+	Minor uint
+} {
+	var calls []struct {
+		// Minor is the minor argument value.
+		Minor uint
+	}
+	mock.lockReadEEPROM.RLock()
+	calls = mock.calls.ReadEEPROM
+	mock.lockReadEEPROM.RUnlock()
+	return calls
+}
+
+// RegisterEventForDevice calls RegisterEventForDeviceFunc.
+func (mock *Interface) RegisterEventForDevice(es gohlml.EventSet, event int, uuid string) error {
+	callInfo := struct {
+		// Es is the es argument value.
+		Es gohlml.EventSet
+		// Event is the event argument value.
+		Event int
+		// Uuid is the uuid argument value.
+		Uuid string
+	}{
+		Es:    es,
+		Event: event,
+		Uuid:  uuid,
+	}
+	mock.lockRegisterEventForDevice.Lock()
+	mock.calls.RegisterEventForDevice = append(mock.calls.RegisterEventForDevice, callInfo)
+	mock.lockRegisterEventForDevice.Unlock()
+	if mock.RegisterEventForDeviceFunc == nil {
+		var (
+			r0 error
+		)
+		return r0
+	}
+	return mock.RegisterEventForDeviceFunc(es, event, uuid)
+}
+
+// RegisterEventForDeviceCalls gets all the calls that were made to RegisterEventForDevice.
+func (mock *Interface) RegisterEventForDeviceCalls() []struct {
+	// Es is the es argument value.
+	Es gohlml.EventSet
+	// Event is the event argument value.
+	Event int
+	// Uuid is the uuid argument value.
+	Uuid string
+} {
+	var calls []struct {
+		// Es is the es argument value.
+		Es gohlml.EventSet
+		// Event is the event argument value.
+		Event int
+		// Uuid is the uuid argument value.
+		Uuid string
+	}
+	mock.lockRegisterEventForDevice.RLock()
+	calls = mock.calls.RegisterEventForDevice
+	mock.lockRegisterEventForDevice.RUnlock()
+	return calls
+}
+
+// RegisterEventForDeviceContext calls RegisterEventForDeviceContextFunc.
+func (mock *Interface) RegisterEventForDeviceContext(ctx context.Context, es gohlml.EventSet, event int, uuid string) error {
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Es is the es argument value.
+		Es gohlml.EventSet
+		// Event is the event argument value.
+		Event int
+		// Uuid is the uuid argument value.
+		Uuid string
+	}{
+		Ctx:   ctx,
+		Es:    es,
+		Event: event,
+		Uuid:  uuid,
+	}
+	mock.lockRegisterEventForDeviceContext.Lock()
+	mock.calls.RegisterEventForDeviceContext = append(mock.calls.RegisterEventForDeviceContext, callInfo)
+	mock.lockRegisterEventForDeviceContext.Unlock()
+	if mock.RegisterEventForDeviceContextFunc == nil {
+		var (
+			r0 error
+		)
+		return r0
+	}
+	return mock.RegisterEventForDeviceContextFunc(ctx, es, event, uuid)
+}
+
+// RegisterEventForDeviceContextCalls gets all the calls that were made to RegisterEventForDeviceContext.
+func (mock *Interface) RegisterEventForDeviceContextCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// Es is the es argument value.
+	Es gohlml.EventSet
+	// Event is the event argument value.
+	Event int
+	// Uuid is the uuid argument value.
+	Uuid string
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Es is the es argument value.
+		Es gohlml.EventSet
+		// Event is the event argument value.
+		Event int
+		// Uuid is the uuid argument value.
+		Uuid string
+	}
+	mock.lockRegisterEventForDeviceContext.RLock()
+	calls = mock.calls.RegisterEventForDeviceContext
+	mock.lockRegisterEventForDeviceContext.RUnlock()
+	return calls
+}
+
+// Reinitialize calls ReinitializeFunc.
+func (mock *Interface) Reinitialize() error {
+	callInfo := struct {
+	}{}
+	mock.lockReinitialize.Lock()
+	mock.calls.Reinitialize = append(mock.calls.Reinitialize, callInfo)
+	mock.lockReinitialize.Unlock()
+	if mock.ReinitializeFunc == nil {
+		var (
+			r0 error
+		)
+		return r0
+	}
+	return mock.ReinitializeFunc()
+}
+
+// ReinitializeCalls gets all the calls that were made to Reinitialize.
+func (mock *Interface) ReinitializeCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockReinitialize.RLock()
+	calls = mock.calls.Reinitialize
+	mock.lockReinitialize.RUnlock()
+	return calls
+}
+
+// Shutdown calls ShutdownFunc.
+func (mock *Interface) Shutdown() error {
+	callInfo := struct {
+	}{}
+	mock.lockShutdown.Lock()
+	mock.calls.Shutdown = append(mock.calls.Shutdown, callInfo)
+	mock.lockShutdown.Unlock()
+	if mock.ShutdownFunc == nil {
+		var (
+			r0 error
+		)
+		return r0
+	}
+	return mock.ShutdownFunc()
+}
+
+// ShutdownCalls gets all the calls that were made to Shutdown.
+func (mock *Interface) ShutdownCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockShutdown.RLock()
+	calls = mock.calls.Shutdown
+	mock.lockShutdown.RUnlock()
+	return calls
+}
+
+// SnapshotAll calls SnapshotAllFunc.
+func (mock *Interface) SnapshotAll(ctx context.Context, parallelism int) ([]gohlml.Snapshot, error) {
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Parallelism is the parallelism argument value.
+		Parallelism int
+	}{
+		Ctx:         ctx,
+		Parallelism: parallelism,
+	}
+	mock.lockSnapshotAll.Lock()
+	mock.calls.SnapshotAll = append(mock.calls.SnapshotAll, callInfo)
+	mock.lockSnapshotAll.Unlock()
+	if mock.SnapshotAllFunc == nil {
+		var (
+			r0 []gohlml.Snapshot
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.SnapshotAllFunc(ctx, parallelism)
+}
+
+// SnapshotAllCalls gets all the calls that were made to SnapshotAll.
+func (mock *Interface) SnapshotAllCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// Parallelism is the parallelism argument value.
+	Parallelism int
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Parallelism is the parallelism argument value.
+		Parallelism int
+	}
+	mock.lockSnapshotAll.RLock()
+	calls = mock.calls.SnapshotAll
+	mock.lockSnapshotAll.RUnlock()
+	return calls
+}
+
+// Stats calls StatsFunc.
+func (mock *Interface) Stats() map[string]gohlml.CallStats {
+	callInfo := struct {
+	}{}
+	mock.lockStats.Lock()
+	mock.calls.Stats = append(mock.calls.Stats, callInfo)
+	mock.lockStats.Unlock()
+	if mock.StatsFunc == nil {
+		var (
+			r0 map[string]gohlml.CallStats
+		)
+		return r0
+	}
+	return mock.StatsFunc()
+}
+
+// StatsCalls gets all the calls that were made to Stats.
+func (mock *Interface) StatsCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockStats.RLock()
+	calls = mock.calls.Stats
+	mock.lockStats.RUnlock()
+	return calls
+}
+
+// SystemDriverVersion calls SystemDriverVersionFunc.
+func (mock *Interface) SystemDriverVersion() (string, error) {
+	callInfo := struct {
+	}{}
+	mock.lockSystemDriverVersion.Lock()
+	mock.calls.SystemDriverVersion = append(mock.calls.SystemDriverVersion, callInfo)
+	mock.lockSystemDriverVersion.Unlock()
+	if mock.SystemDriverVersionFunc == nil {
+		var (
+			r0 string
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.SystemDriverVersionFunc()
+}
+
+// SystemDriverVersionCalls gets all the calls that were made to SystemDriverVersion.
+func (mock *Interface) SystemDriverVersionCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockSystemDriverVersion.RLock()
+	calls = mock.calls.SystemDriverVersion
+	mock.lockSystemDriverVersion.RUnlock()
+	return calls
+}
+
+// WaitForEvent calls WaitForEventFunc.
+func (mock *Interface) WaitForEvent(es gohlml.EventSet, timeout uint) (gohlml.Event, error) {
+	callInfo := struct {
+		// Es is the es argument value.
+		Es gohlml.EventSet
+		// Timeout is the timeout argument value.
+		Timeout uint
+	}{
+		Es:      es,
+		Timeout: timeout,
+	}
+	mock.lockWaitForEvent.Lock()
+	mock.calls.WaitForEvent = append(mock.calls.WaitForEvent, callInfo)
+	mock.lockWaitForEvent.Unlock()
+	if mock.WaitForEventFunc == nil {
+		var (
+			r0 gohlml.Event
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.WaitForEventFunc(es, timeout)
+}
+
+// WaitForEventCalls gets all the calls that were made to WaitForEvent.
+func (mock *Interface) WaitForEventCalls() []struct {
+	// Es is the es argument value.
+	Es gohlml.EventSet
+	// Timeout is the timeout argument value.
+	Timeout uint
+} {
+	var calls []struct {
+		// Es is the es argument value.
+		Es gohlml.EventSet
+		// Timeout is the timeout argument value.
+		Timeout uint
+	}
+	mock.lockWaitForEvent.RLock()
+	calls = mock.calls.WaitForEvent
+	mock.lockWaitForEvent.RUnlock()
+	return calls
+}
+
+// WaitForEventContext calls WaitForEventContextFunc.
+func (mock *Interface) WaitForEventContext(ctx context.Context, es gohlml.EventSet, timeout uint) (gohlml.Event, error) {
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Es is the es argument value.
+		Es gohlml.EventSet
+		// Timeout is the timeout argument value.
+		Timeout uint
+	}{
+		Ctx:     ctx,
+		Es:      es,
+		Timeout: timeout,
+	}
+	mock.lockWaitForEventContext.Lock()
+	mock.calls.WaitForEventContext = append(mock.calls.WaitForEventContext, callInfo)
+	mock.lockWaitForEventContext.Unlock()
+	if mock.WaitForEventContextFunc == nil {
+		var (
+			r0 gohlml.Event
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.WaitForEventContextFunc(ctx, es, timeout)
+}
+
+// WaitForEventContextCalls gets all the calls that were made to WaitForEventContext.
+func (mock *Interface) WaitForEventContextCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// Es is the es argument value.
+	Es gohlml.EventSet
+	// Timeout is the timeout argument value.
+	Timeout uint
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Es is the es argument value.
+		Es gohlml.EventSet
+		// Timeout is the timeout argument value.
+		Timeout uint
+	}
+	mock.lockWaitForEventContext.RLock()
+	calls = mock.calls.WaitForEventContext
+	mock.lockWaitForEventContext.RUnlock()
+	return calls
+}
+
+// WatchDevices calls WatchDevicesFunc.
+func (mock *Interface) WatchDevices(ctx context.Context, cfg gohlml.WatcherConfig) (<-chan gohlml.HotplugEvent, error) {
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Cfg is the cfg argument value.
+		Cfg gohlml.WatcherConfig
+	}{
+		Ctx: ctx,
+		Cfg: cfg,
+	}
+	mock.lockWatchDevices.Lock()
+	mock.calls.WatchDevices = append(mock.calls.WatchDevices, callInfo)
+	mock.lockWatchDevices.Unlock()
+	if mock.WatchDevicesFunc == nil {
+		var (
+			r0 <-chan gohlml.HotplugEvent
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.WatchDevicesFunc(ctx, cfg)
+}
+
+// WatchDevicesCalls gets all the calls that were made to WatchDevices.
+func (mock *Interface) WatchDevicesCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// Cfg is the cfg argument value.
+	Cfg gohlml.WatcherConfig
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Cfg is the cfg argument value.
+		Cfg gohlml.WatcherConfig
+	}
+	mock.lockWatchDevices.RLock()
+	calls = mock.calls.WatchDevices
+	mock.lockWatchDevices.RUnlock()
+	return calls
+}
+
+// WorkerStatus calls WorkerStatusFunc.
+func (mock *Interface) WorkerStatus() gohlml.WorkerState {
+	callInfo := struct {
+	}{}
+	mock.lockWorkerStatus.Lock()
+	mock.calls.WorkerStatus = append(mock.calls.WorkerStatus, callInfo)
+	mock.lockWorkerStatus.Unlock()
+	if mock.WorkerStatusFunc == nil {
+		var (
+			r0 gohlml.WorkerState
+		)
+		return r0
+	}
+	return mock.WorkerStatusFunc()
+}
+
+// WorkerStatusCalls gets all the calls that were made to WorkerStatus.
+func (mock *Interface) WorkerStatusCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockWorkerStatus.RLock()
+	calls = mock.calls.WorkerStatus
+	mock.lockWorkerStatus.RUnlock()
+	return calls
+}
+
+// Ensure that Device implements gohlml.DeviceInterface.
+var _ gohlml.DeviceInterface = &Device{}
+
+// Device is a mock implementation of gohlml.DeviceInterface.
+type Device struct {
+	// BoardIDFunc mocks the BoardID method.
+	BoardIDFunc func() (uint, error)
+
+	// BoardIDContextFunc mocks the BoardIDContext method.
+	BoardIDContextFunc func(ctx context.Context) (uint, error)
+
+	// ClockThrottleReasonsFunc mocks the ClockThrottleReasons method.
+	ClockThrottleReasonsFunc func() (uint64, error)
+
+	// ClockThrottleReasonsContextFunc mocks the ClockThrottleReasonsContext method.
+	ClockThrottleReasonsContextFunc func(ctx context.Context) (uint64, error)
+
+	// DeviceNodesFunc mocks the DeviceNodes method.
+	DeviceNodesFunc func() ([]gohlml.DeviceNode, error)
+
+	// DeviceNodesContextFunc mocks the DeviceNodesContext method.
+	DeviceNodesContextFunc func(ctx context.Context) ([]gohlml.DeviceNode, error)
+
+	// ECCModeFunc mocks the ECCMode method.
+	ECCModeFunc func() (uint, uint, error)
+
+	// ECCModeContextFunc mocks the ECCModeContext method.
+	ECCModeContextFunc func(ctx context.Context) (uint, uint, error)
+
+	// EnergyConsumptionCounterFunc mocks the EnergyConsumptionCounter method.
+	EnergyConsumptionCounterFunc func() (uint64, error)
+
+	// EnergyConsumptionCounterContextFunc mocks the EnergyConsumptionCounterContext method.
+	EnergyConsumptionCounterContextFunc func(ctx context.Context) (uint64, error)
+
+	// HLRevisionFunc mocks the HLRevision method.
+	HLRevisionFunc func() (int, error)
+
+	// HLRevisionContextFunc mocks the HLRevisionContext method.
+	HLRevisionContextFunc func(ctx context.Context) (int, error)
+
+	// HwmonSensorsFunc mocks the HwmonSensors method.
+	HwmonSensorsFunc func() ([]gohlml.HwmonSensor, error)
+
+	// HwmonSensorsContextFunc mocks the HwmonSensorsContext method.
+	HwmonSensorsContextFunc func(ctx context.Context) ([]gohlml.HwmonSensor, error)
+
+	// ICClockMaxFunc mocks the ICClockMax method.
+	ICClockMaxFunc func() (uint, error)
+
+	// ICClockMaxContextFunc mocks the ICClockMaxContext method.
+	ICClockMaxContextFunc func(ctx context.Context) (uint, error)
+
+	// IsReplacedRowsPendingStatusFunc mocks the IsReplacedRowsPendingStatus method.
+	IsReplacedRowsPendingStatusFunc func() (int, error)
+
+	// IsReplacedRowsPendingStatusContextFunc mocks the IsReplacedRowsPendingStatusContext method.
+	IsReplacedRowsPendingStatusContextFunc func(ctx context.Context) (int, error)
+
+	// MMEClockMaxFunc mocks the MMEClockMax method.
+	MMEClockMaxFunc func() (uint, error)
+
+	// MMEClockMaxContextFunc mocks the MMEClockMaxContext method.
+	MMEClockMaxContextFunc func(ctx context.Context) (uint, error)
+
+	// MacAddressInfoFunc mocks the MacAddressInfo method.
+	MacAddressInfoFunc func() (map[int]string, error)
+
+	// MacAddressInfoContextFunc mocks the MacAddressInfoContext method.
+	MacAddressInfoContextFunc func(ctx context.Context) (map[int]string, error)
+
+	// MemoryInfoFunc mocks the MemoryInfo method.
+	MemoryInfoFunc func() (uint64, uint64, uint64, error)
+
+	// MemoryInfoContextFunc mocks the MemoryInfoContext method.
+	MemoryInfoContextFunc func(ctx context.Context) (uint64, uint64, uint64, error)
+
+	// MinorNumberFunc mocks the MinorNumber method.
+	MinorNumberFunc func() (uint, error)
+
+	// MinorNumberContextFunc mocks the MinorNumberContext method.
+	MinorNumberContextFunc func(ctx context.Context) (uint, error)
+
+	// ModuleIDFunc mocks the ModuleID method.
+	ModuleIDFunc func() (uint, error)
+
+	// ModuleIDContextFunc mocks the ModuleIDContext method.
+	ModuleIDContextFunc func(ctx context.Context) (uint, error)
+
+	// NameFunc mocks the Name method.
+	NameFunc func() (string, error)
+
+	// NameContextFunc mocks the NameContext method.
+	NameContextFunc func(ctx context.Context) (string, error)
+
+	// NicLinkStatusFunc mocks the NicLinkStatus method.
+	NicLinkStatusFunc func(port uint) (uint, error)
+
+	// NicLinkStatusContextFunc mocks the NicLinkStatusContext method.
+	NicLinkStatusContextFunc func(ctx context.Context, port uint) (uint, error)
+
+	// NumaNodeFunc mocks the NumaNode method.
+	NumaNodeFunc func() (*uint, error)
+
+	// NumaNodeContextFunc mocks the NumaNodeContext method.
+	NumaNodeContextFunc func(ctx context.Context) (*uint, error)
+
+	// PCBAssemblyVersionFunc mocks the PCBAssemblyVersion method.
+	PCBAssemblyVersionFunc func() (string, error)
+
+	// PCBAssemblyVersionContextFunc mocks the PCBAssemblyVersionContext method.
+	PCBAssemblyVersionContextFunc func(ctx context.Context) (string, error)
+
+	// PCBVersionFunc mocks the PCBVersion method.
+	PCBVersionFunc func() (string, error)
+
+	// PCBVersionContextFunc mocks the PCBVersionContext method.
+	PCBVersionContextFunc func(ctx context.Context) (string, error)
+
+	// PCIBusFunc mocks the PCIBus method.
+	PCIBusFunc func() (uint, error)
+
+	// PCIBusContextFunc mocks the PCIBusContext method.
+	PCIBusContextFunc func(ctx context.Context) (uint, error)
+
+	// PCIBusIDFunc mocks the PCIBusID method.
+	PCIBusIDFunc func() (string, error)
+
+	// PCIBusIDContextFunc mocks the PCIBusIDContext method.
+	PCIBusIDContextFunc func(ctx context.Context) (string, error)
+
+	// PCIDomainFunc mocks the PCIDomain method.
+	PCIDomainFunc func() (uint, error)
+
+	// PCIDomainContextFunc mocks the PCIDomainContext method.
+	PCIDomainContextFunc func(ctx context.Context) (uint, error)
+
+	// PCIIDFunc mocks the PCIID method.
+	PCIIDFunc func() (uint, error)
+
+	// PCIIDContextFunc mocks the PCIIDContext method.
+	PCIIDContextFunc func(ctx context.Context) (uint, error)
+
+	// PCILinkSpeedFunc mocks the PCILinkSpeed method.
+	PCILinkSpeedFunc func() (uint, error)
+
+	// PCILinkSpeedContextFunc mocks the PCILinkSpeedContext method.
+	PCILinkSpeedContextFunc func(ctx context.Context) (uint, error)
+
+	// PCILinkWidthFunc mocks the PCILinkWidth method.
+	PCILinkWidthFunc func() (uint, error)
+
+	// PCILinkWidthContextFunc mocks the PCILinkWidthContext method.
+	PCILinkWidthContextFunc func(ctx context.Context) (uint, error)
+
+	// PCIReplayCounterFunc mocks the PCIReplayCounter method.
+	PCIReplayCounterFunc func() (uint, error)
+
+	// PCIReplayCounterContextFunc mocks the PCIReplayCounterContext method.
+	PCIReplayCounterContextFunc func(ctx context.Context) (uint, error)
+
+	// PCIeLinkGenerationFunc mocks the PCIeLinkGeneration method.
+	PCIeLinkGenerationFunc func() (uint, error)
+
+	// PCIeLinkGenerationContextFunc mocks the PCIeLinkGenerationContext method.
+	PCIeLinkGenerationContextFunc func(ctx context.Context) (uint, error)
+
+	// PCIeLinkWidthFunc mocks the PCIeLinkWidth method.
+	PCIeLinkWidthFunc func() (uint, error)
+
+	// PCIeLinkWidthContextFunc mocks the PCIeLinkWidthContext method.
+	PCIeLinkWidthContextFunc func(ctx context.Context) (uint, error)
+
+	// PCIeRXFunc mocks the PCIeRX method.
+	PCIeRXFunc func() (uint, error)
+
+	// PCIeRXContextFunc mocks the PCIeRXContext method.
+	PCIeRXContextFunc func(ctx context.Context) (uint, error)
+
+	// PCIeTXFunc mocks the PCIeTX method.
+	PCIeTXFunc func() (uint, error)
+
+	// PCIeTXContextFunc mocks the PCIeTXContext method.
+	PCIeTXContextFunc func(ctx context.Context) (uint, error)
+
+	// PowerManagementDefaultLimitFunc mocks the PowerManagementDefaultLimit method.
+	PowerManagementDefaultLimitFunc func() (uint, error)
+
+	// PowerManagementDefaultLimitContextFunc mocks the PowerManagementDefaultLimitContext method.
+	PowerManagementDefaultLimitContextFunc func(ctx context.Context) (uint, error)
+
+	// PowerUsageFunc mocks the PowerUsage method.
+	PowerUsageFunc func() (uint, error)
+
+	// PowerUsageContextFunc mocks the PowerUsageContext method.
+	PowerUsageContextFunc func(ctx context.Context) (uint, error)
+
+	// ProcessesFunc mocks the Processes method.
+	ProcessesFunc func() ([]gohlml.Process, error)
+
+	// ProcessesContextFunc mocks the ProcessesContext method.
+	ProcessesContextFunc func(ctx context.Context) ([]gohlml.Process, error)
+
+	// ReplacedRowDoubleBitECCFunc mocks the ReplacedRowDoubleBitECC method.
+	ReplacedRowDoubleBitECCFunc func() (uint, error)
+
+	// ReplacedRowDoubleBitECCContextFunc mocks the ReplacedRowDoubleBitECCContext method.
+	ReplacedRowDoubleBitECCContextFunc func(ctx context.Context) (uint, error)
+
+	// ReplacedRowSingleBitECCFunc mocks the ReplacedRowSingleBitECC method.
+	ReplacedRowSingleBitECCFunc func() (uint, error)
+
+	// ReplacedRowSingleBitECCContextFunc mocks the ReplacedRowSingleBitECCContext method.
+	ReplacedRowSingleBitECCContextFunc func(ctx context.Context) (uint, error)
+
+	// SOCClockInfoFunc mocks the SOCClockInfo method.
+	SOCClockInfoFunc func() (uint, error)
+
+	// SOCClockInfoContextFunc mocks the SOCClockInfoContext method.
+	SOCClockInfoContextFunc func(ctx context.Context) (uint, error)
+
+	// SOCClockMaxFunc mocks the SOCClockMax method.
+	SOCClockMaxFunc func() (uint, error)
+
+	// SOCClockMaxContextFunc mocks the SOCClockMaxContext method.
+	SOCClockMaxContextFunc func(ctx context.Context) (uint, error)
+
+	// SerialNumberFunc mocks the SerialNumber method.
+	SerialNumberFunc func() (string, error)
+
+	// SerialNumberContextFunc mocks the SerialNumberContext method.
+	SerialNumberContextFunc func(ctx context.Context) (string, error)
+
+	// SnapshotFunc mocks the Snapshot method.
+	SnapshotFunc func() gohlml.Snapshot
+
+	// SnapshotContextFunc mocks the SnapshotContext method.
+	SnapshotContextFunc func(ctx context.Context) gohlml.Snapshot
+
+	// TPCClockMaxFunc mocks the TPCClockMax method.
+	TPCClockMaxFunc func() (uint, error)
+
+	// TPCClockMaxContextFunc mocks the TPCClockMaxContext method.
+	TPCClockMaxContextFunc func(ctx context.Context) (uint, error)
+
+	// TemperatureOnBoardFunc mocks the TemperatureOnBoard method.
+	TemperatureOnBoardFunc func() (uint, error)
+
+	// TemperatureOnBoardContextFunc mocks the TemperatureOnBoardContext method.
+	TemperatureOnBoardContextFunc func(ctx context.Context) (uint, error)
+
+	// TemperatureOnChipFunc mocks the TemperatureOnChip method.
+	TemperatureOnChipFunc func() (uint, error)
+
+	// TemperatureOnChipContextFunc mocks the TemperatureOnChipContext method.
+	TemperatureOnChipContextFunc func(ctx context.Context) (uint, error)
+
+	// TemperatureThresholdGPUFunc mocks the TemperatureThresholdGPU method.
+	TemperatureThresholdGPUFunc func() (uint, error)
+
+	// TemperatureThresholdGPUContextFunc mocks the TemperatureThresholdGPUContext method.
+	TemperatureThresholdGPUContextFunc func(ctx context.Context) (uint, error)
+
+	// TemperatureThresholdMemoryFunc mocks the TemperatureThresholdMemory method.
+	TemperatureThresholdMemoryFunc func() (uint, error)
+
+	// TemperatureThresholdMemoryContextFunc mocks the TemperatureThresholdMemoryContext method.
+	TemperatureThresholdMemoryContextFunc func(ctx context.Context) (uint, error)
+
+	// TemperatureThresholdShutdownFunc mocks the TemperatureThresholdShutdown method.
+	TemperatureThresholdShutdownFunc func() (uint, error)
+
+	// TemperatureThresholdShutdownContextFunc mocks the TemperatureThresholdShutdownContext method.
+	TemperatureThresholdShutdownContextFunc func(ctx context.Context) (uint, error)
+
+	// TemperatureThresholdSlowdownFunc mocks the TemperatureThresholdSlowdown method.
+	TemperatureThresholdSlowdownFunc func() (uint, error)
+
+	// TemperatureThresholdSlowdownContextFunc mocks the TemperatureThresholdSlowdownContext method.
+	TemperatureThresholdSlowdownContextFunc func(ctx context.Context) (uint, error)
+
+	// UUIDFunc mocks the UUID method.
+	UUIDFunc func() (string, error)
+
+	// UUIDContextFunc mocks the UUIDContext method.
+	UUIDContextFunc func(ctx context.Context) (string, error)
+
+	// UtilizationInfoFunc mocks the UtilizationInfo method.
+	UtilizationInfoFunc func() (uint, error)
+
+	// UtilizationInfoContextFunc mocks the UtilizationInfoContext method.
+	UtilizationInfoContextFunc func(ctx context.Context) (uint, error)
+
+	// calls tracks calls to the methods.
+	calls struct {
+		// BoardID holds details about calls to the BoardID method.
+		BoardID []struct {
+		}
+		// BoardIDContext holds details about calls to the BoardIDContext method.
+		BoardIDContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// ClockThrottleReasons holds details about calls to the ClockThrottleReasons method.
+		ClockThrottleReasons []struct {
+		}
+		// ClockThrottleReasonsContext holds details about calls to the ClockThrottleReasonsContext method.
+		ClockThrottleReasonsContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// DeviceNodes holds details about calls to the DeviceNodes method.
+		DeviceNodes []struct {
+		}
+		// DeviceNodesContext holds details about calls to the DeviceNodesContext method.
+		DeviceNodesContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// ECCMode holds details about calls to the ECCMode method.
+		ECCMode []struct {
+		}
+		// ECCModeContext holds details about calls to the ECCModeContext method.
+		ECCModeContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// EnergyConsumptionCounter holds details about calls to the EnergyConsumptionCounter method.
+		EnergyConsumptionCounter []struct {
+		}
+		// EnergyConsumptionCounterContext holds details about calls to the EnergyConsumptionCounterContext method.
+		EnergyConsumptionCounterContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// HLRevision holds details about calls to the HLRevision method.
+		HLRevision []struct {
+		}
+		// HLRevisionContext holds details about calls to the HLRevisionContext method.
+		HLRevisionContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// HwmonSensors holds details about calls to the HwmonSensors method.
+		HwmonSensors []struct {
+		}
+		// HwmonSensorsContext holds details about calls to the HwmonSensorsContext method.
+		HwmonSensorsContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// ICClockMax holds details about calls to the ICClockMax method.
+		ICClockMax []struct {
+		}
+		// ICClockMaxContext holds details about calls to the ICClockMaxContext method.
+		ICClockMaxContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// IsReplacedRowsPendingStatus holds details about calls to the IsReplacedRowsPendingStatus method.
+		IsReplacedRowsPendingStatus []struct {
+		}
+		// IsReplacedRowsPendingStatusContext holds details about calls to the IsReplacedRowsPendingStatusContext method.
+		IsReplacedRowsPendingStatusContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// MMEClockMax holds details about calls to the MMEClockMax method.
+		MMEClockMax []struct {
+		}
+		// MMEClockMaxContext holds details about calls to the MMEClockMaxContext method.
+		MMEClockMaxContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// MacAddressInfo holds details about calls to the MacAddressInfo method.
+		MacAddressInfo []struct {
+		}
+		// MacAddressInfoContext holds details about calls to the MacAddressInfoContext method.
+		MacAddressInfoContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// MemoryInfo holds details about calls to the MemoryInfo method.
+		MemoryInfo []struct {
+		}
+		// MemoryInfoContext holds details about calls to the MemoryInfoContext method.
+		MemoryInfoContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// MinorNumber holds details about calls to the MinorNumber method.
+		MinorNumber []struct {
+		}
+		// MinorNumberContext holds details about calls to the MinorNumberContext method.
+		MinorNumberContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// ModuleID holds details about calls to the ModuleID method.
+		ModuleID []struct {
+		}
+		// ModuleIDContext holds details about calls to the ModuleIDContext method.
+		ModuleIDContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// Name holds details about calls to the Name method.
+		Name []struct {
+		}
+		// NameContext holds details about calls to the NameContext method.
+		NameContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// NicLinkStatus holds details about calls to the NicLinkStatus method.
+		NicLinkStatus []struct {
+			// Port is the port argument value.
+			Port uint
+		}
+		// NicLinkStatusContext holds details about calls to the NicLinkStatusContext method.
+		NicLinkStatusContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Port is the port argument value.
+			Port uint
+		}
+		// NumaNode holds details about calls to the NumaNode method.
+		NumaNode []struct {
+		}
+		// NumaNodeContext holds details about calls to the NumaNodeContext method.
+		NumaNodeContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// PCBAssemblyVersion holds details about calls to the PCBAssemblyVersion method.
+		PCBAssemblyVersion []struct {
+		}
+		// PCBAssemblyVersionContext holds details about calls to the PCBAssemblyVersionContext method.
+		PCBAssemblyVersionContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// PCBVersion holds details about calls to the PCBVersion method.
+		PCBVersion []struct {
+		}
+		// PCBVersionContext holds details about calls to the PCBVersionContext method.
+		PCBVersionContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// PCIBus holds details about calls to the PCIBus method.
+		PCIBus []struct {
+		}
+		// PCIBusContext holds details about calls to the PCIBusContext method.
+		PCIBusContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// PCIBusID holds details about calls to the PCIBusID method.
+		PCIBusID []struct {
+		}
+		// PCIBusIDContext holds details about calls to the PCIBusIDContext method.
+		PCIBusIDContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// PCIDomain holds details about calls to the PCIDomain method.
+		PCIDomain []struct {
+		}
+		// PCIDomainContext holds details about calls to the PCIDomainContext method.
+		PCIDomainContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// PCIID holds details about calls to the PCIID method.
+		PCIID []struct {
+		}
+		// PCIIDContext holds details about calls to the PCIIDContext method.
+		PCIIDContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// PCILinkSpeed holds details about calls to the PCILinkSpeed method.
+		PCILinkSpeed []struct {
+		}
+		// PCILinkSpeedContext holds details about calls to the PCILinkSpeedContext method.
+		PCILinkSpeedContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// PCILinkWidth holds details about calls to the PCILinkWidth method.
+		PCILinkWidth []struct {
+		}
+		// PCILinkWidthContext holds details about calls to the PCILinkWidthContext method.
+		PCILinkWidthContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// PCIReplayCounter holds details about calls to the PCIReplayCounter method.
+		PCIReplayCounter []struct {
+		}
+		// PCIReplayCounterContext holds details about calls to the PCIReplayCounterContext method.
+		PCIReplayCounterContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// PCIeLinkGeneration holds details about calls to the PCIeLinkGeneration method.
+		PCIeLinkGeneration []struct {
+		}
+		// PCIeLinkGenerationContext holds details about calls to the PCIeLinkGenerationContext method.
+		PCIeLinkGenerationContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// PCIeLinkWidth holds details about calls to the PCIeLinkWidth method.
+		PCIeLinkWidth []struct {
+		}
+		// PCIeLinkWidthContext holds details about calls to the PCIeLinkWidthContext method.
+		PCIeLinkWidthContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// PCIeRX holds details about calls to the PCIeRX method.
+		PCIeRX []struct {
+		}
+		// PCIeRXContext holds details about calls to the PCIeRXContext method.
+		PCIeRXContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// PCIeTX holds details about calls to the PCIeTX method.
+		PCIeTX []struct {
+		}
+		// PCIeTXContext holds details about calls to the PCIeTXContext method.
+		PCIeTXContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// PowerManagementDefaultLimit holds details about calls to the PowerManagementDefaultLimit method.
+		PowerManagementDefaultLimit []struct {
+		}
+		// PowerManagementDefaultLimitContext holds details about calls to the PowerManagementDefaultLimitContext method.
+		PowerManagementDefaultLimitContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// PowerUsage holds details about calls to the PowerUsage method.
+		PowerUsage []struct {
+		}
+		// PowerUsageContext holds details about calls to the PowerUsageContext method.
+		PowerUsageContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// Processes holds details about calls to the Processes method.
+		Processes []struct {
+		}
+		// ProcessesContext holds details about calls to the ProcessesContext method.
+		ProcessesContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// ReplacedRowDoubleBitECC holds details about calls to the ReplacedRowDoubleBitECC method.
+		ReplacedRowDoubleBitECC []struct {
+		}
+		// ReplacedRowDoubleBitECCContext holds details about calls to the ReplacedRowDoubleBitECCContext method.
+		ReplacedRowDoubleBitECCContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// ReplacedRowSingleBitECC holds details about calls to the ReplacedRowSingleBitECC method.
+		ReplacedRowSingleBitECC []struct {
+		}
+		// ReplacedRowSingleBitECCContext holds details about calls to the ReplacedRowSingleBitECCContext method.
+		ReplacedRowSingleBitECCContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// SOCClockInfo holds details about calls to the SOCClockInfo method.
+		SOCClockInfo []struct {
+		}
+		// SOCClockInfoContext holds details about calls to the SOCClockInfoContext method.
+		SOCClockInfoContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// SOCClockMax holds details about calls to the SOCClockMax method.
+		SOCClockMax []struct {
+		}
+		// SOCClockMaxContext holds details about calls to the SOCClockMaxContext method.
+		SOCClockMaxContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// SerialNumber holds details about calls to the SerialNumber method.
+		SerialNumber []struct {
+		}
+		// SerialNumberContext holds details about calls to the SerialNumberContext method.
+		SerialNumberContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// Snapshot holds details about calls to the Snapshot method.
+		Snapshot []struct {
+		}
+		// SnapshotContext holds details about calls to the SnapshotContext method.
+		SnapshotContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// TPCClockMax holds details about calls to the TPCClockMax method.
+		TPCClockMax []struct {
+		}
+		// TPCClockMaxContext holds details about calls to the TPCClockMaxContext method.
+		TPCClockMaxContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// TemperatureOnBoard holds details about calls to the TemperatureOnBoard method.
+		TemperatureOnBoard []struct {
+		}
+		// TemperatureOnBoardContext holds details about calls to the TemperatureOnBoardContext method.
+		TemperatureOnBoardContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// TemperatureOnChip holds details about calls to the TemperatureOnChip method.
+		TemperatureOnChip []struct {
+		}
+		// TemperatureOnChipContext holds details about calls to the TemperatureOnChipContext method.
+		TemperatureOnChipContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// TemperatureThresholdGPU holds details about calls to the TemperatureThresholdGPU method.
+		TemperatureThresholdGPU []struct {
+		}
+		// TemperatureThresholdGPUContext holds details about calls to the TemperatureThresholdGPUContext method.
+		TemperatureThresholdGPUContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// TemperatureThresholdMemory holds details about calls to the TemperatureThresholdMemory method.
+		TemperatureThresholdMemory []struct {
+		}
+		// TemperatureThresholdMemoryContext holds details about calls to the TemperatureThresholdMemoryContext method.
+		TemperatureThresholdMemoryContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// TemperatureThresholdShutdown holds details about calls to the TemperatureThresholdShutdown method.
+		TemperatureThresholdShutdown []struct {
+		}
+		// TemperatureThresholdShutdownContext holds details about calls to the TemperatureThresholdShutdownContext method.
+		TemperatureThresholdShutdownContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// TemperatureThresholdSlowdown holds details about calls to the TemperatureThresholdSlowdown method.
+		TemperatureThresholdSlowdown []struct {
+		}
+		// TemperatureThresholdSlowdownContext holds details about calls to the TemperatureThresholdSlowdownContext method.
+		TemperatureThresholdSlowdownContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// UUID holds details about calls to the UUID method.
+		UUID []struct {
+		}
+		// UUIDContext holds details about calls to the UUIDContext method.
+		UUIDContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// UtilizationInfo holds details about calls to the UtilizationInfo method.
+		UtilizationInfo []struct {
+		}
+		// UtilizationInfoContext holds details about calls to the UtilizationInfoContext method.
+		UtilizationInfoContext []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+	}
+	lockBoardID                             sync.RWMutex
+	lockBoardIDContext                      sync.RWMutex
+	lockClockThrottleReasons                sync.RWMutex
+	lockClockThrottleReasonsContext         sync.RWMutex
+	lockDeviceNodes                         sync.RWMutex
+	lockDeviceNodesContext                  sync.RWMutex
+	lockECCMode                             sync.RWMutex
+	lockECCModeContext                      sync.RWMutex
+	lockEnergyConsumptionCounter            sync.RWMutex
+	lockEnergyConsumptionCounterContext     sync.RWMutex
+	lockHLRevision                          sync.RWMutex
+	lockHLRevisionContext                   sync.RWMutex
+	lockHwmonSensors                        sync.RWMutex
+	lockHwmonSensorsContext                 sync.RWMutex
+	lockICClockMax                          sync.RWMutex
+	lockICClockMaxContext                   sync.RWMutex
+	lockIsReplacedRowsPendingStatus         sync.RWMutex
+	lockIsReplacedRowsPendingStatusContext  sync.RWMutex
+	lockMMEClockMax                         sync.RWMutex
+	lockMMEClockMaxContext                  sync.RWMutex
+	lockMacAddressInfo                      sync.RWMutex
+	lockMacAddressInfoContext               sync.RWMutex
+	lockMemoryInfo                          sync.RWMutex
+	lockMemoryInfoContext                   sync.RWMutex
+	lockMinorNumber                         sync.RWMutex
+	lockMinorNumberContext                  sync.RWMutex
+	lockModuleID                            sync.RWMutex
+	lockModuleIDContext                     sync.RWMutex
+	lockName                                sync.RWMutex
+	lockNameContext                         sync.RWMutex
+	lockNicLinkStatus                       sync.RWMutex
+	lockNicLinkStatusContext                sync.RWMutex
+	lockNumaNode                            sync.RWMutex
+	lockNumaNodeContext                     sync.RWMutex
+	lockPCBAssemblyVersion                  sync.RWMutex
+	lockPCBAssemblyVersionContext           sync.RWMutex
+	lockPCBVersion                          sync.RWMutex
+	lockPCBVersionContext                   sync.RWMutex
+	lockPCIBus                              sync.RWMutex
+	lockPCIBusContext                       sync.RWMutex
+	lockPCIBusID                            sync.RWMutex
+	lockPCIBusIDContext                     sync.RWMutex
+	lockPCIDomain                           sync.RWMutex
+	lockPCIDomainContext                    sync.RWMutex
+	lockPCIID                               sync.RWMutex
+	lockPCIIDContext                        sync.RWMutex
+	lockPCILinkSpeed                        sync.RWMutex
+	lockPCILinkSpeedContext                 sync.RWMutex
+	lockPCILinkWidth                        sync.RWMutex
+	lockPCILinkWidthContext                 sync.RWMutex
+	lockPCIReplayCounter                    sync.RWMutex
+	lockPCIReplayCounterContext             sync.RWMutex
+	lockPCIeLinkGeneration                  sync.RWMutex
+	lockPCIeLinkGenerationContext           sync.RWMutex
+	lockPCIeLinkWidth                       sync.RWMutex
+	lockPCIeLinkWidthContext                sync.RWMutex
+	lockPCIeRX                              sync.RWMutex
+	lockPCIeRXContext                       sync.RWMutex
+	lockPCIeTX                              sync.RWMutex
+	lockPCIeTXContext                       sync.RWMutex
+	lockPowerManagementDefaultLimit         sync.RWMutex
+	lockPowerManagementDefaultLimitContext  sync.RWMutex
+	lockPowerUsage                          sync.RWMutex
+	lockPowerUsageContext                   sync.RWMutex
+	lockProcesses                           sync.RWMutex
+	lockProcessesContext                    sync.RWMutex
+	lockReplacedRowDoubleBitECC             sync.RWMutex
+	lockReplacedRowDoubleBitECCContext      sync.RWMutex
+	lockReplacedRowSingleBitECC             sync.RWMutex
+	lockReplacedRowSingleBitECCContext      sync.RWMutex
+	lockSOCClockInfo                        sync.RWMutex
+	lockSOCClockInfoContext                 sync.RWMutex
+	lockSOCClockMax                         sync.RWMutex
+	lockSOCClockMaxContext                  sync.RWMutex
+	lockSerialNumber                        sync.RWMutex
+	lockSerialNumberContext                 sync.RWMutex
+	lockSnapshot                            sync.RWMutex
+	lockSnapshotContext                     sync.RWMutex
+	lockTPCClockMax                         sync.RWMutex
+	lockTPCClockMaxContext                  sync.RWMutex
+	lockTemperatureOnBoard                  sync.RWMutex
+	lockTemperatureOnBoardContext           sync.RWMutex
+	lockTemperatureOnChip                   sync.RWMutex
+	lockTemperatureOnChipContext            sync.RWMutex
+	lockTemperatureThresholdGPU             sync.RWMutex
+	lockTemperatureThresholdGPUContext      sync.RWMutex
+	lockTemperatureThresholdMemory          sync.RWMutex
+	lockTemperatureThresholdMemoryContext   sync.RWMutex
+	lockTemperatureThresholdShutdown        sync.RWMutex
+	lockTemperatureThresholdShutdownContext sync.RWMutex
+	lockTemperatureThresholdSlowdown        sync.RWMutex
+	lockTemperatureThresholdSlowdownContext sync.RWMutex
+	lockUUID                                sync.RWMutex
+	lockUUIDContext                         sync.RWMutex
+	lockUtilizationInfo                     sync.RWMutex
+	lockUtilizationInfoContext              sync.RWMutex
+}
+
+// BoardID calls BoardIDFunc.
+func (mock *Device) BoardID() (uint, error) {
+	callInfo := struct {
+	}{}
+	mock.lockBoardID.Lock()
+	mock.calls.BoardID = append(mock.calls.BoardID, callInfo)
+	mock.lockBoardID.Unlock()
+	if mock.BoardIDFunc == nil {
+		var (
+			r0 uint
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.BoardIDFunc()
+}
+
+// BoardIDCalls gets all the calls that were made to BoardID.
+func (mock *Device) BoardIDCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockBoardID.RLock()
+	calls = mock.calls.BoardID
+	mock.lockBoardID.RUnlock()
+	return calls
+}
+
+// BoardIDContext calls BoardIDContextFunc.
+func (mock *Device) BoardIDContext(ctx context.Context) (uint, error) {
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockBoardIDContext.Lock()
+	mock.calls.BoardIDContext = append(mock.calls.BoardIDContext, callInfo)
+	mock.lockBoardIDContext.Unlock()
+	if mock.BoardIDContextFunc == nil {
+		var (
+			r0 uint
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.BoardIDContextFunc(ctx)
+}
+
+// BoardIDContextCalls gets all the calls that were made to BoardIDContext.
+func (mock *Device) BoardIDContextCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}
+	mock.lockBoardIDContext.RLock()
+	calls = mock.calls.BoardIDContext
+	mock.lockBoardIDContext.RUnlock()
+	return calls
+}
+
+// ClockThrottleReasons calls ClockThrottleReasonsFunc.
+func (mock *Device) ClockThrottleReasons() (uint64, error) {
+	callInfo := struct {
+	}{}
+	mock.lockClockThrottleReasons.Lock()
+	mock.calls.ClockThrottleReasons = append(mock.calls.ClockThrottleReasons, callInfo)
+	mock.lockClockThrottleReasons.Unlock()
+	if mock.ClockThrottleReasonsFunc == nil {
+		var (
+			r0 uint64
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.ClockThrottleReasonsFunc()
+}
+
+// ClockThrottleReasonsCalls gets all the calls that were made to ClockThrottleReasons.
+func (mock *Device) ClockThrottleReasonsCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockClockThrottleReasons.RLock()
+	calls = mock.calls.ClockThrottleReasons
+	mock.lockClockThrottleReasons.RUnlock()
+	return calls
+}
+
+// ClockThrottleReasonsContext calls ClockThrottleReasonsContextFunc.
+func (mock *Device) ClockThrottleReasonsContext(ctx context.Context) (uint64, error) {
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockClockThrottleReasonsContext.Lock()
+	mock.calls.ClockThrottleReasonsContext = append(mock.calls.ClockThrottleReasonsContext, callInfo)
+	mock.lockClockThrottleReasonsContext.Unlock()
+	if mock.ClockThrottleReasonsContextFunc == nil {
+		var (
+			r0 uint64
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.ClockThrottleReasonsContextFunc(ctx)
+}
+
+// ClockThrottleReasonsContextCalls gets all the calls that were made to ClockThrottleReasonsContext.
+func (mock *Device) ClockThrottleReasonsContextCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}
+	mock.lockClockThrottleReasonsContext.RLock()
+	calls = mock.calls.ClockThrottleReasonsContext
+	mock.lockClockThrottleReasonsContext.RUnlock()
+	return calls
+}
+
+// DeviceNodes calls DeviceNodesFunc.
+func (mock *Device) DeviceNodes() ([]gohlml.DeviceNode, error) {
+	callInfo := struct {
+	}{}
+	mock.lockDeviceNodes.Lock()
+	mock.calls.DeviceNodes = append(mock.calls.DeviceNodes, callInfo)
+	mock.lockDeviceNodes.Unlock()
+	if mock.DeviceNodesFunc == nil {
+		var (
+			r0 []gohlml.DeviceNode
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.DeviceNodesFunc()
+}
+
+// DeviceNodesCalls gets all the calls that were made to DeviceNodes.
+func (mock *Device) DeviceNodesCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockDeviceNodes.RLock()
+	calls = mock.calls.DeviceNodes
+	mock.lockDeviceNodes.RUnlock()
+	return calls
+}
+
+// DeviceNodesContext calls DeviceNodesContextFunc.
+func (mock *Device) DeviceNodesContext(ctx context.Context) ([]gohlml.DeviceNode, error) {
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockDeviceNodesContext.Lock()
+	mock.calls.DeviceNodesContext = append(mock.calls.DeviceNodesContext, callInfo)
+	mock.lockDeviceNodesContext.Unlock()
+	if mock.DeviceNodesContextFunc == nil {
+		var (
+			r0 []gohlml.DeviceNode
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.DeviceNodesContextFunc(ctx)
+}
+
+// DeviceNodesContextCalls gets all the calls that were made to DeviceNodesContext.
+func (mock *Device) DeviceNodesContextCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}
+	mock.lockDeviceNodesContext.RLock()
+	calls = mock.calls.DeviceNodesContext
+	mock.lockDeviceNodesContext.RUnlock()
+	return calls
+}
+
+// ECCMode calls ECCModeFunc.
+func (mock *Device) ECCMode() (uint, uint, error) {
+	callInfo := struct {
+	}{}
+	mock.lockECCMode.Lock()
+	mock.calls.ECCMode = append(mock.calls.ECCMode, callInfo)
+	mock.lockECCMode.Unlock()
+	if mock.ECCModeFunc == nil {
+		var (
+			r0 uint
+			r1 uint
+			r2 error
+		)
+		return r0, r1, r2
+	}
+	return mock.ECCModeFunc()
+}
+
+// ECCModeCalls gets all the calls that were made to ECCMode.
+func (mock *Device) ECCModeCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockECCMode.RLock()
+	calls = mock.calls.ECCMode
+	mock.lockECCMode.RUnlock()
+	return calls
+}
+
+// ECCModeContext calls ECCModeContextFunc.
+func (mock *Device) ECCModeContext(ctx context.Context) (uint, uint, error) {
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockECCModeContext.Lock()
+	mock.calls.ECCModeContext = append(mock.calls.ECCModeContext, callInfo)
+	mock.lockECCModeContext.Unlock()
+	if mock.ECCModeContextFunc == nil {
+		var (
+			r0 uint
+			r1 uint
+			r2 error
+		)
+		return r0, r1, r2
+	}
+	return mock.ECCModeContextFunc(ctx)
+}
+
+// ECCModeContextCalls gets all the calls that were made to ECCModeContext.
+func (mock *Device) ECCModeContextCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}
+	mock.lockECCModeContext.RLock()
+	calls = mock.calls.ECCModeContext
+	mock.lockECCModeContext.RUnlock()
+	return calls
+}
+
+// EnergyConsumptionCounter calls EnergyConsumptionCounterFunc.
+func (mock *Device) EnergyConsumptionCounter() (uint64, error) {
+	callInfo := struct {
+	}{}
+	mock.lockEnergyConsumptionCounter.Lock()
+	mock.calls.EnergyConsumptionCounter = append(mock.calls.EnergyConsumptionCounter, callInfo)
+	mock.lockEnergyConsumptionCounter.Unlock()
+	if mock.EnergyConsumptionCounterFunc == nil {
+		var (
+			r0 uint64
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.EnergyConsumptionCounterFunc()
+}
+
+// EnergyConsumptionCounterCalls gets all the calls that were made to EnergyConsumptionCounter.
+func (mock *Device) EnergyConsumptionCounterCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockEnergyConsumptionCounter.RLock()
+	calls = mock.calls.EnergyConsumptionCounter
+	mock.lockEnergyConsumptionCounter.RUnlock()
+	return calls
+}
+
+// EnergyConsumptionCounterContext calls EnergyConsumptionCounterContextFunc.
+func (mock *Device) EnergyConsumptionCounterContext(ctx context.Context) (uint64, error) {
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockEnergyConsumptionCounterContext.Lock()
+	mock.calls.EnergyConsumptionCounterContext = append(mock.calls.EnergyConsumptionCounterContext, callInfo)
+	mock.lockEnergyConsumptionCounterContext.Unlock()
+	if mock.EnergyConsumptionCounterContextFunc == nil {
+		var (
+			r0 uint64
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.EnergyConsumptionCounterContextFunc(ctx)
+}
+
+// EnergyConsumptionCounterContextCalls gets all the calls that were made to EnergyConsumptionCounterContext.
+func (mock *Device) EnergyConsumptionCounterContextCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}
+	mock.lockEnergyConsumptionCounterContext.RLock()
+	calls = mock.calls.EnergyConsumptionCounterContext
+	mock.lockEnergyConsumptionCounterContext.RUnlock()
+	return calls
+}
+
+// HLRevision calls HLRevisionFunc.
+func (mock *Device) HLRevision() (int, error) {
+	callInfo := struct {
+	}{}
+	mock.lockHLRevision.Lock()
+	mock.calls.HLRevision = append(mock.calls.HLRevision, callInfo)
+	mock.lockHLRevision.Unlock()
+	if mock.HLRevisionFunc == nil {
+		var (
+			r0 int
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.HLRevisionFunc()
+}
+
+// HLRevisionCalls gets all the calls that were made to HLRevision.
+func (mock *Device) HLRevisionCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockHLRevision.RLock()
+	calls = mock.calls.HLRevision
+	mock.lockHLRevision.RUnlock()
+	return calls
+}
+
+// HLRevisionContext calls HLRevisionContextFunc.
+func (mock *Device) HLRevisionContext(ctx context.Context) (int, error) {
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockHLRevisionContext.Lock()
+	mock.calls.HLRevisionContext = append(mock.calls.HLRevisionContext, callInfo)
+	mock.lockHLRevisionContext.Unlock()
+	if mock.HLRevisionContextFunc == nil {
+		var (
+			r0 int
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.HLRevisionContextFunc(ctx)
+}
+
+// HLRevisionContextCalls gets all the calls that were made to HLRevisionContext.
+func (mock *Device) HLRevisionContextCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}
+	mock.lockHLRevisionContext.RLock()
+	calls = mock.calls.HLRevisionContext
+	mock.lockHLRevisionContext.RUnlock()
+	return calls
+}
+
+// HwmonSensors calls HwmonSensorsFunc.
+func (mock *Device) HwmonSensors() ([]gohlml.HwmonSensor, error) {
+	callInfo := struct {
+	}{}
+	mock.lockHwmonSensors.Lock()
+	mock.calls.HwmonSensors = append(mock.calls.HwmonSensors, callInfo)
+	mock.lockHwmonSensors.Unlock()
+	if mock.HwmonSensorsFunc == nil {
+		var (
+			r0 []gohlml.HwmonSensor
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.HwmonSensorsFunc()
+}
+
+// HwmonSensorsCalls gets all the calls that were made to HwmonSensors.
+func (mock *Device) HwmonSensorsCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockHwmonSensors.RLock()
+	calls = mock.calls.HwmonSensors
+	mock.lockHwmonSensors.RUnlock()
+	return calls
+}
+
+// HwmonSensorsContext calls HwmonSensorsContextFunc.
+func (mock *Device) HwmonSensorsContext(ctx context.Context) ([]gohlml.HwmonSensor, error) {
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockHwmonSensorsContext.Lock()
+	mock.calls.HwmonSensorsContext = append(mock.calls.HwmonSensorsContext, callInfo)
+	mock.lockHwmonSensorsContext.Unlock()
+	if mock.HwmonSensorsContextFunc == nil {
+		var (
+			r0 []gohlml.HwmonSensor
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.HwmonSensorsContextFunc(ctx)
+}
+
+// HwmonSensorsContextCalls gets all the calls that were made to HwmonSensorsContext.
+func (mock *Device) HwmonSensorsContextCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}
+	mock.lockHwmonSensorsContext.RLock()
+	calls = mock.calls.HwmonSensorsContext
+	mock.lockHwmonSensorsContext.RUnlock()
+	return calls
+}
+
+// ICClockMax calls ICClockMaxFunc.
+func (mock *Device) ICClockMax() (uint, error) {
+	callInfo := struct {
+	}{}
+	mock.lockICClockMax.Lock()
+	mock.calls.ICClockMax = append(mock.calls.ICClockMax, callInfo)
+	mock.lockICClockMax.Unlock()
+	if mock.ICClockMaxFunc == nil {
+		var (
+			r0 uint
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.ICClockMaxFunc()
+}
+
+// ICClockMaxCalls gets all the calls that were made to ICClockMax.
+func (mock *Device) ICClockMaxCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockICClockMax.RLock()
+	calls = mock.calls.ICClockMax
+	mock.lockICClockMax.RUnlock()
+	return calls
+}
+
+// ICClockMaxContext calls ICClockMaxContextFunc.
+func (mock *Device) ICClockMaxContext(ctx context.Context) (uint, error) {
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockICClockMaxContext.Lock()
+	mock.calls.ICClockMaxContext = append(mock.calls.ICClockMaxContext, callInfo)
+	mock.lockICClockMaxContext.Unlock()
+	if mock.ICClockMaxContextFunc == nil {
+		var (
+			r0 uint
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.ICClockMaxContextFunc(ctx)
+}
+
+// ICClockMaxContextCalls gets all the calls that were made to ICClockMaxContext.
+func (mock *Device) ICClockMaxContextCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}
+	mock.lockICClockMaxContext.RLock()
+	calls = mock.calls.ICClockMaxContext
+	mock.lockICClockMaxContext.RUnlock()
+	return calls
+}
+
+// IsReplacedRowsPendingStatus calls IsReplacedRowsPendingStatusFunc.
+func (mock *Device) IsReplacedRowsPendingStatus() (int, error) {
+	callInfo := struct {
+	}{}
+	mock.lockIsReplacedRowsPendingStatus.Lock()
+	mock.calls.IsReplacedRowsPendingStatus = append(mock.calls.IsReplacedRowsPendingStatus, callInfo)
+	mock.lockIsReplacedRowsPendingStatus.Unlock()
+	if mock.IsReplacedRowsPendingStatusFunc == nil {
+		var (
+			r0 int
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.IsReplacedRowsPendingStatusFunc()
+}
+
+// IsReplacedRowsPendingStatusCalls gets all the calls that were made to IsReplacedRowsPendingStatus.
+func (mock *Device) IsReplacedRowsPendingStatusCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockIsReplacedRowsPendingStatus.RLock()
+	calls = mock.calls.IsReplacedRowsPendingStatus
+	mock.lockIsReplacedRowsPendingStatus.RUnlock()
+	return calls
+}
+
+// IsReplacedRowsPendingStatusContext calls IsReplacedRowsPendingStatusContextFunc.
+func (mock *Device) IsReplacedRowsPendingStatusContext(ctx context.Context) (int, error) {
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockIsReplacedRowsPendingStatusContext.Lock()
+	mock.calls.IsReplacedRowsPendingStatusContext = append(mock.calls.IsReplacedRowsPendingStatusContext, callInfo)
+	mock.lockIsReplacedRowsPendingStatusContext.Unlock()
+	if mock.IsReplacedRowsPendingStatusContextFunc == nil {
+		var (
+			r0 int
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.IsReplacedRowsPendingStatusContextFunc(ctx)
+}
+
+// IsReplacedRowsPendingStatusContextCalls gets all the calls that were made to IsReplacedRowsPendingStatusContext.
+func (mock *Device) IsReplacedRowsPendingStatusContextCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}
+	mock.lockIsReplacedRowsPendingStatusContext.RLock()
+	calls = mock.calls.IsReplacedRowsPendingStatusContext
+	mock.lockIsReplacedRowsPendingStatusContext.RUnlock()
+	return calls
+}
+
+// MMEClockMax calls MMEClockMaxFunc.
+func (mock *Device) MMEClockMax() (uint, error) {
+	callInfo := struct {
+	}{}
+	mock.lockMMEClockMax.Lock()
+	mock.calls.MMEClockMax = append(mock.calls.MMEClockMax, callInfo)
+	mock.lockMMEClockMax.Unlock()
+	if mock.MMEClockMaxFunc == nil {
+		var (
+			r0 uint
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.MMEClockMaxFunc()
+}
+
+// MMEClockMaxCalls gets all the calls that were made to MMEClockMax.
+func (mock *Device) MMEClockMaxCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockMMEClockMax.RLock()
+	calls = mock.calls.MMEClockMax
+	mock.lockMMEClockMax.RUnlock()
+	return calls
+}
+
+// MMEClockMaxContext calls MMEClockMaxContextFunc.
+func (mock *Device) MMEClockMaxContext(ctx context.Context) (uint, error) {
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockMMEClockMaxContext.Lock()
+	mock.calls.MMEClockMaxContext = append(mock.calls.MMEClockMaxContext, callInfo)
+	mock.lockMMEClockMaxContext.Unlock()
+	if mock.MMEClockMaxContextFunc == nil {
+		var (
+			r0 uint
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.MMEClockMaxContextFunc(ctx)
+}
+
+// MMEClockMaxContextCalls gets all the calls that were made to MMEClockMaxContext.
+func (mock *Device) MMEClockMaxContextCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}
+	mock.lockMMEClockMaxContext.RLock()
+	calls = mock.calls.MMEClockMaxContext
+	mock.lockMMEClockMaxContext.RUnlock()
+	return calls
+}
+
+// MacAddressInfo calls MacAddressInfoFunc.
+func (mock *Device) MacAddressInfo() (map[int]string, error) {
+	callInfo := struct {
+	}{}
+	mock.lockMacAddressInfo.Lock()
+	mock.calls.MacAddressInfo = append(mock.calls.MacAddressInfo, callInfo)
+	mock.lockMacAddressInfo.Unlock()
+	if mock.MacAddressInfoFunc == nil {
+		var (
+			r0 map[int]string
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.MacAddressInfoFunc()
+}
+
+// MacAddressInfoCalls gets all the calls that were made to MacAddressInfo.
+func (mock *Device) MacAddressInfoCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockMacAddressInfo.RLock()
+	calls = mock.calls.MacAddressInfo
+	mock.lockMacAddressInfo.RUnlock()
+	return calls
+}
+
+// MacAddressInfoContext calls MacAddressInfoContextFunc.
+func (mock *Device) MacAddressInfoContext(ctx context.Context) (map[int]string, error) {
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockMacAddressInfoContext.Lock()
+	mock.calls.MacAddressInfoContext = append(mock.calls.MacAddressInfoContext, callInfo)
+	mock.lockMacAddressInfoContext.Unlock()
+	if mock.MacAddressInfoContextFunc == nil {
+		var (
+			r0 map[int]string
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.MacAddressInfoContextFunc(ctx)
+}
+
+// MacAddressInfoContextCalls gets all the calls that were made to MacAddressInfoContext.
+func (mock *Device) MacAddressInfoContextCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}
+	mock.lockMacAddressInfoContext.RLock()
+	calls = mock.calls.MacAddressInfoContext
+	mock.lockMacAddressInfoContext.RUnlock()
+	return calls
+}
+
+// MemoryInfo calls MemoryInfoFunc.
+func (mock *Device) MemoryInfo() (uint64, uint64, uint64, error) {
+	callInfo := struct {
+	}{}
+	mock.lockMemoryInfo.Lock()
+	mock.calls.MemoryInfo = append(mock.calls.MemoryInfo, callInfo)
+	mock.lockMemoryInfo.Unlock()
+	if mock.MemoryInfoFunc == nil {
+		var (
+			r0 uint64
+			r1 uint64
+			r2 uint64
+			r3 error
+		)
+		return r0, r1, r2, r3
+	}
+	return mock.MemoryInfoFunc()
+}
+
+// MemoryInfoCalls gets all the calls that were made to MemoryInfo.
+func (mock *Device) MemoryInfoCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockMemoryInfo.RLock()
+	calls = mock.calls.MemoryInfo
+	mock.lockMemoryInfo.RUnlock()
+	return calls
+}
+
+// MemoryInfoContext calls MemoryInfoContextFunc.
+func (mock *Device) MemoryInfoContext(ctx context.Context) (uint64, uint64, uint64, error) {
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockMemoryInfoContext.Lock()
+	mock.calls.MemoryInfoContext = append(mock.calls.MemoryInfoContext, callInfo)
+	mock.lockMemoryInfoContext.Unlock()
+	if mock.MemoryInfoContextFunc == nil {
+		var (
+			r0 uint64
+			r1 uint64
+			r2 uint64
+			r3 error
+		)
+		return r0, r1, r2, r3
+	}
+	return mock.MemoryInfoContextFunc(ctx)
+}
+
+// MemoryInfoContextCalls gets all the calls that were made to MemoryInfoContext.
+func (mock *Device) MemoryInfoContextCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}
+	mock.lockMemoryInfoContext.RLock()
+	calls = mock.calls.MemoryInfoContext
+	mock.lockMemoryInfoContext.RUnlock()
+	return calls
+}
+
+// MinorNumber calls MinorNumberFunc.
+func (mock *Device) MinorNumber() (uint, error) {
+	callInfo := struct {
+	}{}
+	mock.lockMinorNumber.Lock()
+	mock.calls.MinorNumber = append(mock.calls.MinorNumber, callInfo)
+	mock.lockMinorNumber.Unlock()
+	if mock.MinorNumberFunc == nil {
+		var (
+			r0 uint
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.MinorNumberFunc()
+}
+
+// MinorNumberCalls gets all the calls that were made to MinorNumber.
+func (mock *Device) MinorNumberCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockMinorNumber.RLock()
+	calls = mock.calls.MinorNumber
+	mock.lockMinorNumber.RUnlock()
+	return calls
+}
+
+// MinorNumberContext calls MinorNumberContextFunc.
+func (mock *Device) MinorNumberContext(ctx context.Context) (uint, error) {
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockMinorNumberContext.Lock()
+	mock.calls.MinorNumberContext = append(mock.calls.MinorNumberContext, callInfo)
+	mock.lockMinorNumberContext.Unlock()
+	if mock.MinorNumberContextFunc == nil {
+		var (
+			r0 uint
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.MinorNumberContextFunc(ctx)
+}
+
+// MinorNumberContextCalls gets all the calls that were made to MinorNumberContext.
+func (mock *Device) MinorNumberContextCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}
+	mock.lockMinorNumberContext.RLock()
+	calls = mock.calls.MinorNumberContext
+	mock.lockMinorNumberContext.RUnlock()
+	return calls
+}
+
+// ModuleID calls ModuleIDFunc.
+func (mock *Device) ModuleID() (uint, error) {
+	callInfo := struct {
+	}{}
+	mock.lockModuleID.Lock()
+	mock.calls.ModuleID = append(mock.calls.ModuleID, callInfo)
+	mock.lockModuleID.Unlock()
+	if mock.ModuleIDFunc == nil {
+		var (
+			r0 uint
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.ModuleIDFunc()
+}
+
+// ModuleIDCalls gets all the calls that were made to ModuleID.
+func (mock *Device) ModuleIDCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockModuleID.RLock()
+	calls = mock.calls.ModuleID
+	mock.lockModuleID.RUnlock()
+	return calls
+}
+
+// ModuleIDContext calls ModuleIDContextFunc.
+func (mock *Device) ModuleIDContext(ctx context.Context) (uint, error) {
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockModuleIDContext.Lock()
+	mock.calls.ModuleIDContext = append(mock.calls.ModuleIDContext, callInfo)
+	mock.lockModuleIDContext.Unlock()
+	if mock.ModuleIDContextFunc == nil {
+		var (
+			r0 uint
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.ModuleIDContextFunc(ctx)
+}
+
+// ModuleIDContextCalls gets all the calls that were made to ModuleIDContext.
+func (mock *Device) ModuleIDContextCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}
+	mock.lockModuleIDContext.RLock()
+	calls = mock.calls.ModuleIDContext
+	mock.lockModuleIDContext.RUnlock()
+	return calls
+}
+
+// Name calls NameFunc.
+func (mock *Device) Name() (string, error) {
+	callInfo := struct {
+	}{}
+	mock.lockName.Lock()
+	mock.calls.Name = append(mock.calls.Name, callInfo)
+	mock.lockName.Unlock()
+	if mock.NameFunc == nil {
+		var (
+			r0 string
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.NameFunc()
+}
+
+// NameCalls gets all the calls that were made to Name.
+func (mock *Device) NameCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockName.RLock()
+	calls = mock.calls.Name
+	mock.lockName.RUnlock()
+	return calls
+}
+
+// NameContext calls NameContextFunc.
+func (mock *Device) NameContext(ctx context.Context) (string, error) {
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockNameContext.Lock()
+	mock.calls.NameContext = append(mock.calls.NameContext, callInfo)
+	mock.lockNameContext.Unlock()
+	if mock.NameContextFunc == nil {
+		var (
+			r0 string
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.NameContextFunc(ctx)
+}
+
+// NameContextCalls gets all the calls that were made to NameContext.
+func (mock *Device) NameContextCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}
+	mock.lockNameContext.RLock()
+	calls = mock.calls.NameContext
+	mock.lockNameContext.RUnlock()
+	return calls
+}
+
+// NicLinkStatus calls NicLinkStatusFunc.
+func (mock *Device) NicLinkStatus(port uint) (uint, error) {
+	callInfo := struct {
+		// Port is the port argument value.
+		Port uint
+	}{
+		Port: port,
+	}
+	mock.lockNicLinkStatus.Lock()
+	mock.calls.NicLinkStatus = append(mock.calls.NicLinkStatus, callInfo)
+	mock.lockNicLinkStatus.Unlock()
+	if mock.NicLinkStatusFunc == nil {
+		var (
+			r0 uint
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.NicLinkStatusFunc(port)
+}
+
+// NicLinkStatusCalls gets all the calls that were made to NicLinkStatus.
+func (mock *Device) NicLinkStatusCalls() []struct {
+	// Port is the port argument value.
+	Port uint
+} {
+	var calls []struct {
+		// Port is the port argument value.
+		Port uint
+	}
+	mock.lockNicLinkStatus.RLock()
+	calls = mock.calls.NicLinkStatus
+	mock.lockNicLinkStatus.RUnlock()
+	return calls
+}
+
+// NicLinkStatusContext calls NicLinkStatusContextFunc.
+func (mock *Device) NicLinkStatusContext(ctx context.Context, port uint) (uint, error) {
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Port is the port argument value.
+		Port uint
+	}{
+		Ctx:  ctx,
+		Port: port,
+	}
+	mock.lockNicLinkStatusContext.Lock()
+	mock.calls.NicLinkStatusContext = append(mock.calls.NicLinkStatusContext, callInfo)
+	mock.lockNicLinkStatusContext.Unlock()
+	if mock.NicLinkStatusContextFunc == nil {
+		var (
+			r0 uint
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.NicLinkStatusContextFunc(ctx, port)
+}
+
+// NicLinkStatusContextCalls gets all the calls that were made to NicLinkStatusContext.
+func (mock *Device) NicLinkStatusContextCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+	// Port is the port argument value.
+	Port uint
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+		// Port is the port argument value.
+		Port uint
+	}
+	mock.lockNicLinkStatusContext.RLock()
+	calls = mock.calls.NicLinkStatusContext
+	mock.lockNicLinkStatusContext.RUnlock()
+	return calls
+}
+
+// NumaNode calls NumaNodeFunc.
+func (mock *Device) NumaNode() (*uint, error) {
+	callInfo := struct {
+	}{}
+	mock.lockNumaNode.Lock()
+	mock.calls.NumaNode = append(mock.calls.NumaNode, callInfo)
+	mock.lockNumaNode.Unlock()
+	if mock.NumaNodeFunc == nil {
+		var (
+			r0 *uint
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.NumaNodeFunc()
+}
+
+// NumaNodeCalls gets all the calls that were made to NumaNode.
+func (mock *Device) NumaNodeCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockNumaNode.RLock()
+	calls = mock.calls.NumaNode
+	mock.lockNumaNode.RUnlock()
+	return calls
+}
+
+// NumaNodeContext calls NumaNodeContextFunc.
+func (mock *Device) NumaNodeContext(ctx context.Context) (*uint, error) {
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockNumaNodeContext.Lock()
+	mock.calls.NumaNodeContext = append(mock.calls.NumaNodeContext, callInfo)
+	mock.lockNumaNodeContext.Unlock()
+	if mock.NumaNodeContextFunc == nil {
+		var (
+			r0 *uint
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.NumaNodeContextFunc(ctx)
+}
+
+// NumaNodeContextCalls gets all the calls that were made to NumaNodeContext.
+func (mock *Device) NumaNodeContextCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}
+	mock.lockNumaNodeContext.RLock()
+	calls = mock.calls.NumaNodeContext
+	mock.lockNumaNodeContext.RUnlock()
+	return calls
+}
+
+// PCBAssemblyVersion calls PCBAssemblyVersionFunc.
+func (mock *Device) PCBAssemblyVersion() (string, error) {
+	callInfo := struct {
+	}{}
+	mock.lockPCBAssemblyVersion.Lock()
+	mock.calls.PCBAssemblyVersion = append(mock.calls.PCBAssemblyVersion, callInfo)
+	mock.lockPCBAssemblyVersion.Unlock()
+	if mock.PCBAssemblyVersionFunc == nil {
+		var (
+			r0 string
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.PCBAssemblyVersionFunc()
+}
+
+// PCBAssemblyVersionCalls gets all the calls that were made to PCBAssemblyVersion.
+func (mock *Device) PCBAssemblyVersionCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockPCBAssemblyVersion.RLock()
+	calls = mock.calls.PCBAssemblyVersion
+	mock.lockPCBAssemblyVersion.RUnlock()
+	return calls
+}
+
+// PCBAssemblyVersionContext calls PCBAssemblyVersionContextFunc.
+func (mock *Device) PCBAssemblyVersionContext(ctx context.Context) (string, error) {
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockPCBAssemblyVersionContext.Lock()
+	mock.calls.PCBAssemblyVersionContext = append(mock.calls.PCBAssemblyVersionContext, callInfo)
+	mock.lockPCBAssemblyVersionContext.Unlock()
+	if mock.PCBAssemblyVersionContextFunc == nil {
+		var (
+			r0 string
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.PCBAssemblyVersionContextFunc(ctx)
+}
+
+// PCBAssemblyVersionContextCalls gets all the calls that were made to PCBAssemblyVersionContext.
+func (mock *Device) PCBAssemblyVersionContextCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}
+	mock.lockPCBAssemblyVersionContext.RLock()
+	calls = mock.calls.PCBAssemblyVersionContext
+	mock.lockPCBAssemblyVersionContext.RUnlock()
+	return calls
+}
+
+// PCBVersion calls PCBVersionFunc.
+func (mock *Device) PCBVersion() (string, error) {
+	callInfo := struct {
+	}{}
+	mock.lockPCBVersion.Lock()
+	mock.calls.PCBVersion = append(mock.calls.PCBVersion, callInfo)
+	mock.lockPCBVersion.Unlock()
+	if mock.PCBVersionFunc == nil {
+		var (
+			r0 string
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.PCBVersionFunc()
+}
+
+// PCBVersionCalls gets all the calls that were made to PCBVersion.
+func (mock *Device) PCBVersionCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockPCBVersion.RLock()
+	calls = mock.calls.PCBVersion
+	mock.lockPCBVersion.RUnlock()
+	return calls
+}
+
+// PCBVersionContext calls PCBVersionContextFunc.
+func (mock *Device) PCBVersionContext(ctx context.Context) (string, error) {
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockPCBVersionContext.Lock()
+	mock.calls.PCBVersionContext = append(mock.calls.PCBVersionContext, callInfo)
+	mock.lockPCBVersionContext.Unlock()
+	if mock.PCBVersionContextFunc == nil {
+		var (
+			r0 string
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.PCBVersionContextFunc(ctx)
+}
+
+// PCBVersionContextCalls gets all the calls that were made to PCBVersionContext.
+func (mock *Device) PCBVersionContextCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}
+	mock.lockPCBVersionContext.RLock()
+	calls = mock.calls.PCBVersionContext
+	mock.lockPCBVersionContext.RUnlock()
+	return calls
+}
+
+// PCIBus calls PCIBusFunc.
+func (mock *Device) PCIBus() (uint, error) {
+	callInfo := struct {
+	}{}
+	mock.lockPCIBus.Lock()
+	mock.calls.PCIBus = append(mock.calls.PCIBus, callInfo)
+	mock.lockPCIBus.Unlock()
+	if mock.PCIBusFunc == nil {
+		var (
+			r0 uint
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.PCIBusFunc()
+}
+
+// PCIBusCalls gets all the calls that were made to PCIBus.
+func (mock *Device) PCIBusCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockPCIBus.RLock()
+	calls = mock.calls.PCIBus
+	mock.lockPCIBus.RUnlock()
+	return calls
+}
+
+// PCIBusContext calls PCIBusContextFunc.
+func (mock *Device) PCIBusContext(ctx context.Context) (uint, error) {
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockPCIBusContext.Lock()
+	mock.calls.PCIBusContext = append(mock.calls.PCIBusContext, callInfo)
+	mock.lockPCIBusContext.Unlock()
+	if mock.PCIBusContextFunc == nil {
+		var (
+			r0 uint
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.PCIBusContextFunc(ctx)
+}
+
+// PCIBusContextCalls gets all the calls that were made to PCIBusContext.
+func (mock *Device) PCIBusContextCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}
+	mock.lockPCIBusContext.RLock()
+	calls = mock.calls.PCIBusContext
+	mock.lockPCIBusContext.RUnlock()
+	return calls
+}
+
+// PCIBusID calls PCIBusIDFunc.
+func (mock *Device) PCIBusID() (string, error) {
+	callInfo := struct {
+	}{}
+	mock.lockPCIBusID.Lock()
+	mock.calls.PCIBusID = append(mock.calls.PCIBusID, callInfo)
+	mock.lockPCIBusID.Unlock()
+	if mock.PCIBusIDFunc == nil {
+		var (
+			r0 string
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.PCIBusIDFunc()
+}
+
+// PCIBusIDCalls gets all the calls that were made to PCIBusID.
+func (mock *Device) PCIBusIDCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockPCIBusID.RLock()
+	calls = mock.calls.PCIBusID
+	mock.lockPCIBusID.RUnlock()
+	return calls
+}
+
+// PCIBusIDContext calls PCIBusIDContextFunc.
+func (mock *Device) PCIBusIDContext(ctx context.Context) (string, error) {
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockPCIBusIDContext.Lock()
+	mock.calls.PCIBusIDContext = append(mock.calls.PCIBusIDContext, callInfo)
+	mock.lockPCIBusIDContext.Unlock()
+	if mock.PCIBusIDContextFunc == nil {
+		var (
+			r0 string
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.PCIBusIDContextFunc(ctx)
+}
+
+// PCIBusIDContextCalls gets all the calls that were made to PCIBusIDContext.
+func (mock *Device) PCIBusIDContextCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}
+	mock.lockPCIBusIDContext.RLock()
+	calls = mock.calls.PCIBusIDContext
+	mock.lockPCIBusIDContext.RUnlock()
+	return calls
+}
+
+// PCIDomain calls PCIDomainFunc.
+func (mock *Device) PCIDomain() (uint, error) {
+	callInfo := struct {
+	}{}
+	mock.lockPCIDomain.Lock()
+	mock.calls.PCIDomain = append(mock.calls.PCIDomain, callInfo)
+	mock.lockPCIDomain.Unlock()
+	if mock.PCIDomainFunc == nil {
+		var (
+			r0 uint
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.PCIDomainFunc()
+}
+
+// PCIDomainCalls gets all the calls that were made to PCIDomain.
+func (mock *Device) PCIDomainCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockPCIDomain.RLock()
+	calls = mock.calls.PCIDomain
+	mock.lockPCIDomain.RUnlock()
+	return calls
+}
+
+// PCIDomainContext calls PCIDomainContextFunc.
+func (mock *Device) PCIDomainContext(ctx context.Context) (uint, error) {
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockPCIDomainContext.Lock()
+	mock.calls.PCIDomainContext = append(mock.calls.PCIDomainContext, callInfo)
+	mock.lockPCIDomainContext.Unlock()
+	if mock.PCIDomainContextFunc == nil {
+		var (
+			r0 uint
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.PCIDomainContextFunc(ctx)
+}
+
+// PCIDomainContextCalls gets all the calls that were made to PCIDomainContext.
+func (mock *Device) PCIDomainContextCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}
+	mock.lockPCIDomainContext.RLock()
+	calls = mock.calls.PCIDomainContext
+	mock.lockPCIDomainContext.RUnlock()
+	return calls
+}
+
+// PCIID calls PCIIDFunc.
+func (mock *Device) PCIID() (uint, error) {
+	callInfo := struct {
+	}{}
+	mock.lockPCIID.Lock()
+	mock.calls.PCIID = append(mock.calls.PCIID, callInfo)
+	mock.lockPCIID.Unlock()
+	if mock.PCIIDFunc == nil {
+		var (
+			r0 uint
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.PCIIDFunc()
+}
+
+// PCIIDCalls gets all the calls that were made to PCIID.
+func (mock *Device) PCIIDCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockPCIID.RLock()
+	calls = mock.calls.PCIID
+	mock.lockPCIID.RUnlock()
+	return calls
+}
+
+// PCIIDContext calls PCIIDContextFunc.
+func (mock *Device) PCIIDContext(ctx context.Context) (uint, error) {
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockPCIIDContext.Lock()
+	mock.calls.PCIIDContext = append(mock.calls.PCIIDContext, callInfo)
+	mock.lockPCIIDContext.Unlock()
+	if mock.PCIIDContextFunc == nil {
+		var (
+			r0 uint
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.PCIIDContextFunc(ctx)
+}
+
+// PCIIDContextCalls gets all the calls that were made to PCIIDContext.
+func (mock *Device) PCIIDContextCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}
+	mock.lockPCIIDContext.RLock()
+	calls = mock.calls.PCIIDContext
+	mock.lockPCIIDContext.RUnlock()
+	return calls
+}
+
+// PCILinkSpeed calls PCILinkSpeedFunc.
+func (mock *Device) PCILinkSpeed() (uint, error) {
+	callInfo := struct {
+	}{}
+	mock.lockPCILinkSpeed.Lock()
+	mock.calls.PCILinkSpeed = append(mock.calls.PCILinkSpeed, callInfo)
+	mock.lockPCILinkSpeed.Unlock()
+	if mock.PCILinkSpeedFunc == nil {
+		var (
+			r0 uint
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.PCILinkSpeedFunc()
+}
+
+// PCILinkSpeedCalls gets all the calls that were made to PCILinkSpeed.
+func (mock *Device) PCILinkSpeedCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockPCILinkSpeed.RLock()
+	calls = mock.calls.PCILinkSpeed
+	mock.lockPCILinkSpeed.RUnlock()
+	return calls
+}
+
+// PCILinkSpeedContext calls PCILinkSpeedContextFunc.
+func (mock *Device) PCILinkSpeedContext(ctx context.Context) (uint, error) {
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockPCILinkSpeedContext.Lock()
+	mock.calls.PCILinkSpeedContext = append(mock.calls.PCILinkSpeedContext, callInfo)
+	mock.lockPCILinkSpeedContext.Unlock()
+	if mock.PCILinkSpeedContextFunc == nil {
+		var (
+			r0 uint
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.PCILinkSpeedContextFunc(ctx)
+}
+
+// PCILinkSpeedContextCalls gets all the calls that were made to PCILinkSpeedContext.
+func (mock *Device) PCILinkSpeedContextCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}
+	mock.lockPCILinkSpeedContext.RLock()
+	calls = mock.calls.PCILinkSpeedContext
+	mock.lockPCILinkSpeedContext.RUnlock()
+	return calls
+}
+
+// PCILinkWidth calls PCILinkWidthFunc.
+func (mock *Device) PCILinkWidth() (uint, error) {
+	callInfo := struct {
+	}{}
+	mock.lockPCILinkWidth.Lock()
+	mock.calls.PCILinkWidth = append(mock.calls.PCILinkWidth, callInfo)
+	mock.lockPCILinkWidth.Unlock()
+	if mock.PCILinkWidthFunc == nil {
+		var (
+			r0 uint
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.PCILinkWidthFunc()
+}
+
+// PCILinkWidthCalls gets all the calls that were made to PCILinkWidth.
+func (mock *Device) PCILinkWidthCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockPCILinkWidth.RLock()
+	calls = mock.calls.PCILinkWidth
+	mock.lockPCILinkWidth.RUnlock()
+	return calls
+}
+
+// PCILinkWidthContext calls PCILinkWidthContextFunc.
+func (mock *Device) PCILinkWidthContext(ctx context.Context) (uint, error) {
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockPCILinkWidthContext.Lock()
+	mock.calls.PCILinkWidthContext = append(mock.calls.PCILinkWidthContext, callInfo)
+	mock.lockPCILinkWidthContext.Unlock()
+	if mock.PCILinkWidthContextFunc == nil {
+		var (
+			r0 uint
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.PCILinkWidthContextFunc(ctx)
+}
+
+// PCILinkWidthContextCalls gets all the calls that were made to PCILinkWidthContext.
+func (mock *Device) PCILinkWidthContextCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}
+	mock.lockPCILinkWidthContext.RLock()
+	calls = mock.calls.PCILinkWidthContext
+	mock.lockPCILinkWidthContext.RUnlock()
+	return calls
+}
+
+// PCIReplayCounter calls PCIReplayCounterFunc.
+func (mock *Device) PCIReplayCounter() (uint, error) {
+	callInfo := struct {
+	}{}
+	mock.lockPCIReplayCounter.Lock()
+	mock.calls.PCIReplayCounter = append(mock.calls.PCIReplayCounter, callInfo)
+	mock.lockPCIReplayCounter.Unlock()
+	if mock.PCIReplayCounterFunc == nil {
+		var (
+			r0 uint
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.PCIReplayCounterFunc()
+}
+
+// PCIReplayCounterCalls gets all the calls that were made to PCIReplayCounter.
+func (mock *Device) PCIReplayCounterCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockPCIReplayCounter.RLock()
+	calls = mock.calls.PCIReplayCounter
+	mock.lockPCIReplayCounter.RUnlock()
+	return calls
+}
+
+// PCIReplayCounterContext calls PCIReplayCounterContextFunc.
+func (mock *Device) PCIReplayCounterContext(ctx context.Context) (uint, error) {
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockPCIReplayCounterContext.Lock()
+	mock.calls.PCIReplayCounterContext = append(mock.calls.PCIReplayCounterContext, callInfo)
+	mock.lockPCIReplayCounterContext.Unlock()
+	if mock.PCIReplayCounterContextFunc == nil {
+		var (
+			r0 uint
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.PCIReplayCounterContextFunc(ctx)
+}
+
+// PCIReplayCounterContextCalls gets all the calls that were made to PCIReplayCounterContext.
+func (mock *Device) PCIReplayCounterContextCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}
+	mock.lockPCIReplayCounterContext.RLock()
+	calls = mock.calls.PCIReplayCounterContext
+	mock.lockPCIReplayCounterContext.RUnlock()
+	return calls
+}
+
+// PCIeLinkGeneration calls PCIeLinkGenerationFunc.
+func (mock *Device) PCIeLinkGeneration() (uint, error) {
+	callInfo := struct {
+	}{}
+	mock.lockPCIeLinkGeneration.Lock()
+	mock.calls.PCIeLinkGeneration = append(mock.calls.PCIeLinkGeneration, callInfo)
+	mock.lockPCIeLinkGeneration.Unlock()
+	if mock.PCIeLinkGenerationFunc == nil {
+		var (
+			r0 uint
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.PCIeLinkGenerationFunc()
+}
+
+// PCIeLinkGenerationCalls gets all the calls that were made to PCIeLinkGeneration.
+func (mock *Device) PCIeLinkGenerationCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockPCIeLinkGeneration.RLock()
+	calls = mock.calls.PCIeLinkGeneration
+	mock.lockPCIeLinkGeneration.RUnlock()
+	return calls
+}
+
+// PCIeLinkGenerationContext calls PCIeLinkGenerationContextFunc.
+func (mock *Device) PCIeLinkGenerationContext(ctx context.Context) (uint, error) {
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockPCIeLinkGenerationContext.Lock()
+	mock.calls.PCIeLinkGenerationContext = append(mock.calls.PCIeLinkGenerationContext, callInfo)
+	mock.lockPCIeLinkGenerationContext.Unlock()
+	if mock.PCIeLinkGenerationContextFunc == nil {
+		var (
+			r0 uint
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.PCIeLinkGenerationContextFunc(ctx)
+}
+
+// PCIeLinkGenerationContextCalls gets all the calls that were made to PCIeLinkGenerationContext.
+func (mock *Device) PCIeLinkGenerationContextCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}
+	mock.lockPCIeLinkGenerationContext.RLock()
+	calls = mock.calls.PCIeLinkGenerationContext
+	mock.lockPCIeLinkGenerationContext.RUnlock()
+	return calls
+}
+
+// PCIeLinkWidth calls PCIeLinkWidthFunc.
+func (mock *Device) PCIeLinkWidth() (uint, error) {
+	callInfo := struct {
+	}{}
+	mock.lockPCIeLinkWidth.Lock()
+	mock.calls.PCIeLinkWidth = append(mock.calls.PCIeLinkWidth, callInfo)
+	mock.lockPCIeLinkWidth.Unlock()
+	if mock.PCIeLinkWidthFunc == nil {
+		var (
+			r0 uint
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.PCIeLinkWidthFunc()
+}
+
+// PCIeLinkWidthCalls gets all the calls that were made to PCIeLinkWidth.
+func (mock *Device) PCIeLinkWidthCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockPCIeLinkWidth.RLock()
+	calls = mock.calls.PCIeLinkWidth
+	mock.lockPCIeLinkWidth.RUnlock()
+	return calls
+}
+
+// PCIeLinkWidthContext calls PCIeLinkWidthContextFunc.
+func (mock *Device) PCIeLinkWidthContext(ctx context.Context) (uint, error) {
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockPCIeLinkWidthContext.Lock()
+	mock.calls.PCIeLinkWidthContext = append(mock.calls.PCIeLinkWidthContext, callInfo)
+	mock.lockPCIeLinkWidthContext.Unlock()
+	if mock.PCIeLinkWidthContextFunc == nil {
+		var (
+			r0 uint
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.PCIeLinkWidthContextFunc(ctx)
+}
+
+// PCIeLinkWidthContextCalls gets all the calls that were made to PCIeLinkWidthContext.
+func (mock *Device) PCIeLinkWidthContextCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}
+	mock.lockPCIeLinkWidthContext.RLock()
+	calls = mock.calls.PCIeLinkWidthContext
+	mock.lockPCIeLinkWidthContext.RUnlock()
+	return calls
+}
+
+// PCIeRX calls PCIeRXFunc.
+func (mock *Device) PCIeRX() (uint, error) {
+	callInfo := struct {
+	}{}
+	mock.lockPCIeRX.Lock()
+	mock.calls.PCIeRX = append(mock.calls.PCIeRX, callInfo)
+	mock.lockPCIeRX.Unlock()
+	if mock.PCIeRXFunc == nil {
+		var (
+			r0 uint
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.PCIeRXFunc()
+}
+
+// PCIeRXCalls gets all the calls that were made to PCIeRX.
+func (mock *Device) PCIeRXCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockPCIeRX.RLock()
+	calls = mock.calls.PCIeRX
+	mock.lockPCIeRX.RUnlock()
+	return calls
+}
+
+// PCIeRXContext calls PCIeRXContextFunc.
+func (mock *Device) PCIeRXContext(ctx context.Context) (uint, error) {
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockPCIeRXContext.Lock()
+	mock.calls.PCIeRXContext = append(mock.calls.PCIeRXContext, callInfo)
+	mock.lockPCIeRXContext.Unlock()
+	if mock.PCIeRXContextFunc == nil {
+		var (
+			r0 uint
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.PCIeRXContextFunc(ctx)
+}
+
+// PCIeRXContextCalls gets all the calls that were made to PCIeRXContext.
+func (mock *Device) PCIeRXContextCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}
+	mock.lockPCIeRXContext.RLock()
+	calls = mock.calls.PCIeRXContext
+	mock.lockPCIeRXContext.RUnlock()
+	return calls
+}
+
+// PCIeTX calls PCIeTXFunc.
+func (mock *Device) PCIeTX() (uint, error) {
+	callInfo := struct {
+	}{}
+	mock.lockPCIeTX.Lock()
+	mock.calls.PCIeTX = append(mock.calls.PCIeTX, callInfo)
+	mock.lockPCIeTX.Unlock()
+	if mock.PCIeTXFunc == nil {
+		var (
+			r0 uint
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.PCIeTXFunc()
+}
+
+// PCIeTXCalls gets all the calls that were made to PCIeTX.
+func (mock *Device) PCIeTXCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockPCIeTX.RLock()
+	calls = mock.calls.PCIeTX
+	mock.lockPCIeTX.RUnlock()
+	return calls
+}
+
+// PCIeTXContext calls PCIeTXContextFunc.
+func (mock *Device) PCIeTXContext(ctx context.Context) (uint, error) {
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockPCIeTXContext.Lock()
+	mock.calls.PCIeTXContext = append(mock.calls.PCIeTXContext, callInfo)
+	mock.lockPCIeTXContext.Unlock()
+	if mock.PCIeTXContextFunc == nil {
+		var (
+			r0 uint
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.PCIeTXContextFunc(ctx)
+}
+
+// PCIeTXContextCalls gets all the calls that were made to PCIeTXContext.
+func (mock *Device) PCIeTXContextCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}
+	mock.lockPCIeTXContext.RLock()
+	calls = mock.calls.PCIeTXContext
+	mock.lockPCIeTXContext.RUnlock()
+	return calls
+}
+
+// PowerManagementDefaultLimit calls PowerManagementDefaultLimitFunc.
+func (mock *Device) PowerManagementDefaultLimit() (uint, error) {
+	callInfo := struct {
+	}{}
+	mock.lockPowerManagementDefaultLimit.Lock()
+	mock.calls.PowerManagementDefaultLimit = append(mock.calls.PowerManagementDefaultLimit, callInfo)
+	mock.lockPowerManagementDefaultLimit.Unlock()
+	if mock.PowerManagementDefaultLimitFunc == nil {
+		var (
+			r0 uint
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.PowerManagementDefaultLimitFunc()
+}
+
+// PowerManagementDefaultLimitCalls gets all the calls that were made to PowerManagementDefaultLimit.
+func (mock *Device) PowerManagementDefaultLimitCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockPowerManagementDefaultLimit.RLock()
+	calls = mock.calls.PowerManagementDefaultLimit
+	mock.lockPowerManagementDefaultLimit.RUnlock()
+	return calls
+}
+
+// PowerManagementDefaultLimitContext calls PowerManagementDefaultLimitContextFunc.
+func (mock *Device) PowerManagementDefaultLimitContext(ctx context.Context) (uint, error) {
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockPowerManagementDefaultLimitContext.Lock()
+	mock.calls.PowerManagementDefaultLimitContext = append(mock.calls.PowerManagementDefaultLimitContext, callInfo)
+	mock.lockPowerManagementDefaultLimitContext.Unlock()
+	if mock.PowerManagementDefaultLimitContextFunc == nil {
+		var (
+			r0 uint
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.PowerManagementDefaultLimitContextFunc(ctx)
+}
+
+// PowerManagementDefaultLimitContextCalls gets all the calls that were made to PowerManagementDefaultLimitContext.
+func (mock *Device) PowerManagementDefaultLimitContextCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}
+	mock.lockPowerManagementDefaultLimitContext.RLock()
+	calls = mock.calls.PowerManagementDefaultLimitContext
+	mock.lockPowerManagementDefaultLimitContext.RUnlock()
+	return calls
+}
+
+// PowerUsage calls PowerUsageFunc.
+func (mock *Device) PowerUsage() (uint, error) {
+	callInfo := struct {
+	}{}
+	mock.lockPowerUsage.Lock()
+	mock.calls.PowerUsage = append(mock.calls.PowerUsage, callInfo)
+	mock.lockPowerUsage.Unlock()
+	if mock.PowerUsageFunc == nil {
+		var (
+			r0 uint
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.PowerUsageFunc()
+}
+
+// PowerUsageCalls gets all the calls that were made to PowerUsage.
+func (mock *Device) PowerUsageCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockPowerUsage.RLock()
+	calls = mock.calls.PowerUsage
+	mock.lockPowerUsage.RUnlock()
+	return calls
+}
+
+// PowerUsageContext calls PowerUsageContextFunc.
+func (mock *Device) PowerUsageContext(ctx context.Context) (uint, error) {
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockPowerUsageContext.Lock()
+	mock.calls.PowerUsageContext = append(mock.calls.PowerUsageContext, callInfo)
+	mock.lockPowerUsageContext.Unlock()
+	if mock.PowerUsageContextFunc == nil {
+		var (
+			r0 uint
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.PowerUsageContextFunc(ctx)
+}
+
+// PowerUsageContextCalls gets all the calls that were made to PowerUsageContext.
+func (mock *Device) PowerUsageContextCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}
+	mock.lockPowerUsageContext.RLock()
+	calls = mock.calls.PowerUsageContext
+	mock.lockPowerUsageContext.RUnlock()
+	return calls
+}
+
+// Processes calls ProcessesFunc.
+func (mock *Device) Processes() ([]gohlml.Process, error) {
+	callInfo := struct {
+	}{}
+	mock.lockProcesses.Lock()
+	mock.calls.Processes = append(mock.calls.Processes, callInfo)
+	mock.lockProcesses.Unlock()
+	if mock.ProcessesFunc == nil {
+		var (
+			r0 []gohlml.Process
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.ProcessesFunc()
+}
+
+// ProcessesCalls gets all the calls that were made to Processes.
+func (mock *Device) ProcessesCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockProcesses.RLock()
+	calls = mock.calls.Processes
+	mock.lockProcesses.RUnlock()
+	return calls
+}
+
+// ProcessesContext calls ProcessesContextFunc.
+func (mock *Device) ProcessesContext(ctx context.Context) ([]gohlml.Process, error) {
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockProcessesContext.Lock()
+	mock.calls.ProcessesContext = append(mock.calls.ProcessesContext, callInfo)
+	mock.lockProcessesContext.Unlock()
+	if mock.ProcessesContextFunc == nil {
+		var (
+			r0 []gohlml.Process
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.ProcessesContextFunc(ctx)
+}
+
+// ProcessesContextCalls gets all the calls that were made to ProcessesContext.
+func (mock *Device) ProcessesContextCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}
+	mock.lockProcessesContext.RLock()
+	calls = mock.calls.ProcessesContext
+	mock.lockProcessesContext.RUnlock()
+	return calls
+}
+
+// ReplacedRowDoubleBitECC calls ReplacedRowDoubleBitECCFunc.
+func (mock *Device) ReplacedRowDoubleBitECC() (uint, error) {
+	callInfo := struct {
+	}{}
+	mock.lockReplacedRowDoubleBitECC.Lock()
+	mock.calls.ReplacedRowDoubleBitECC = append(mock.calls.ReplacedRowDoubleBitECC, callInfo)
+	mock.lockReplacedRowDoubleBitECC.Unlock()
+	if mock.ReplacedRowDoubleBitECCFunc == nil {
+		var (
+			r0 uint
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.ReplacedRowDoubleBitECCFunc()
+}
+
+// ReplacedRowDoubleBitECCCalls gets all the calls that were made to ReplacedRowDoubleBitECC.
+func (mock *Device) ReplacedRowDoubleBitECCCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockReplacedRowDoubleBitECC.RLock()
+	calls = mock.calls.ReplacedRowDoubleBitECC
+	mock.lockReplacedRowDoubleBitECC.RUnlock()
+	return calls
+}
+
+// ReplacedRowDoubleBitECCContext calls ReplacedRowDoubleBitECCContextFunc.
+func (mock *Device) ReplacedRowDoubleBitECCContext(ctx context.Context) (uint, error) {
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockReplacedRowDoubleBitECCContext.Lock()
+	mock.calls.ReplacedRowDoubleBitECCContext = append(mock.calls.ReplacedRowDoubleBitECCContext, callInfo)
+	mock.lockReplacedRowDoubleBitECCContext.Unlock()
+	if mock.ReplacedRowDoubleBitECCContextFunc == nil {
+		var (
+			r0 uint
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.ReplacedRowDoubleBitECCContextFunc(ctx)
+}
+
+// ReplacedRowDoubleBitECCContextCalls gets all the calls that were made to ReplacedRowDoubleBitECCContext.
+func (mock *Device) ReplacedRowDoubleBitECCContextCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}
+	mock.lockReplacedRowDoubleBitECCContext.RLock()
+	calls = mock.calls.ReplacedRowDoubleBitECCContext
+	mock.lockReplacedRowDoubleBitECCContext.RUnlock()
+	return calls
+}
+
+// ReplacedRowSingleBitECC calls ReplacedRowSingleBitECCFunc.
+func (mock *Device) ReplacedRowSingleBitECC() (uint, error) {
+	callInfo := struct {
+	}{}
+	mock.lockReplacedRowSingleBitECC.Lock()
+	mock.calls.ReplacedRowSingleBitECC = append(mock.calls.ReplacedRowSingleBitECC, callInfo)
+	mock.lockReplacedRowSingleBitECC.Unlock()
+	if mock.ReplacedRowSingleBitECCFunc == nil {
+		var (
+			r0 uint
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.ReplacedRowSingleBitECCFunc()
+}
+
+// ReplacedRowSingleBitECCCalls gets all the calls that were made to ReplacedRowSingleBitECC.
+func (mock *Device) ReplacedRowSingleBitECCCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockReplacedRowSingleBitECC.RLock()
+	calls = mock.calls.ReplacedRowSingleBitECC
+	mock.lockReplacedRowSingleBitECC.RUnlock()
+	return calls
+}
+
+// ReplacedRowSingleBitECCContext calls ReplacedRowSingleBitECCContextFunc.
+func (mock *Device) ReplacedRowSingleBitECCContext(ctx context.Context) (uint, error) {
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockReplacedRowSingleBitECCContext.Lock()
+	mock.calls.ReplacedRowSingleBitECCContext = append(mock.calls.ReplacedRowSingleBitECCContext, callInfo)
+	mock.lockReplacedRowSingleBitECCContext.Unlock()
+	if mock.ReplacedRowSingleBitECCContextFunc == nil {
+		var (
+			r0 uint
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.ReplacedRowSingleBitECCContextFunc(ctx)
+}
+
+// ReplacedRowSingleBitECCContextCalls gets all the calls that were made to ReplacedRowSingleBitECCContext.
+func (mock *Device) ReplacedRowSingleBitECCContextCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}
+	mock.lockReplacedRowSingleBitECCContext.RLock()
+	calls = mock.calls.ReplacedRowSingleBitECCContext
+	mock.lockReplacedRowSingleBitECCContext.RUnlock()
+	return calls
+}
+
+// SOCClockInfo calls SOCClockInfoFunc.
+func (mock *Device) SOCClockInfo() (uint, error) {
+	callInfo := struct {
+	}{}
+	mock.lockSOCClockInfo.Lock()
+	mock.calls.SOCClockInfo = append(mock.calls.SOCClockInfo, callInfo)
+	mock.lockSOCClockInfo.Unlock()
+	if mock.SOCClockInfoFunc == nil {
+		var (
+			r0 uint
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.SOCClockInfoFunc()
+}
+
+// SOCClockInfoCalls gets all the calls that were made to SOCClockInfo.
+func (mock *Device) SOCClockInfoCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockSOCClockInfo.RLock()
+	calls = mock.calls.SOCClockInfo
+	mock.lockSOCClockInfo.RUnlock()
+	return calls
+}
+
+// SOCClockInfoContext calls SOCClockInfoContextFunc.
+func (mock *Device) SOCClockInfoContext(ctx context.Context) (uint, error) {
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockSOCClockInfoContext.Lock()
+	mock.calls.SOCClockInfoContext = append(mock.calls.SOCClockInfoContext, callInfo)
+	mock.lockSOCClockInfoContext.Unlock()
+	if mock.SOCClockInfoContextFunc == nil {
+		var (
+			r0 uint
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.SOCClockInfoContextFunc(ctx)
+}
+
+// SOCClockInfoContextCalls gets all the calls that were made to SOCClockInfoContext.
+func (mock *Device) SOCClockInfoContextCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}
+	mock.lockSOCClockInfoContext.RLock()
+	calls = mock.calls.SOCClockInfoContext
+	mock.lockSOCClockInfoContext.RUnlock()
+	return calls
+}
+
+// SOCClockMax calls SOCClockMaxFunc.
+func (mock *Device) SOCClockMax() (uint, error) {
+	callInfo := struct {
+	}{}
+	mock.lockSOCClockMax.Lock()
+	mock.calls.SOCClockMax = append(mock.calls.SOCClockMax, callInfo)
+	mock.lockSOCClockMax.Unlock()
+	if mock.SOCClockMaxFunc == nil {
+		var (
+			r0 uint
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.SOCClockMaxFunc()
+}
+
+// SOCClockMaxCalls gets all the calls that were made to SOCClockMax.
+func (mock *Device) SOCClockMaxCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockSOCClockMax.RLock()
+	calls = mock.calls.SOCClockMax
+	mock.lockSOCClockMax.RUnlock()
+	return calls
+}
+
+// SOCClockMaxContext calls SOCClockMaxContextFunc.
+func (mock *Device) SOCClockMaxContext(ctx context.Context) (uint, error) {
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockSOCClockMaxContext.Lock()
+	mock.calls.SOCClockMaxContext = append(mock.calls.SOCClockMaxContext, callInfo)
+	mock.lockSOCClockMaxContext.Unlock()
+	if mock.SOCClockMaxContextFunc == nil {
+		var (
+			r0 uint
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.SOCClockMaxContextFunc(ctx)
+}
+
+// SOCClockMaxContextCalls gets all the calls that were made to SOCClockMaxContext.
+func (mock *Device) SOCClockMaxContextCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}
+	mock.lockSOCClockMaxContext.RLock()
+	calls = mock.calls.SOCClockMaxContext
+	mock.lockSOCClockMaxContext.RUnlock()
+	return calls
+}
+
+// SerialNumber calls SerialNumberFunc.
+func (mock *Device) SerialNumber() (string, error) {
+	callInfo := struct {
+	}{}
+	mock.lockSerialNumber.Lock()
+	mock.calls.SerialNumber = append(mock.calls.SerialNumber, callInfo)
+	mock.lockSerialNumber.Unlock()
+	if mock.SerialNumberFunc == nil {
+		var (
+			r0 string
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.SerialNumberFunc()
+}
+
+// SerialNumberCalls gets all the calls that were made to SerialNumber.
+func (mock *Device) SerialNumberCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockSerialNumber.RLock()
+	calls = mock.calls.SerialNumber
+	mock.lockSerialNumber.RUnlock()
+	return calls
+}
+
+// SerialNumberContext calls SerialNumberContextFunc.
+func (mock *Device) SerialNumberContext(ctx context.Context) (string, error) {
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockSerialNumberContext.Lock()
+	mock.calls.SerialNumberContext = append(mock.calls.SerialNumberContext, callInfo)
+	mock.lockSerialNumberContext.Unlock()
+	if mock.SerialNumberContextFunc == nil {
+		var (
+			r0 string
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.SerialNumberContextFunc(ctx)
+}
+
+// SerialNumberContextCalls gets all the calls that were made to SerialNumberContext.
+func (mock *Device) SerialNumberContextCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}
+	mock.lockSerialNumberContext.RLock()
+	calls = mock.calls.SerialNumberContext
+	mock.lockSerialNumberContext.RUnlock()
+	return calls
+}
+
+// Snapshot calls SnapshotFunc.
+func (mock *Device) Snapshot() gohlml.Snapshot {
+	callInfo := struct {
+	}{}
+	mock.lockSnapshot.Lock()
+	mock.calls.Snapshot = append(mock.calls.Snapshot, callInfo)
+	mock.lockSnapshot.Unlock()
+	if mock.SnapshotFunc == nil {
+		var (
+			r0 gohlml.Snapshot
+		)
+		return r0
+	}
+	return mock.SnapshotFunc()
+}
+
+// SnapshotCalls gets all the calls that were made to Snapshot.
+func (mock *Device) SnapshotCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockSnapshot.RLock()
+	calls = mock.calls.Snapshot
+	mock.lockSnapshot.RUnlock()
+	return calls
+}
+
+// SnapshotContext calls SnapshotContextFunc.
+func (mock *Device) SnapshotContext(ctx context.Context) gohlml.Snapshot {
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockSnapshotContext.Lock()
+	mock.calls.SnapshotContext = append(mock.calls.SnapshotContext, callInfo)
+	mock.lockSnapshotContext.Unlock()
+	if mock.SnapshotContextFunc == nil {
+		var (
+			r0 gohlml.Snapshot
+		)
+		return r0
+	}
+	return mock.SnapshotContextFunc(ctx)
+}
+
+// SnapshotContextCalls gets all the calls that were made to SnapshotContext.
+func (mock *Device) SnapshotContextCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}
+	mock.lockSnapshotContext.RLock()
+	calls = mock.calls.SnapshotContext
+	mock.lockSnapshotContext.RUnlock()
+	return calls
+}
+
+// TPCClockMax calls TPCClockMaxFunc.
+func (mock *Device) TPCClockMax() (uint, error) {
+	callInfo := struct {
+	}{}
+	mock.lockTPCClockMax.Lock()
+	mock.calls.TPCClockMax = append(mock.calls.TPCClockMax, callInfo)
+	mock.lockTPCClockMax.Unlock()
+	if mock.TPCClockMaxFunc == nil {
+		var (
+			r0 uint
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.TPCClockMaxFunc()
+}
+
+// TPCClockMaxCalls gets all the calls that were made to TPCClockMax.
+func (mock *Device) TPCClockMaxCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockTPCClockMax.RLock()
+	calls = mock.calls.TPCClockMax
+	mock.lockTPCClockMax.RUnlock()
+	return calls
+}
+
+// TPCClockMaxContext calls TPCClockMaxContextFunc.
+func (mock *Device) TPCClockMaxContext(ctx context.Context) (uint, error) {
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockTPCClockMaxContext.Lock()
+	mock.calls.TPCClockMaxContext = append(mock.calls.TPCClockMaxContext, callInfo)
+	mock.lockTPCClockMaxContext.Unlock()
+	if mock.TPCClockMaxContextFunc == nil {
+		var (
+			r0 uint
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.TPCClockMaxContextFunc(ctx)
+}
+
+// TPCClockMaxContextCalls gets all the calls that were made to TPCClockMaxContext.
+func (mock *Device) TPCClockMaxContextCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}
+	mock.lockTPCClockMaxContext.RLock()
+	calls = mock.calls.TPCClockMaxContext
+	mock.lockTPCClockMaxContext.RUnlock()
+	return calls
+}
+
+// TemperatureOnBoard calls TemperatureOnBoardFunc.
+func (mock *Device) TemperatureOnBoard() (uint, error) {
+	callInfo := struct {
+	}{}
+	mock.lockTemperatureOnBoard.Lock()
+	mock.calls.TemperatureOnBoard = append(mock.calls.TemperatureOnBoard, callInfo)
+	mock.lockTemperatureOnBoard.Unlock()
+	if mock.TemperatureOnBoardFunc == nil {
+		var (
+			r0 uint
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.TemperatureOnBoardFunc()
+}
+
+// TemperatureOnBoardCalls gets all the calls that were made to TemperatureOnBoard.
+func (mock *Device) TemperatureOnBoardCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockTemperatureOnBoard.RLock()
+	calls = mock.calls.TemperatureOnBoard
+	mock.lockTemperatureOnBoard.RUnlock()
+	return calls
+}
+
+// TemperatureOnBoardContext calls TemperatureOnBoardContextFunc.
+func (mock *Device) TemperatureOnBoardContext(ctx context.Context) (uint, error) {
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockTemperatureOnBoardContext.Lock()
+	mock.calls.TemperatureOnBoardContext = append(mock.calls.TemperatureOnBoardContext, callInfo)
+	mock.lockTemperatureOnBoardContext.Unlock()
+	if mock.TemperatureOnBoardContextFunc == nil {
+		var (
+			r0 uint
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.TemperatureOnBoardContextFunc(ctx)
+}
+
+// TemperatureOnBoardContextCalls gets all the calls that were made to TemperatureOnBoardContext.
+func (mock *Device) TemperatureOnBoardContextCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}
+	mock.lockTemperatureOnBoardContext.RLock()
+	calls = mock.calls.TemperatureOnBoardContext
+	mock.lockTemperatureOnBoardContext.RUnlock()
+	return calls
+}
+
+// TemperatureOnChip calls TemperatureOnChipFunc.
+func (mock *Device) TemperatureOnChip() (uint, error) {
+	callInfo := struct {
+	}{}
+	mock.lockTemperatureOnChip.Lock()
+	mock.calls.TemperatureOnChip = append(mock.calls.TemperatureOnChip, callInfo)
+	mock.lockTemperatureOnChip.Unlock()
+	if mock.TemperatureOnChipFunc == nil {
+		var (
+			r0 uint
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.TemperatureOnChipFunc()
+}
+
+// TemperatureOnChipCalls gets all the calls that were made to TemperatureOnChip.
+func (mock *Device) TemperatureOnChipCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockTemperatureOnChip.RLock()
+	calls = mock.calls.TemperatureOnChip
+	mock.lockTemperatureOnChip.RUnlock()
+	return calls
+}
+
+// TemperatureOnChipContext calls TemperatureOnChipContextFunc.
+func (mock *Device) TemperatureOnChipContext(ctx context.Context) (uint, error) {
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockTemperatureOnChipContext.Lock()
+	mock.calls.TemperatureOnChipContext = append(mock.calls.TemperatureOnChipContext, callInfo)
+	mock.lockTemperatureOnChipContext.Unlock()
+	if mock.TemperatureOnChipContextFunc == nil {
+		var (
+			r0 uint
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.TemperatureOnChipContextFunc(ctx)
+}
+
+// TemperatureOnChipContextCalls gets all the calls that were made to TemperatureOnChipContext.
+func (mock *Device) TemperatureOnChipContextCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}
+	mock.lockTemperatureOnChipContext.RLock()
+	calls = mock.calls.TemperatureOnChipContext
+	mock.lockTemperatureOnChipContext.RUnlock()
+	return calls
+}
+
+// TemperatureThresholdGPU calls TemperatureThresholdGPUFunc.
+func (mock *Device) TemperatureThresholdGPU() (uint, error) {
+	callInfo := struct {
+	}{}
+	mock.lockTemperatureThresholdGPU.Lock()
+	mock.calls.TemperatureThresholdGPU = append(mock.calls.TemperatureThresholdGPU, callInfo)
+	mock.lockTemperatureThresholdGPU.Unlock()
+	if mock.TemperatureThresholdGPUFunc == nil {
+		var (
+			r0 uint
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.TemperatureThresholdGPUFunc()
+}
+
+// TemperatureThresholdGPUCalls gets all the calls that were made to TemperatureThresholdGPU.
+func (mock *Device) TemperatureThresholdGPUCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockTemperatureThresholdGPU.RLock()
+	calls = mock.calls.TemperatureThresholdGPU
+	mock.lockTemperatureThresholdGPU.RUnlock()
+	return calls
+}
+
+// TemperatureThresholdGPUContext calls TemperatureThresholdGPUContextFunc.
+func (mock *Device) TemperatureThresholdGPUContext(ctx context.Context) (uint, error) {
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockTemperatureThresholdGPUContext.Lock()
+	mock.calls.TemperatureThresholdGPUContext = append(mock.calls.TemperatureThresholdGPUContext, callInfo)
+	mock.lockTemperatureThresholdGPUContext.Unlock()
+	if mock.TemperatureThresholdGPUContextFunc == nil {
+		var (
+			r0 uint
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.TemperatureThresholdGPUContextFunc(ctx)
+}
+
+// TemperatureThresholdGPUContextCalls gets all the calls that were made to TemperatureThresholdGPUContext.
+func (mock *Device) TemperatureThresholdGPUContextCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}
+	mock.lockTemperatureThresholdGPUContext.RLock()
+	calls = mock.calls.TemperatureThresholdGPUContext
+	mock.lockTemperatureThresholdGPUContext.RUnlock()
+	return calls
+}
+
+// TemperatureThresholdMemory calls TemperatureThresholdMemoryFunc.
+func (mock *Device) TemperatureThresholdMemory() (uint, error) {
+	callInfo := struct {
+	}{}
+	mock.lockTemperatureThresholdMemory.Lock()
+	mock.calls.TemperatureThresholdMemory = append(mock.calls.TemperatureThresholdMemory, callInfo)
+	mock.lockTemperatureThresholdMemory.Unlock()
+	if mock.TemperatureThresholdMemoryFunc == nil {
+		var (
+			r0 uint
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.TemperatureThresholdMemoryFunc()
+}
+
+// TemperatureThresholdMemoryCalls gets all the calls that were made to TemperatureThresholdMemory.
+func (mock *Device) TemperatureThresholdMemoryCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockTemperatureThresholdMemory.RLock()
+	calls = mock.calls.TemperatureThresholdMemory
+	mock.lockTemperatureThresholdMemory.RUnlock()
+	return calls
+}
+
+// TemperatureThresholdMemoryContext calls TemperatureThresholdMemoryContextFunc.
+func (mock *Device) TemperatureThresholdMemoryContext(ctx context.Context) (uint, error) {
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockTemperatureThresholdMemoryContext.Lock()
+	mock.calls.TemperatureThresholdMemoryContext = append(mock.calls.TemperatureThresholdMemoryContext, callInfo)
+	mock.lockTemperatureThresholdMemoryContext.Unlock()
+	if mock.TemperatureThresholdMemoryContextFunc == nil {
+		var (
+			r0 uint
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.TemperatureThresholdMemoryContextFunc(ctx)
+}
+
+// TemperatureThresholdMemoryContextCalls gets all the calls that were made to TemperatureThresholdMemoryContext.
+func (mock *Device) TemperatureThresholdMemoryContextCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}
+	mock.lockTemperatureThresholdMemoryContext.RLock()
+	calls = mock.calls.TemperatureThresholdMemoryContext
+	mock.lockTemperatureThresholdMemoryContext.RUnlock()
+	return calls
+}
+
+// TemperatureThresholdShutdown calls TemperatureThresholdShutdownFunc.
+func (mock *Device) TemperatureThresholdShutdown() (uint, error) {
+	callInfo := struct {
+	}{}
+	mock.lockTemperatureThresholdShutdown.Lock()
+	mock.calls.TemperatureThresholdShutdown = append(mock.calls.TemperatureThresholdShutdown, callInfo)
+	mock.lockTemperatureThresholdShutdown.Unlock()
+	if mock.TemperatureThresholdShutdownFunc == nil {
+		var (
+			r0 uint
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.TemperatureThresholdShutdownFunc()
+}
+
+// TemperatureThresholdShutdownCalls gets all the calls that were made to TemperatureThresholdShutdown.
+func (mock *Device) TemperatureThresholdShutdownCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockTemperatureThresholdShutdown.RLock()
+	calls = mock.calls.TemperatureThresholdShutdown
+	mock.lockTemperatureThresholdShutdown.RUnlock()
+	return calls
+}
+
+// TemperatureThresholdShutdownContext calls TemperatureThresholdShutdownContextFunc.
+func (mock *Device) TemperatureThresholdShutdownContext(ctx context.Context) (uint, error) {
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockTemperatureThresholdShutdownContext.Lock()
+	mock.calls.TemperatureThresholdShutdownContext = append(mock.calls.TemperatureThresholdShutdownContext, callInfo)
+	mock.lockTemperatureThresholdShutdownContext.Unlock()
+	if mock.TemperatureThresholdShutdownContextFunc == nil {
+		var (
+			r0 uint
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.TemperatureThresholdShutdownContextFunc(ctx)
+}
+
+// TemperatureThresholdShutdownContextCalls gets all the calls that were made to TemperatureThresholdShutdownContext.
+func (mock *Device) TemperatureThresholdShutdownContextCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}
+	mock.lockTemperatureThresholdShutdownContext.RLock()
+	calls = mock.calls.TemperatureThresholdShutdownContext
+	mock.lockTemperatureThresholdShutdownContext.RUnlock()
+	return calls
+}
+
+// TemperatureThresholdSlowdown calls TemperatureThresholdSlowdownFunc.
+func (mock *Device) TemperatureThresholdSlowdown() (uint, error) {
+	callInfo := struct {
+	}{}
+	mock.lockTemperatureThresholdSlowdown.Lock()
+	mock.calls.TemperatureThresholdSlowdown = append(mock.calls.TemperatureThresholdSlowdown, callInfo)
+	mock.lockTemperatureThresholdSlowdown.Unlock()
+	if mock.TemperatureThresholdSlowdownFunc == nil {
+		var (
+			r0 uint
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.TemperatureThresholdSlowdownFunc()
+}
+
+// TemperatureThresholdSlowdownCalls gets all the calls that were made to TemperatureThresholdSlowdown.
+func (mock *Device) TemperatureThresholdSlowdownCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockTemperatureThresholdSlowdown.RLock()
+	calls = mock.calls.TemperatureThresholdSlowdown
+	mock.lockTemperatureThresholdSlowdown.RUnlock()
+	return calls
+}
+
+// TemperatureThresholdSlowdownContext calls TemperatureThresholdSlowdownContextFunc.
+func (mock *Device) TemperatureThresholdSlowdownContext(ctx context.Context) (uint, error) {
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockTemperatureThresholdSlowdownContext.Lock()
+	mock.calls.TemperatureThresholdSlowdownContext = append(mock.calls.TemperatureThresholdSlowdownContext, callInfo)
+	mock.lockTemperatureThresholdSlowdownContext.Unlock()
+	if mock.TemperatureThresholdSlowdownContextFunc == nil {
+		var (
+			r0 uint
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.TemperatureThresholdSlowdownContextFunc(ctx)
+}
+
+// TemperatureThresholdSlowdownContextCalls gets all the calls that were made to TemperatureThresholdSlowdownContext.
+func (mock *Device) TemperatureThresholdSlowdownContextCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}
+	mock.lockTemperatureThresholdSlowdownContext.RLock()
+	calls = mock.calls.TemperatureThresholdSlowdownContext
+	mock.lockTemperatureThresholdSlowdownContext.RUnlock()
+	return calls
+}
+
+// UUID calls UUIDFunc.
+func (mock *Device) UUID() (string, error) {
+	callInfo := struct {
+	}{}
+	mock.lockUUID.Lock()
+	mock.calls.UUID = append(mock.calls.UUID, callInfo)
+	mock.lockUUID.Unlock()
+	if mock.UUIDFunc == nil {
+		var (
+			r0 string
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.UUIDFunc()
+}
+
+// UUIDCalls gets all the calls that were made to UUID.
+func (mock *Device) UUIDCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockUUID.RLock()
+	calls = mock.calls.UUID
+	mock.lockUUID.RUnlock()
+	return calls
+}
+
+// UUIDContext calls UUIDContextFunc.
+func (mock *Device) UUIDContext(ctx context.Context) (string, error) {
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockUUIDContext.Lock()
+	mock.calls.UUIDContext = append(mock.calls.UUIDContext, callInfo)
+	mock.lockUUIDContext.Unlock()
+	if mock.UUIDContextFunc == nil {
+		var (
+			r0 string
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.UUIDContextFunc(ctx)
+}
+
+// UUIDContextCalls gets all the calls that were made to UUIDContext.
+func (mock *Device) UUIDContextCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}
+	mock.lockUUIDContext.RLock()
+	calls = mock.calls.UUIDContext
+	mock.lockUUIDContext.RUnlock()
+	return calls
+}
+
+// UtilizationInfo calls UtilizationInfoFunc.
+func (mock *Device) UtilizationInfo() (uint, error) {
+	callInfo := struct {
+	}{}
+	mock.lockUtilizationInfo.Lock()
+	mock.calls.UtilizationInfo = append(mock.calls.UtilizationInfo, callInfo)
+	mock.lockUtilizationInfo.Unlock()
+	if mock.UtilizationInfoFunc == nil {
+		var (
+			r0 uint
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.UtilizationInfoFunc()
+}
+
+// UtilizationInfoCalls gets all the calls that were made to UtilizationInfo.
+func (mock *Device) UtilizationInfoCalls() []struct {
+} {
+	var calls []struct {
+	}
+	mock.lockUtilizationInfo.RLock()
+	calls = mock.calls.UtilizationInfo
+	mock.lockUtilizationInfo.RUnlock()
+	return calls
+}
+
+// UtilizationInfoContext calls UtilizationInfoContextFunc.
+func (mock *Device) UtilizationInfoContext(ctx context.Context) (uint, error) {
+	callInfo := struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockUtilizationInfoContext.Lock()
+	mock.calls.UtilizationInfoContext = append(mock.calls.UtilizationInfoContext, callInfo)
+	mock.lockUtilizationInfoContext.Unlock()
+	if mock.UtilizationInfoContextFunc == nil {
+		var (
+			r0 uint
+			r1 error
+		)
+		return r0, r1
+	}
+	return mock.UtilizationInfoContextFunc(ctx)
+}
+
+// UtilizationInfoContextCalls gets all the calls that were made to UtilizationInfoContext.
+func (mock *Device) UtilizationInfoContextCalls() []struct {
+	// Ctx is the ctx argument value.
+	Ctx context.Context
+} {
+	var calls []struct {
+		// Ctx is the ctx argument value.
+		Ctx context.Context
+	}
+	mock.lockUtilizationInfoContext.RLock()
+	calls = mock.calls.UtilizationInfoContext
+	mock.lockUtilizationInfoContext.RUnlock()
+	return calls
+}
@@ -0,0 +1,77 @@
+/*
+ * Copyright (c) 2022, HabanaLabs Ltd.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the Lic
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package mock
+
+import (
+	"testing"
+
+	"github.com/HabanaAI/gohlml"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInterfaceMock(t *testing.T) {
+	dev := &Device{
+		PowerUsageFunc: func() (uint, error) { return 150000, nil },
+		NicLinkStatusFunc: func(port uint) (uint, error) {
+			if port == 3 {
+				return 0, gohlml.ErrNotSupported
+			}
+			return 1, nil
+		},
+	}
+	lib := &Interface{
+		DeviceCountFunc:         func() (uint, error) { return 1, nil },
+		DeviceHandleByIndexFunc: func(idx uint) (gohlml.DeviceInterface, error) { return dev, nil },
+		DiscoverFunc: func() ([]gohlml.PCIDevice, error) {
+			return []gohlml.PCIDevice{{Address: "0000:19:00.0", VendorID: gohlml.HabanaVendorID, DeviceID: 0x1020}}, nil
+		},
+	}
+
+	var hlml gohlml.Interface = lib
+	assert.Nil(t, hlml.Initialize(), "Unset functions should return zero values")
+
+	cnt, err := hlml.DeviceCount()
+	assert.Nil(t, err, err)
+	assert.Equal(t, uint(1), cnt)
+
+	d, err := hlml.DeviceHandleByIndex(0)
+	assert.Nil(t, err, err)
+
+	power, err := d.PowerUsage()
+	assert.Nil(t, err, err)
+	assert.Equal(t, uint(150000), power)
+
+	_, err = d.NicLinkStatus(1)
+	assert.Nil(t, err, err)
+	_, err = d.NicLinkStatus(3)
+	assert.ErrorIs(t, err, gohlml.ErrNotSupported)
+
+	serial, err := d.SerialNumber()
+	assert.Nil(t, err, err)
+	assert.Equal(t, "", serial)
+
+	assert.Len(t, lib.InitializeCalls(), 1)
+	assert.Len(t, lib.DeviceHandleByIndexCalls(), 1)
+	assert.Equal(t, uint(0), lib.DeviceHandleByIndexCalls()[0].Idx)
+	assert.Len(t, dev.PowerUsageCalls(), 1)
+	assert.Len(t, dev.NicLinkStatusCalls(), 2)
+	assert.Equal(t, uint(3), dev.NicLinkStatusCalls()[1].Port)
+
+	pci, err := hlml.Discover()
+	assert.Nil(t, err, err)
+	assert.Len(t, pci, 1, "Package functions reading sysfs should be mockable")
+	assert.Len(t, lib.DiscoverCalls(), 1)
+}
@@ -0,0 +1,172 @@
+/*
+ * Copyright (c) 2022, HabanaLabs Ltd.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the Lic
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gohlml
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+)
+
+// KernelModule is the state and configuration of the habanalabs kernel
+// module, read from module/habanalabs
+type KernelModule struct {
+	Version    string
+	SrcVersion string
+	// RefCount is the number of references held on the module
+	RefCount int
+	// Holders lists the modules that depend on habanalabs
+	Holders []string
+	// InitState is live once the module is loaded
+	InitState string
+	// Taint holds the taint flags of the module, e.g. O for out of tree
+	Taint string
+	// Parameters maps the name of every readable module parameter to its
+	// value
+	Parameters map[string]ModuleParameter
+}
+
+// ModuleParameter is the value of a module parameter. Value holds an int,
+// bool or uint64 for the parameters the package knows the type of, and
+// the raw string otherwise
+type ModuleParameter struct {
+	Raw   string
+	Value any
+}
+
+// moduleParameterTypes parses the parameters of known type
+var moduleParameterTypes = map[string]func(string) (any, error){
+	// seconds before a command submission is considered stuck
+	"timeout_locked":         parseInt,
+	"reset_on_lockup":        parseBool,
+	"memory_scrub":           parseBool,
+	"boot_error_status_mask": parseUint64,
+}
+
+func parseInt(s string) (any, error) {
+	v, err := strconv.Atoi(s)
+	return v, err
+}
+
+// parseBool accepts the Y/N of bool parameters and the 0/1 of int ones
+func parseBool(s string) (any, error) {
+	switch s {
+	case "Y", "y":
+		return true, nil
+	case "N", "n":
+		return false, nil
+	}
+	return strconv.ParseBool(s)
+}
+
+func parseUint64(s string) (any, error) {
+	return parseUint(s)
+}
+
+// ModuleInfo reads the state of the habanalabs module and its parameters.
+// It fails with ErrDriverNotLoaded if the module is not loaded. Parameters
+// that are not readable, or fail to parse as their known type, are kept as
+// raw strings or left out
+func ModuleInfo() (KernelModule, error) {
+	dir := sysfsPath("module", "habanalabs")
+	if _, err := os.Stat(dir); errors.Is(err, os.ErrNotExist) {
+		return KernelModule{}, fmt.Errorf("habanalabs module: %w", ErrDriverNotLoaded)
+	}
+
+	var m KernelModule
+	for name, p := range map[string]*string{
+		"version":    &m.Version,
+		"srcversion": &m.SrcVersion,
+		"initstate":  &m.InitState,
+		"taint":      &m.Taint,
+	} {
+		if v, ok, err := readAttr(dir, name); err == nil && ok {
+			*p = v
+		}
+	}
+	if v, ok, err := readAttr(dir, "refcnt"); err == nil && ok {
+		if n, err := strconv.Atoi(v); err == nil {
+			m.RefCount = n
+		}
+	}
+
+	if entries, err := os.ReadDir(sysfsPath("module", "habanalabs", "holders")); err == nil {
+		for _, e := range entries {
+			m.Holders = append(m.Holders, e.Name())
+		}
+	}
+
+	params := sysfsPath("module", "habanalabs", "parameters")
+	if entries, err := os.ReadDir(params); err == nil {
+		m.Parameters = map[string]ModuleParameter{}
+		for _, e := range entries {
+			v, ok, err := readAttr(params, e.Name())
+			if err != nil || !ok {
+				continue
+			}
+			p := ModuleParameter{Raw: v, Value: v}
+			if parse, ok := moduleParameterTypes[e.Name()]; ok {
+				if x, err := parse(v); err == nil {
+					p.Value = x
+				}
+			}
+			m.Parameters[e.Name()] = p
+		}
+	}
+	return m, nil
+}
+
+// ModuleDifference is a configuration item that differs between two
+// modules. A and B are empty where the item is absent
+type ModuleDifference struct {
+	// Field is e.g. version or parameters.timeout_locked
+	Field string
+	A     string
+	B     string
+}
+
+// DiffModules compares the configuration of two modules, typically read on
+// different nodes: their versions, taint flags and parameters. Runtime
+// state such as RefCount and Holders is ignored. Differences are ordered by
+// field
+func DiffModules(a, b KernelModule) []ModuleDifference {
+	var diffs []ModuleDifference
+	add := func(field, x, y string) {
+		if x != y {
+			diffs = append(diffs, ModuleDifference{Field: field, A: x, B: y})
+		}
+	}
+	add("srcversion", a.SrcVersion, b.SrcVersion)
+	add("taint", a.Taint, b.Taint)
+	add("version", a.Version, b.Version)
+
+	names := map[string]struct{}{}
+	for name := range a.Parameters {
+		names[name] = struct{}{}
+	}
+	for name := range b.Parameters {
+		names[name] = struct{}{}
+	}
+	for name := range names {
+		add("parameters."+name, a.Parameters[name].Raw, b.Parameters[name].Raw)
+	}
+
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i].Field < diffs[j].Field })
+	return diffs
+}
@@ -0,0 +1,84 @@
+/*
+ * Copyright (c) 2022, HabanaLabs Ltd.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the Lic
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gohlml
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestModuleInfo(t *testing.T) {
+	root := t.TempDir()
+	useSysfsRoot(t, root)
+
+	_, err := ModuleInfo()
+	assert.ErrorIs(t, err, ErrDriverNotLoaded)
+
+	dir := filepath.Join(root, "module", "habanalabs")
+	for name, v := range map[string]string{
+		"version":                           "1.17.0-fake",
+		"srcversion":                        "6E3F0C5B1A2D4E8F9A7B3C1",
+		"refcnt":                            "3",
+		"initstate":                         "live",
+		"taint":                             "OE",
+		"parameters/timeout_locked":         "30",
+		"parameters/reset_on_lockup":        "1",
+		"parameters/memory_scrub":           "0",
+		"parameters/boot_error_status_mask": "0xffffffff",
+		"parameters/new_param":              "Y",
+	} {
+		path := filepath.Join(dir, name)
+		assert.Nil(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		assert.Nil(t, os.WriteFile(path, []byte(v+"\n"), 0o644))
+	}
+	assert.Nil(t, os.MkdirAll(filepath.Join(dir, "holders", "habanalabs_ib"), 0o755))
+
+	m, err := ModuleInfo()
+	assert.Nil(t, err, err)
+	assert.Equal(t, "1.17.0-fake", m.Version)
+	assert.Equal(t, "6E3F0C5B1A2D4E8F9A7B3C1", m.SrcVersion)
+	assert.Equal(t, 3, m.RefCount)
+	assert.Equal(t, []string{"habanalabs_ib"}, m.Holders)
+	assert.Equal(t, "live", m.InitState)
+	assert.Equal(t, "OE", m.Taint)
+	assert.Equal(t, map[string]ModuleParameter{
+		"timeout_locked":         {Raw: "30", Value: 30},
+		"reset_on_lockup":        {Raw: "1", Value: true},
+		"memory_scrub":           {Raw: "0", Value: false},
+		"boot_error_status_mask": {Raw: "0xffffffff", Value: uint64(0xffffffff)},
+		"new_param":              {Raw: "Y", Value: "Y"},
+	}, m.Parameters)
+
+	other := m
+	other.RefCount = 0
+	other.Version = "1.18.0"
+	other.Parameters = map[string]ModuleParameter{
+		"timeout_locked":         {Raw: "300", Value: 300},
+		"reset_on_lockup":        {Raw: "1", Value: true},
+		"memory_scrub":           {Raw: "0", Value: false},
+		"boot_error_status_mask": {Raw: "0xffffffff", Value: uint64(0xffffffff)},
+	}
+	assert.Equal(t, []ModuleDifference{
+		{Field: "parameters.new_param", A: "Y"},
+		{Field: "parameters.timeout_locked", A: "30", B: "300"},
+		{Field: "version", A: "1.17.0-fake", B: "1.18.0"},
+	}, DiffModules(m, other))
+	assert.Empty(t, DiffModules(m, m))
+}
@@ -0,0 +1,207 @@
+/*
+ * Copyright (c) 2022, HabanaLabs Ltd.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the Lic
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gohlml
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ContainerRuntime is the runtime that created a container
+type ContainerRuntime int
+
+const (
+	// RuntimeUnknown is set when the cgroup path does not name the runtime,
+	// as with the cgroupfs driver of kubelet
+	RuntimeUnknown ContainerRuntime = iota
+	RuntimeDocker
+	RuntimeContainerd
+	RuntimeCRIO
+)
+
+var runtimeNames = map[ContainerRuntime]string{
+	RuntimeUnknown:    "unknown",
+	RuntimeDocker:     "docker",
+	RuntimeContainerd: "containerd",
+	RuntimeCRIO:       "cri-o",
+}
+
+func (r ContainerRuntime) String() string {
+	if name, ok := runtimeNames[r]; ok {
+		return name
+	}
+	return fmt.Sprintf("ContainerRuntime(%d)", int(r))
+}
+
+// Kubernetes pod QoS classes
+const (
+	QoSGuaranteed = "Guaranteed"
+	QoSBurstable  = "Burstable"
+	QoSBestEffort = "BestEffort"
+)
+
+// Container is the container and pod a process runs in
+type Container struct {
+	Runtime ContainerRuntime
+	// ID is the full container id, empty for a process in a pod cgroup
+	// outside any container
+	ID string
+	// PodUID and QoSClass are empty outside Kubernetes
+	PodUID   string
+	QoSClass string
+}
+
+// OwnerProcess is a process holding a device and its container
+type OwnerProcess struct {
+	Process
+	// Container is nil for a process outside any container or pod
+	Container *Container
+}
+
+// DeviceOwner lists the workloads holding a device
+type DeviceOwner struct {
+	UUID      string
+	Processes []OwnerProcess
+	// Err is set when the UUID or the device nodes of the device could not
+	// be resolved, in which case Processes is empty
+	Err error
+}
+
+var (
+	// systemd cgroup driver, e.g. cri-containerd-<id>.scope
+	scopeContainer = regexp.MustCompile(`^(docker|cri-containerd|crio)-([0-9a-f]{64})\.scope$`)
+	// cgroupfs driver, e.g. crio-<id> or a bare id
+	cgroupfsContainer = regexp.MustCompile(`^(?:(docker|crio)-)?([0-9a-f]{64})$`)
+	// kubepods-burstable-pod<uid>.slice, with _ in place of - in the uid
+	slicePod = regexp.MustCompile(`^kubepods(?:-(burstable|besteffort))?-pod([0-9a-f_]+)\.slice$`)
+	// pod<uid> under kubepods/<qos>
+	cgroupfsPod = regexp.MustCompile(`^pod([0-9a-f-]+)$`)
+)
+
+var containerRuntimes = map[string]ContainerRuntime{
+	"docker":         RuntimeDocker,
+	"cri-containerd": RuntimeContainerd,
+	"crio":           RuntimeCRIO,
+}
+
+// ParseContainer resolves a cgroup path, such as Process.Cgroup, to its
+// container and pod. It understands the paths of the cgroupfs and systemd
+// drivers of docker, containerd, CRI-O and kubelet, in cgroup v1 and v2
+// hierarchies. It reports false for a path outside any container or pod
+func ParseContainer(cgroup string) (Container, bool) {
+	var c Container
+	kubepods := false
+	parent := ""
+	for _, seg := range strings.Split(cgroup, "/") {
+		switch {
+		case seg == "kubepods" || seg == "kubepods.slice":
+			kubepods = true
+		case kubepods && (seg == "burstable" || seg == "kubepods-burstable.slice"):
+			c.QoSClass = QoSBurstable
+		case kubepods && (seg == "besteffort" || seg == "kubepods-besteffort.slice"):
+			c.QoSClass = QoSBestEffort
+		}
+
+		if m := slicePod.FindStringSubmatch(seg); m != nil {
+			c.PodUID = strings.ReplaceAll(m[2], "_", "-")
+		} else if m := cgroupfsPod.FindStringSubmatch(seg); kubepods && m != nil {
+			c.PodUID = m[1]
+		}
+
+		if m := scopeContainer.FindStringSubmatch(seg); m != nil {
+			c.Runtime, c.ID = containerRuntimes[m[1]], m[2]
+		} else if m := cgroupfsContainer.FindStringSubmatch(seg); m != nil {
+			c.ID = m[2]
+			switch {
+			case m[1] != "":
+				c.Runtime = containerRuntimes[m[1]]
+			case parent == "docker":
+				c.Runtime = RuntimeDocker
+			default:
+				c.Runtime = RuntimeUnknown
+			}
+		}
+		parent = seg
+	}
+
+	if c.PodUID != "" && c.QoSClass == "" {
+		c.QoSClass = QoSGuaranteed
+	}
+	if c.PodUID == "" {
+		c.QoSClass = ""
+	}
+	return c, c.ID != "" || c.PodUID != ""
+}
+
+// Owners returns, for each device, the processes holding it with their
+// containers and pods. Procfs is scanned once for all the devices. A device
+// that fails to resolve has its DeviceOwner.Err set, and Owners only fails
+// if procfs cannot be scanned or ctx is done. A process holding several
+// devices is listed under each, with the nodes of that device
+func Owners(ctx context.Context, devices []DeviceInterface) ([]DeviceOwner, error) {
+	owners := make([]DeviceOwner, len(devices))
+	// index in owners of the device of each node
+	byPath := map[string]int{}
+	var nodes []DeviceNode
+	for i, dev := range devices {
+		uuid, err := dev.UUIDContext(ctx)
+		if err != nil {
+			owners[i].Err = err
+			continue
+		}
+		owners[i].UUID = uuid
+		devNodes, err := dev.DeviceNodesContext(ctx)
+		if err != nil {
+			owners[i].Err = err
+			continue
+		}
+		for _, n := range devNodes {
+			byPath[n.Path] = i
+		}
+		nodes = append(nodes, devNodes...)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return owners, nil
+	}
+
+	procs, err := scanProcesses(ctx, nodes)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range procs {
+		held := map[int][]string{}
+		for _, path := range p.Nodes {
+			held[byPath[path]] = append(held[byPath[path]], path)
+		}
+		for i := range owners {
+			if paths, ok := held[i]; ok {
+				op := OwnerProcess{Process: p}
+				op.Nodes = paths
+				if c, ok := ParseContainer(p.Cgroup); ok {
+					op.Container = &c
+				}
+				owners[i].Processes = append(owners[i].Processes, op)
+			}
+		}
+	}
+	return owners, nil
+}
@@ -0,0 +1,145 @@
+/*
+ * Copyright (c) 2022, HabanaLabs Ltd.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the Lic
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gohlml_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/HabanaAI/gohlml"
+	"github.com/HabanaAI/gohlml/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+const containerID = "3f4e9b2c0a1d8e7f6b5c4d3e2f1a0b9c8d7e6f5a4b3c2d1e0f9a8b7c6d5e4f3a"
+
+func TestParseContainer(t *testing.T) {
+	for _, tc := range []struct {
+		cgroup string
+		want   gohlml.Container
+		ok     bool
+	}{
+		{"/system.slice/docker-" + containerID + ".scope", gohlml.Container{Runtime: gohlml.RuntimeDocker, ID: containerID}, true},
+		{"/docker/" + containerID, gohlml.Container{Runtime: gohlml.RuntimeDocker, ID: containerID}, true},
+		{
+			"/kubepods.slice/kubepods-burstable.slice/kubepods-burstable-pod8d5c1f2e_4a3b_4c6d_9e8f_7a6b5c4d3e2f.slice/cri-containerd-" + containerID + ".scope",
+			gohlml.Container{Runtime: gohlml.RuntimeContainerd, ID: containerID, PodUID: "8d5c1f2e-4a3b-4c6d-9e8f-7a6b5c4d3e2f", QoSClass: gohlml.QoSBurstable},
+			true,
+		},
+		{
+			"/kubepods.slice/kubepods-pod8d5c1f2e_4a3b_4c6d_9e8f_7a6b5c4d3e2f.slice/crio-" + containerID + ".scope",
+			gohlml.Container{Runtime: gohlml.RuntimeCRIO, ID: containerID, PodUID: "8d5c1f2e-4a3b-4c6d-9e8f-7a6b5c4d3e2f", QoSClass: gohlml.QoSGuaranteed},
+			true,
+		},
+		{
+			"/kubepods/besteffort/pod8d5c1f2e-4a3b-4c6d-9e8f-7a6b5c4d3e2f/" + containerID,
+			gohlml.Container{ID: containerID, PodUID: "8d5c1f2e-4a3b-4c6d-9e8f-7a6b5c4d3e2f", QoSClass: gohlml.QoSBestEffort},
+			true,
+		},
+		{
+			"/kubepods/burstable/pod8d5c1f2e-4a3b-4c6d-9e8f-7a6b5c4d3e2f/crio-" + containerID,
+			gohlml.Container{Runtime: gohlml.RuntimeCRIO, ID: containerID, PodUID: "8d5c1f2e-4a3b-4c6d-9e8f-7a6b5c4d3e2f", QoSClass: gohlml.QoSBurstable},
+			true,
+		},
+		{"/user.slice/user-1000.slice/session-3.scope", gohlml.Container{}, false},
+		{"/", gohlml.Container{}, false},
+	} {
+		c, ok := gohlml.ParseContainer(tc.cgroup)
+		assert.Equal(t, tc.ok, ok, tc.cgroup)
+		assert.Equal(t, tc.want, c, tc.cgroup)
+	}
+}
+
+// writeProc writes a process holding the given files open to a fake procfs
+func writeProc(t *testing.T, root string, pid int, cgroup string, fds ...string) {
+	dir := filepath.Join(root, fmt.Sprint(pid))
+	assert.Nil(t, os.MkdirAll(filepath.Join(dir, "fd"), 0o755))
+	for name, content := range map[string]string{
+		"cmdline": "python3\x00",
+		"stat":    fmt.Sprintf("%d (python3) S 1 1 1 0 -1 4194560 1 0 0 0 0 0 0 0 20 0 1 0 100 0 0", pid),
+		"status":  "Name:\tpython3\nUid:\t0\t0\t0\t0\n",
+		"cgroup":  "0::" + cgroup + "\n",
+	} {
+		assert.Nil(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+	}
+	for i, target := range fds {
+		assert.Nil(t, os.Symlink(target, filepath.Join(dir, "fd", fmt.Sprint(i+3))))
+	}
+}
+
+func TestOwners(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("device nodes are only available on linux")
+	}
+	// the memory devices stand in for the accel nodes, which the test
+	// cannot create without privileges
+	for _, path := range []string{"/dev/null", "/dev/zero", "/dev/full"} {
+		if fi, err := os.Stat(path); err != nil || fi.Mode()&os.ModeCharDevice == 0 {
+			t.Skipf("%s is not a character device", path)
+		}
+	}
+
+	root := t.TempDir()
+	// nothing else in the package tests overrides the proc root
+	t.Cleanup(func() { gohlml.SetProcRoot("") })
+	gohlml.SetProcRoot(root)
+	assert.Nil(t, os.WriteFile(filepath.Join(root, "stat"), []byte("btime 1700000000\n"), 0o644))
+	writeProc(t, root, 17, "/user.slice", "/dev/zero")
+	writeProc(t, root, 4242, "/system.slice/docker-"+containerID+".scope", "/dev/null", "/dev/full")
+
+	device := func(uuid string, nodes []gohlml.DeviceNode, err error) *mock.Device {
+		return &mock.Device{
+			UUIDContextFunc: func(context.Context) (string, error) { return uuid, nil },
+			DeviceNodesContextFunc: func(context.Context) ([]gohlml.DeviceNode, error) {
+				return nodes, err
+			},
+		}
+	}
+	dev0 := device("uuid-0", []gohlml.DeviceNode{
+		{Path: "/dev/accel/accel0", Major: 1, Minor: 3},
+		{Path: "/dev/accel/accel_controlD0", Major: 1, Minor: 5},
+	}, nil)
+	dev1 := device("uuid-1", []gohlml.DeviceNode{{Path: "/dev/accel/accel1", Major: 1, Minor: 7}}, nil)
+	broken := device("uuid-2", nil, gohlml.ErrNotFound)
+
+	owners, err := gohlml.Owners(context.Background(), []gohlml.DeviceInterface{dev0, broken, dev1})
+	assert.Nil(t, err, err)
+	if !assert.Len(t, owners, 3) {
+		return
+	}
+	if assert.Len(t, owners[0].Processes, 2) {
+		assert.Equal(t, "uuid-0", owners[0].UUID)
+		assert.Nil(t, owners[0].Err)
+		assert.Equal(t, 17, owners[0].Processes[0].PID)
+		assert.Equal(t, []string{"/dev/accel/accel_controlD0"}, owners[0].Processes[0].Nodes)
+		assert.Nil(t, owners[0].Processes[0].Container)
+		assert.Equal(t, 4242, owners[0].Processes[1].PID)
+		assert.Equal(t, []string{"/dev/accel/accel0"}, owners[0].Processes[1].Nodes)
+		assert.Equal(t, &gohlml.Container{Runtime: gohlml.RuntimeDocker, ID: containerID}, owners[0].Processes[1].Container)
+	}
+	assert.ErrorIs(t, owners[1].Err, gohlml.ErrNotFound, "A failing device should not fail the others")
+	assert.Empty(t, owners[1].Processes)
+	if assert.Len(t, owners[2].Processes, 1) {
+		assert.Equal(t, 4242, owners[2].Processes[0].PID)
+		assert.Equal(t, []string{"/dev/accel/accel1"}, owners[2].Processes[0].Nodes)
+	}
+	assert.Len(t, dev0.DeviceNodesContextCalls(), 1)
+}
@@ -0,0 +1,283 @@
+/*
+ * Copyright (c) 2022, HabanaLabs Ltd.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the Lic
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gohlml
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ProcRootEnv names the environment variable that sets the default procfs
+// root, e.g. /host/proc
+const ProcRootEnv = "HLML_PROC_ROOT"
+
+var procRoot = rootOverride{env: ProcRootEnv, def: "/proc"}
+
+// SetProcRoot sets where procfs is mounted. An empty root restores the
+// default, which is $HLML_PROC_ROOT if set and /proc otherwise
+func SetProcRoot(root string) {
+	procRoot.set(root)
+}
+
+// ProcRoot returns where the package expects procfs to be mounted
+func ProcRoot() string {
+	return procRoot.get()
+}
+
+// userHZ is the unit of the start time in /proc/PID/stat. The kernel
+// reports 100 ticks per second to user space on every supported
+// architecture
+const userHZ = 100
+
+// Process is a process holding a device node open
+type Process struct {
+	PID     int
+	Cmdline []string
+	UID     uint32
+	// User is the name of UID, empty if it cannot be resolved, e.g. for a
+	// process in a container
+	User      string
+	StartTime time.Time
+	// Cgroup is the cgroup v2 path of the process or, on a host with a
+	// cgroup v1 devices hierarchy, its path in that hierarchy
+	Cgroup string
+	// Nodes lists the paths, as returned by DeviceNodes, of the device
+	// nodes the process has open
+	Nodes []string
+}
+
+// Processes returns the processes holding the compute or control node of
+// the device open, ordered by PID. It scans the file descriptors of every
+// process, so without privileges it only finds the processes of the
+// current user. Processes exiting during the scan are left out
+func (d Device) Processes() ([]Process, error) {
+	return d.ProcessesContext(context.Background())
+}
+
+// ProcessesContext is like Processes but returns ctx.Err() if ctx is done
+// before the scan completes
+func (d Device) ProcessesContext(ctx context.Context) ([]Process, error) {
+	minor, err := d.MinorNumberContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return processes(ctx, minor)
+}
+
+// processes finds the processes holding the nodes of the device with the
+// given minor number
+func processes(ctx context.Context, minor uint) ([]Process, error) {
+	nodes, err := deviceNodes(minor)
+	if err != nil {
+		return nil, err
+	}
+	return scanProcesses(ctx, nodes)
+}
+
+// devNumber is the major and minor number of a device node
+type devNumber [2]uint32
+
+// scanProcesses finds the processes holding any of nodes open. Descriptors
+// are matched by device number rather than by path, since a process in a
+// container may see the nodes under other paths
+func scanProcesses(ctx context.Context, nodes []DeviceNode) ([]Process, error) {
+	paths := map[devNumber]string{}
+	for _, n := range nodes {
+		paths[devNumber{n.Major, n.Minor}] = n.Path
+	}
+
+	entries, err := os.ReadDir(ProcRoot())
+	if err != nil {
+		return nil, err
+	}
+	boot, err := bootTime()
+	if err != nil {
+		return nil, err
+	}
+
+	var procs []Process
+	for _, e := range entries {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		pid, err := strconv.Atoi(e.Name())
+		if err != nil {
+			continue
+		}
+		open := openNodes(pid, paths)
+		if len(open) == 0 {
+			continue
+		}
+		// the process may exit at any point, dropping it from the result
+		p, err := readProcess(pid, boot)
+		if err != nil {
+			continue
+		}
+		p.Nodes = open
+		procs = append(procs, p)
+	}
+	sort.Slice(procs, func(i, j int) bool { return procs[i].PID < procs[j].PID })
+	return procs, nil
+}
+
+// openNodes returns the paths of the nodes the process has descriptors open
+// on. Each descriptor is stat'ed through its /proc link, which resolves to
+// the opened file even where its path is not visible from here
+func openNodes(pid int, paths map[devNumber]string) []string {
+	dir := filepath.Join(ProcRoot(), strconv.Itoa(pid), "fd")
+	fds, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	seen := map[string]bool{}
+	var open []string
+	for _, fd := range fds {
+		n, err := statDeviceNode(filepath.Join(dir, fd.Name()))
+		if err != nil {
+			continue
+		}
+		path, ok := paths[devNumber{n.Major, n.Minor}]
+		if !ok || seen[path] {
+			continue
+		}
+		seen[path] = true
+		open = append(open, path)
+	}
+	sort.Strings(open)
+	return open
+}
+
+// bootTime reads the btime line of /proc/stat
+func bootTime() (time.Time, error) {
+	f, err := os.Open(filepath.Join(ProcRoot(), "stat"))
+	if err != nil {
+		return time.Time{}, err
+	}
+	defer f.Close()
+
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		if v, ok := strings.CutPrefix(s.Text(), "btime "); ok {
+			sec, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+			if err != nil {
+				return time.Time{}, fmt.Errorf("btime: %w", err)
+			}
+			return time.Unix(sec, 0), nil
+		}
+	}
+	if err := s.Err(); err != nil {
+		return time.Time{}, err
+	}
+	return time.Time{}, fmt.Errorf("btime: %w", ErrNotFound)
+}
+
+func readProcess(pid int, boot time.Time) (Process, error) {
+	dir := filepath.Join(ProcRoot(), strconv.Itoa(pid))
+	p := Process{PID: pid}
+
+	b, err := os.ReadFile(filepath.Join(dir, "cmdline"))
+	if err != nil {
+		return p, err
+	}
+	for _, arg := range bytes.Split(bytes.TrimRight(b, "\x00"), []byte{0}) {
+		p.Cmdline = append(p.Cmdline, string(arg))
+	}
+
+	// the command name may hold spaces and parentheses, so fields are
+	// counted from the last closing parenthesis. starttime is field 22
+	b, err = os.ReadFile(filepath.Join(dir, "stat"))
+	if err != nil {
+		return p, err
+	}
+	i := bytes.LastIndexByte(b, ')')
+	if i < 0 {
+		return p, fmt.Errorf("malformed stat of pid %d", pid)
+	}
+	stat := strings.Fields(string(b[i+1:]))
+	if len(stat) < 20 {
+		return p, fmt.Errorf("malformed stat of pid %d", pid)
+	}
+	ticks, err := strconv.ParseUint(stat[19], 10, 64)
+	if err != nil {
+		return p, err
+	}
+	p.StartTime = boot.Add(time.Duration(ticks) * time.Second / userHZ)
+
+	b, err = os.ReadFile(filepath.Join(dir, "status"))
+	if err != nil {
+		return p, err
+	}
+	for _, line := range strings.Split(string(b), "\n") {
+		if v, ok := strings.CutPrefix(line, "Uid:"); ok {
+			if f := strings.Fields(v); len(f) > 0 {
+				uid, err := strconv.ParseUint(f[0], 10, 32)
+				if err != nil {
+					return p, err
+				}
+				p.UID = uint32(uid)
+			}
+			break
+		}
+	}
+	if u, err := user.LookupId(strconv.FormatUint(uint64(p.UID), 10)); err == nil {
+		p.User = u.Username
+	}
+
+	b, err = os.ReadFile(filepath.Join(dir, "cgroup"))
+	if err != nil {
+		return p, err
+	}
+	p.Cgroup = parseCgroup(string(b))
+	return p, nil
+}
+
+// parseCgroup returns the devices hierarchy path from /proc/PID/cgroup, or
+// the unified hierarchy path if devices is not a cgroup v1 controller
+func parseCgroup(s string) string {
+	var unified, devices string
+	for _, line := range strings.Split(s, "\n") {
+		id, rest, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		controllers, path, ok := strings.Cut(rest, ":")
+		if !ok {
+			continue
+		}
+		if id == "0" && controllers == "" {
+			unified = path
+		}
+		for _, c := range strings.Split(controllers, ",") {
+			if c == "devices" {
+				devices = path
+			}
+		}
+	}
+	if devices != "" {
+		return devices
+	}
+	return unified
+}
@@ -0,0 +1,126 @@
+//go:build linux
+
+/*
+ * Copyright (c) 2022, HabanaLabs Ltd.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the Lic
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gohlml
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// writeFakeProc writes a process to a fake procfs, with a descriptor open
+// on each of the given paths. Files with an empty content are left out
+func writeFakeProc(t *testing.T, root string, pid int, files map[string]string, fds ...string) {
+	dir := filepath.Join(root, fmt.Sprint(pid))
+	assert.Nil(t, os.MkdirAll(filepath.Join(dir, "fd"), 0o755))
+	for name, content := range files {
+		if content != "" {
+			assert.Nil(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+		}
+	}
+	for i, target := range fds {
+		assert.Nil(t, os.Symlink(target, filepath.Join(dir, "fd", fmt.Sprint(i+3))))
+	}
+}
+
+// useProcRoot points the package at a fake procfs for the duration of the
+// test
+func useProcRoot(t *testing.T, root string) {
+	saved := procRoot.override.Load()
+	t.Cleanup(func() { procRoot.override.Store(saved) })
+	SetProcRoot(root)
+}
+
+func TestProcesses(t *testing.T) {
+	root := t.TempDir()
+	useProcRoot(t, root)
+
+	dev := t.TempDir()
+	assert.Nil(t, os.MkdirAll(filepath.Join(dev, "accel"), 0o755))
+	useDevRoot(t, dev)
+	useSysfsRoot(t, t.TempDir())
+
+	compute := filepath.Join(dev, "accel", "accel1")
+	control := filepath.Join(dev, "accel", "accel_controlD1")
+	mknod(t, compute, 261, 1)
+	mknod(t, control, 510, 1)
+	mknod(t, filepath.Join(dev, "accel", "accel0"), 261, 0)
+	// the nodes of device 1 as a container sees them, renumbered from 0
+	ctr := t.TempDir()
+	mknod(t, filepath.Join(ctr, "accel0"), 261, 1)
+	mknod(t, filepath.Join(ctr, "accel_controlD0"), 510, 1)
+	// a regular file named like a node of the device
+	fake := filepath.Join(t.TempDir(), "accel1")
+	assert.Nil(t, os.WriteFile(fake, nil, 0o644))
+
+	assert.Nil(t, os.WriteFile(filepath.Join(root, "stat"), []byte("cpu  1 2 3 4\nbtime 1700000000\nprocesses 42\n"), 0o644))
+	assert.Nil(t, os.MkdirAll(filepath.Join(root, "self"), 0o755))
+
+	files := func(cmdline, comm string, ticks, uid int, cgroup string) map[string]string {
+		return map[string]string{
+			"cmdline": cmdline,
+			"stat":    fmt.Sprintf("1 (%s) S 1 1 1 0 -1 4194560 1 0 0 0 0 0 0 0 20 0 1 0 %d 0 0", comm, ticks),
+			"status":  fmt.Sprintf("Name:\t%s\nUmask:\t0022\nUid:\t%d\t%d\t%d\t%d\n", comm, uid, uid, uid, uid),
+			"cgroup":  cgroup,
+		}
+	}
+	writeFakeProc(t, root, 4242, files("python3\x00train.py\x00--hpu\x00", "pt_main (thread)", 150, 0, "0::/kubepods.slice/pod1/cri-containerd-abc.scope\n"),
+		"/dev/null", filepath.Join(ctr, "accel0"), filepath.Join(ctr, "accel_controlD0"), filepath.Join(ctr, "accel0"))
+	writeFakeProc(t, root, 17, files("hl-smi\x00", "hl-smi", 200, 0, "0::/user.slice\n"),
+		control)
+	writeFakeProc(t, root, 99, files("other\x00", "other", 1, 0, "0::/\n"),
+		filepath.Join(dev, "accel", "accel0"), fake)
+	// exited between the descriptor scan and reading its status
+	exited := files("gone\x00", "gone", 1, 0, "0::/\n")
+	exited["status"] = ""
+	writeFakeProc(t, root, 500, exited, compute)
+
+	procs, err := processes(context.Background(), 1)
+	assert.Nil(t, err, err)
+	if assert.Len(t, procs, 2) {
+		assert.Equal(t, 17, procs[0].PID)
+		assert.Equal(t, []string{control}, procs[0].Nodes)
+		assert.Equal(t, "/user.slice", procs[0].Cgroup)
+
+		p := procs[1]
+		assert.Equal(t, 4242, p.PID)
+		assert.Equal(t, []string{"python3", "train.py", "--hpu"}, p.Cmdline)
+		assert.Equal(t, []string{compute, control}, p.Nodes, "Descriptors should match by device number")
+		assert.Equal(t, time.Unix(1700000001, 500e6), p.StartTime)
+		assert.Equal(t, uint32(0), p.UID)
+		assert.Equal(t, "/kubepods.slice/pod1/cri-containerd-abc.scope", p.Cgroup)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = processes(ctx, 1)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestParseCgroup(t *testing.T) {
+	assert.Equal(t, "/system.slice/docker-1.scope", parseCgroup("0::/system.slice/docker-1.scope\n"))
+	assert.Equal(t, "/kubepods/burstable/pod1/abc", parseCgroup(
+		"12:cpu,cpuacct:/kubepods/burstable/pod1/abc\n5:devices:/kubepods/burstable/pod1/abc\n0::/\n"))
+	assert.Equal(t, "", parseCgroup(""))
+}
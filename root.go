@@ -0,0 +1,50 @@
+/*
+ * Copyright (c) 2022, HabanaLabs Ltd.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the Lic
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gohlml
+
+import (
+	"os"
+	"sync/atomic"
+)
+
+// rootOverride is where a filesystem the package reads is mounted. It is
+// the override if one is set, else the value of the environment variable
+// env if set, else def
+type rootOverride struct {
+	override atomic.Pointer[string]
+	env      string
+	def      string
+}
+
+// set overrides the root, or drops the override if root is empty
+func (r *rootOverride) set(root string) {
+	if root == "" {
+		r.override.Store(nil)
+		return
+	}
+	r.override.Store(&root)
+}
+
+func (r *rootOverride) get() string {
+	if root := r.override.Load(); root != nil {
+		return *root
+	}
+	if root := os.Getenv(r.env); root != "" {
+		return root
+	}
+	return r.def
+}
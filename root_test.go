@@ -0,0 +1,38 @@
+/*
+ * Copyright (c) 2022, HabanaLabs Ltd.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the Lic
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gohlml
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRootOverride(t *testing.T) {
+	r := rootOverride{env: "HLML_TEST_ROOT", def: "/sys"}
+	t.Setenv("HLML_TEST_ROOT", "")
+	assert.Equal(t, "/sys", r.get())
+
+	t.Setenv("HLML_TEST_ROOT", "/host/sys")
+	assert.Equal(t, "/host/sys", r.get(), "The environment should set the default")
+
+	r.set("/tmp/sys")
+	assert.Equal(t, "/tmp/sys", r.get(), "An override should take precedence")
+
+	r.set("")
+	assert.Equal(t, "/host/sys", r.get(), "An empty root should restore the default")
+}
@@ -0,0 +1,173 @@
+/*
+ * Copyright (c) 2022, HabanaLabs Ltd.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the Lic
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gohlml
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Snapshot holds the dynamic metrics of a device read in one pass
+type Snapshot struct {
+	// Time is when the snapshot was started
+	Time  time.Time
+	UUID  string
+	BusID string
+
+	MemoryTotal uint64
+	MemoryUsed  uint64
+	MemoryFree  uint64
+	Utilization uint
+	SOCClock    uint
+
+	PowerUsage        uint
+	EnergyConsumption uint64
+
+	TemperatureOnBoard   uint
+	TemperatureOnChip    uint
+	ClockThrottleReasons uint64
+
+	PCIeTX             uint
+	PCIeRX             uint
+	PCIReplayCounter   uint
+	PCIeLinkGeneration uint
+	PCIeLinkWidth      uint
+
+	ReplacedRowsSingleBitECC uint
+	ReplacedRowsDoubleBitECC uint
+	ReplacedRowsPending      int
+
+	// Errors maps the fields that could not be read to the error the query
+	// returned. It is nil when every field was read
+	Errors map[string]error
+}
+
+func (s *Snapshot) fail(name string, err error) {
+	if s.Errors == nil {
+		s.Errors = map[string]error{}
+	}
+	s.Errors[name] = err
+}
+
+// snapshotField reads one Snapshot field through fn, recording its error
+func snapshotField[T any](ctx context.Context, s *Snapshot, name string, fn func(context.Context) (T, error), v *T) {
+	x, err := fn(ctx)
+	if err != nil {
+		s.fail(name, err)
+		return
+	}
+	*v = x
+}
+
+// snapshot reads every Snapshot field of dev
+func snapshot(ctx context.Context, dev DeviceInterface) Snapshot {
+	s := Snapshot{Time: time.Now()}
+
+	snapshotField(ctx, &s, "UUID", dev.UUIDContext, &s.UUID)
+	snapshotField(ctx, &s, "BusID", dev.PCIBusIDContext, &s.BusID)
+
+	if total, used, free, err := dev.MemoryInfoContext(ctx); err != nil {
+		s.fail("Memory", err)
+	} else {
+		s.MemoryTotal, s.MemoryUsed, s.MemoryFree = total, used, free
+	}
+	snapshotField(ctx, &s, "Utilization", dev.UtilizationInfoContext, &s.Utilization)
+	snapshotField(ctx, &s, "SOCClock", dev.SOCClockInfoContext, &s.SOCClock)
+
+	snapshotField(ctx, &s, "PowerUsage", dev.PowerUsageContext, &s.PowerUsage)
+	snapshotField(ctx, &s, "EnergyConsumption", dev.EnergyConsumptionCounterContext, &s.EnergyConsumption)
+
+	snapshotField(ctx, &s, "TemperatureOnBoard", dev.TemperatureOnBoardContext, &s.TemperatureOnBoard)
+	snapshotField(ctx, &s, "TemperatureOnChip", dev.TemperatureOnChipContext, &s.TemperatureOnChip)
+	snapshotField(ctx, &s, "ClockThrottleReasons", dev.ClockThrottleReasonsContext, &s.ClockThrottleReasons)
+
+	snapshotField(ctx, &s, "PCIeTX", dev.PCIeTXContext, &s.PCIeTX)
+	snapshotField(ctx, &s, "PCIeRX", dev.PCIeRXContext, &s.PCIeRX)
+	snapshotField(ctx, &s, "PCIReplayCounter", dev.PCIReplayCounterContext, &s.PCIReplayCounter)
+	snapshotField(ctx, &s, "PCIeLinkGeneration", dev.PCIeLinkGenerationContext, &s.PCIeLinkGeneration)
+	snapshotField(ctx, &s, "PCIeLinkWidth", dev.PCIeLinkWidthContext, &s.PCIeLinkWidth)
+
+	snapshotField(ctx, &s, "ReplacedRowsSingleBitECC", dev.ReplacedRowSingleBitECCContext, &s.ReplacedRowsSingleBitECC)
+	snapshotField(ctx, &s, "ReplacedRowsDoubleBitECC", dev.ReplacedRowDoubleBitECCContext, &s.ReplacedRowsDoubleBitECC)
+	snapshotField(ctx, &s, "ReplacedRowsPending", dev.IsReplacedRowsPendingStatusContext, &s.ReplacedRowsPending)
+
+	return s
+}
+
+// Snapshot reads the dynamic metrics of the device in one pass
+func (d Device) Snapshot() Snapshot {
+	return d.SnapshotContext(context.Background())
+}
+
+// SnapshotContext is like Snapshot but fields not read before ctx is done
+// fail with ctx.Err()
+func (d Device) SnapshotContext(ctx context.Context) Snapshot {
+	return snapshot(ctx, d)
+}
+
+// SnapshotAll takes a Snapshot of every device, in index order, with at
+// most parallelism devices in flight. Since HLML calls run one at a time
+// on the worker, parallelism mostly bounds how many devices wait behind a
+// slow call. A device whose handle cannot be obtained reports the error
+// under DeviceHandle in its Errors
+func SnapshotAll(ctx context.Context, parallelism int) ([]Snapshot, error) {
+	return snapshotAll(ctx, New(), parallelism)
+}
+
+func snapshotAll(ctx context.Context, lib Interface, parallelism int) ([]Snapshot, error) {
+	count, err := lib.DeviceCountContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if parallelism < 1 {
+		parallelism = 1
+	}
+
+	snapshots := make([]Snapshot, count)
+	sem := make(chan struct{}, parallelism)
+	var wg sync.WaitGroup
+	for i := uint(0); i < count; i++ {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+			return nil, ctx.Err()
+		}
+		wg.Add(1)
+		go func(i uint) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			dev, err := lib.DeviceHandleByIndexContext(ctx, i)
+			if err != nil {
+				snapshots[i] = Snapshot{Time: time.Now()}
+				snapshots[i].fail("DeviceHandle", err)
+				return
+			}
+			snapshots[i] = dev.SnapshotContext(ctx)
+		}(i)
+	}
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return snapshots, nil
+}
@@ -0,0 +1,57 @@
+//go:build linux && cgo && fakehlml
+
+/*
+ * Copyright (c) 2022, HabanaLabs Ltd.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the Lic
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package gohlml
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeSnapshotAll(t *testing.T) {
+	err := Initialize()
+	assert.Nil(t, err, err)
+
+	snapshots, err := SnapshotAll(context.Background(), 2)
+	assert.Nil(t, err, err)
+	if assert.Len(t, snapshots, 2) {
+		s := snapshots[0]
+		assert.Nil(t, s.Errors)
+		assert.Equal(t, "0000:19:00.0", s.BusID)
+		assert.Equal(t, uint64(34359738368), s.MemoryTotal)
+		assert.Equal(t, uint(98000), s.PowerUsage)
+		assert.Equal(t, uint64(8437021), s.EnergyConsumption)
+		assert.Equal(t, uint(1024), s.PCIeTX)
+
+		s = snapshots[1]
+		assert.ErrorIs(t, s.Errors["PowerUsage"], ErrAipIsLost)
+		assert.ErrorIs(t, s.Errors["TemperatureOnChip"], ErrAipIsLost)
+		assert.NotContains(t, s.Errors, "PCIeLinkGeneration", "The slow call should still complete")
+		assert.Equal(t, "0000:1a:00.0", s.BusID)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err = SnapshotAll(ctx, 1)
+	assert.ErrorIs(t, err, context.DeadlineExceeded, "The slow call on device 1 should exceed the deadline")
+
+	err = Shutdown()
+	assert.Nil(t, err, err)
+}
@@ -0,0 +1,125 @@
+/*
+ * Copyright (c) 2022, HabanaLabs Ltd.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the Lic
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gohlml
+
+import (
+	"sync"
+	"time"
+)
+
+// latencyBuckets are the upper bounds of the CallStats latency histogram.
+// They are fixed so that every histogram has the same length
+var latencyBuckets = [...]time.Duration{
+	100 * time.Microsecond,
+	time.Millisecond,
+	10 * time.Millisecond,
+	100 * time.Millisecond,
+	time.Second,
+	10 * time.Second,
+}
+
+// LatencyBuckets returns the upper bounds of the CallStats latency histogram
+func LatencyBuckets() []time.Duration {
+	return append([]time.Duration(nil), latencyBuckets[:]...)
+}
+
+// CallStats are the metrics recorded for one HLML function
+type CallStats struct {
+	// Calls counts the completed calls
+	Calls uint64
+	// Errors counts the failed calls by return code
+	Errors map[Return]uint64
+	// Latency counts the calls by duration. Latency[i] counts the calls
+	// that took at most LatencyBuckets()[i] and longer than the bucket
+	// before it. The last entry counts the calls slower than every bucket
+	Latency []uint64
+	// Total is the time spent in all calls
+	Total time.Duration
+	// Max is the duration of the slowest call
+	Max time.Duration
+}
+
+var stats struct {
+	sync.Mutex
+	ops map[string]*CallStats
+}
+
+// Stats returns the metrics recorded for every HLML function called so far,
+// keyed by the C function name, e.g. hlml_device_get_power_usage. Calls are
+// recorded when they complete, including the ones their caller gave up on.
+//
+// Go methods that wrap the same C function share its entry. PCIDomain,
+// PCIBus, PCIBusID, PCIID, PCILinkSpeed and PCILinkWidth all count as
+// hlml_device_get_pci_info, TemperatureOnChip and TemperatureOnBoard as
+// hlml_device_get_temperature, and the TemperatureThreshold getters as
+// hlml_device_get_temperature_threshold
+func Stats() map[string]CallStats {
+	stats.Lock()
+	defer stats.Unlock()
+
+	out := make(map[string]CallStats, len(stats.ops))
+	for op, s := range stats.ops {
+		c := *s
+		c.Errors = make(map[Return]uint64, len(s.Errors))
+		for rc, n := range s.Errors {
+			c.Errors[rc] = n
+		}
+		c.Latency = append([]uint64(nil), s.Latency...)
+		out[op] = c
+	}
+	return out
+}
+
+// ResetStats discards the recorded metrics
+func ResetStats() {
+	stats.Lock()
+	defer stats.Unlock()
+
+	stats.ops = nil
+}
+
+// recordCall adds a completed call of op to the metrics
+func recordCall(op string, rc Return, latency time.Duration) {
+	stats.Lock()
+	defer stats.Unlock()
+
+	if stats.ops == nil {
+		stats.ops = map[string]*CallStats{}
+	}
+	s, ok := stats.ops[op]
+	if !ok {
+		s = &CallStats{Errors: map[Return]uint64{}, Latency: make([]uint64, len(latencyBuckets)+1)}
+		stats.ops[op] = s
+	}
+
+	s.Calls++
+	if rc != ReturnSuccess {
+		s.Errors[rc]++
+	}
+	bucket := len(latencyBuckets)
+	for i, bound := range latencyBuckets {
+		if latency <= bound {
+			bucket = i
+			break
+		}
+	}
+	s.Latency[bucket]++
+	s.Total += latency
+	if latency > s.Max {
+		s.Max = latency
+	}
+}
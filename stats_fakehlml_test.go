@@ -0,0 +1,50 @@
+//go:build linux && cgo && fakehlml
+
+/*
+ * Copyright (c) 2022, HabanaLabs Ltd.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the Lic
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package gohlml
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeStats(t *testing.T) {
+	ResetStats()
+
+	err := Initialize()
+	assert.Nil(t, err, err)
+
+	dev, err := DeviceHandleByIndex(1)
+	assert.Nil(t, err, err)
+
+	_, err = dev.PowerUsage()
+	assert.ErrorIs(t, err, ErrAipIsLost)
+	_, err = dev.PCIeLinkGeneration()
+	assert.Nil(t, err, err)
+
+	s := Stats()
+	assert.Equal(t, uint64(1), s["hlml_device_get_power_usage"].Calls)
+	assert.Equal(t, uint64(1), s["hlml_device_get_power_usage"].Errors[ReturnAipIsLost])
+	assert.GreaterOrEqual(t, s["hlml_device_get_curr_pcie_link_generation"].Max, 300*time.Millisecond,
+		"The fixture delays the call by 300ms")
+	assert.Equal(t, uint64(1), s["hlml_init"].Calls)
+
+	err = Shutdown()
+	assert.Nil(t, err, err)
+}
@@ -0,0 +1,60 @@
+/*
+ * Copyright (c) 2022, HabanaLabs Ltd.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the Lic
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package gohlml
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStats(t *testing.T) {
+	ResetStats()
+	defer ResetStats()
+
+	recordCall("hlml_device_get_power_usage", ReturnSuccess, 50*time.Microsecond)
+	recordCall("hlml_device_get_power_usage", ReturnAipIsLost, 2*time.Millisecond)
+	recordCall("hlml_device_get_power_usage", ReturnAipIsLost, time.Minute)
+
+	s := Stats()["hlml_device_get_power_usage"]
+	assert.Equal(t, uint64(3), s.Calls)
+	assert.Equal(t, map[Return]uint64{ReturnAipIsLost: 2}, s.Errors)
+	assert.Equal(t, []uint64{1, 0, 1, 0, 0, 0, 1}, s.Latency)
+	assert.Len(t, s.Latency, len(LatencyBuckets())+1)
+	assert.Equal(t, time.Minute, s.Max)
+	assert.Equal(t, time.Minute+2*time.Millisecond+50*time.Microsecond, s.Total)
+
+	s.Errors[ReturnSuccess] = 1
+	assert.NotContains(t, Stats()["hlml_device_get_power_usage"].Errors, ReturnSuccess,
+		"Stats should return a copy")
+
+	ResetStats()
+	assert.Empty(t, Stats())
+}
+
+func TestLatencyBuckets(t *testing.T) {
+	ResetStats()
+	defer ResetStats()
+
+	buckets := LatencyBuckets()
+	buckets[0] = time.Hour
+	_ = append(buckets, time.Hour)
+	assert.Equal(t, 100*time.Microsecond, LatencyBuckets()[0], "LatencyBuckets should return a copy")
+
+	recordCall("hlml_device_get_power_usage", ReturnSuccess, time.Hour)
+	assert.Equal(t, []uint64{0, 0, 0, 0, 0, 0, 1}, Stats()["hlml_device_get_power_usage"].Latency)
+}
@@ -0,0 +1,132 @@
+/*
+ * Copyright (c) 2022, HabanaLabs Ltd.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the Lic
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gohlml
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// SysfsRootEnv names the environment variable that sets the default sysfs
+// root, e.g. /host/sys where a container mounts the host sysfs
+const SysfsRootEnv = "HLML_SYSFS_ROOT"
+
+var sysfsRoot = rootOverride{env: SysfsRootEnv, def: "/sys"}
+
+// SetSysfsRoot sets where sysfs is mounted for every sysfs reader of the
+// package. An empty root restores the default, which is $HLML_SYSFS_ROOT if
+// set and /sys otherwise
+func SetSysfsRoot(root string) {
+	sysfsRoot.set(root)
+}
+
+// SysfsRoot returns where the package expects sysfs to be mounted
+func SysfsRoot() string {
+	return sysfsRoot.get()
+}
+
+// sysfsPath joins elem to the sysfs root
+func sysfsPath(elem ...string) string {
+	return filepath.Join(append([]string{SysfsRoot()}, elem...)...)
+}
+
+// NumaNode returns the Numa affinity of the device or nil is no affinity.
+func (d Device) NumaNode() (*uint, error) {
+	return d.NumaNodeContext(context.Background())
+}
+
+// NumaNodeContext is like NumaNode but returns ctx.Err() if ctx is done before
+// the PCI bus id is resolved
+func (d Device) NumaNodeContext(ctx context.Context) (*uint, error) {
+	busID, err := d.PCIBusIDContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return NumaNodeByBusID(busID)
+}
+
+// NumaNodeByBusID returns the Numa affinity of the PCI device with the given
+// bus id, e.g. 0000:19:00.0, or nil if it has no affinity. It reads sysfs
+// only and works without libhlml
+func NumaNodeByBusID(busID string) (*uint, error) {
+	b, err := os.ReadFile(sysfsPath("bus", "pci", "devices", strings.ToLower(busID), "numa_node"))
+	if err != nil {
+		// report nil if NUMA support isn't enabled
+		return nil, nil
+	}
+	node, err := strconv.ParseInt(string(bytes.TrimSpace(b)), 10, 8)
+	if err != nil {
+		return nil, fmt.Errorf("%v: %v", errors.New("failed to retrieve CPU affinity"), err)
+	}
+	if node < 0 {
+		return nil, nil
+	}
+
+	numaNode := uint(node)
+	return &numaNode, nil
+}
+
+// FWVersion returns the firmware version for a given device
+func FWVersion(idx uint) (kernel string, uboot string, err error) {
+	b, err := os.ReadFile(sysfsPath("class", "accel", fmt.Sprintf("accel%d", idx), "device", "armcp_kernel_ver"))
+	if errors.Is(err, os.ErrNotExist) {
+		// newer drivers renamed the attribute
+		b, err = os.ReadFile(sysfsPath("class", "accel", fmt.Sprintf("accel%d", idx), "device", "cpucp_kernel_ver"))
+	}
+	if err != nil {
+		return "", "", fmt.Errorf("file reading error %s", err)
+	}
+	kernel = string(b)
+
+	b, err = os.ReadFile(sysfsPath("class", "accel", fmt.Sprintf("accel%d", idx), "device", "uboot_ver"))
+	if err != nil {
+		return "", "", fmt.Errorf("file reading error %s", err)
+	}
+	uboot = string(b)
+
+	return kernel, uboot, nil
+}
+
+// SystemDriverVersion returns the driver version on the system
+func SystemDriverVersion() (string, error) {
+	driver, err := os.ReadFile(sysfsPath("module", "habanalabs", "version"))
+	if err != nil {
+		return "", fmt.Errorf("file reading error %s", err)
+	}
+	return string(driver), nil
+}
+
+// GetDeviceTypeName returns the family of the first Habana device Discover
+// finds, e.g. gaudi
+func GetDeviceTypeName() (string, error) {
+	devices, err := Discover()
+	if err != nil {
+		return "", err
+	}
+	for _, d := range devices {
+		if d.Family != "" {
+			return d.Family, nil
+		}
+	}
+	return "", errors.New("no habana devices on the system")
+}
@@ -0,0 +1,76 @@
+/*
+ * Copyright (c) 2022, HabanaLabs Ltd.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the Lic
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package gohlml
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// useSysfsRoot sets the sysfs root for the duration of the test, then
+// restores the override, or its absence, in place before
+func useSysfsRoot(t *testing.T, root string) {
+	saved := sysfsRoot.override.Load()
+	t.Cleanup(func() { sysfsRoot.override.Store(saved) })
+	SetSysfsRoot(root)
+}
+
+func TestGetDeviceTypeName(t *testing.T) {
+	root := t.TempDir()
+	devices := filepath.Join(root, "bus", "pci", "devices")
+	assert.Nil(t, os.MkdirAll(devices, 0o755))
+
+	for addr, ids := range map[string][2]string{
+		"0000:00:01.0": {"0x8086", "0x1234"},
+		"0000:33:00.0": {"0x1da3", "0x1020"},
+	} {
+		dir := filepath.Join(root, "devices", addr)
+		assert.Nil(t, os.MkdirAll(dir, 0o755))
+		assert.Nil(t, os.WriteFile(filepath.Join(dir, "vendor"), []byte(ids[0]+"\n"), 0o644))
+		assert.Nil(t, os.WriteFile(filepath.Join(dir, "device"), []byte(ids[1]+"\n"), 0o644))
+		assert.Nil(t, os.Symlink(dir, filepath.Join(devices, addr)))
+	}
+
+	useSysfsRoot(t, root)
+
+	name, err := GetDeviceTypeName()
+	assert.Nil(t, err, err)
+	assert.Equal(t, "gaudi", name)
+}
+
+func TestSysfsRoot(t *testing.T) {
+	useSysfsRoot(t, "")
+	t.Setenv(SysfsRootEnv, "")
+	assert.Equal(t, "/sys", SysfsRoot())
+
+	t.Setenv(SysfsRootEnv, "/host/sys")
+	assert.Equal(t, "/host/sys", SysfsRoot())
+	assert.Equal(t, "/host/sys/module/habanalabs/version", sysfsPath("module", "habanalabs", "version"))
+
+	SetSysfsRoot("/fixture")
+	assert.Equal(t, "/fixture", SysfsRoot(), "SetSysfsRoot should override the environment")
+
+	root := t.TempDir()
+	assert.Nil(t, os.MkdirAll(filepath.Join(root, "module", "habanalabs"), 0o755))
+	assert.Nil(t, os.WriteFile(filepath.Join(root, "module", "habanalabs", "version"), []byte("1.17.0"), 0o644))
+	SetSysfsRoot(root)
+	ver, err := SystemDriverVersion()
+	assert.Nil(t, err, err)
+	assert.Equal(t, "1.17.0", ver)
+}
@@ -0,0 +1,40 @@
+/*
+ * Copyright (c) 2022, HabanaLabs Ltd.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the Lic
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gohlml
+
+const (
+	// HLDriverPath indicates on habana device dir under the default sysfs
+	// root. The sysfs readers of the package honor SysfsRoot instead
+	HLDriverPath = "/sys/class/accel"
+	// HLModulePath indicates on habana module dir under the default sysfs
+	// root. The sysfs readers of the package honor SysfsRoot instead
+	HLModulePath = "/sys/module/habanalabs"
+	// BITSPerLong repsenets 64 bits in logs
+	BITSPerLong = 64
+)
+
+// Event contains uuid and event type
+type Event struct {
+	Serial string
+	Etype  uint64
+}
+
+// PCIInfo contains the PCI properties of the device
+type PCIInfo struct {
+	BusID    string
+	DeviceID uint
+}
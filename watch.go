@@ -0,0 +1,202 @@
+/*
+ * Copyright (c) 2022, HabanaLabs Ltd.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the Lic
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gohlml
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"time"
+)
+
+// HotplugEventType is the kind of change reported by WatchDevices
+type HotplugEventType int
+
+const (
+	// DeviceAdded reports a new accel device
+	DeviceAdded HotplugEventType = iota + 1
+	// DeviceRemoved reports an accel device that went away
+	DeviceRemoved
+	// DriverLoaded reports the habanalabs module was loaded
+	DriverLoaded
+	// DriverUnloaded reports the habanalabs module was unloaded
+	DriverUnloaded
+)
+
+var hotplugEventNames = map[HotplugEventType]string{
+	DeviceAdded:    "DeviceAdded",
+	DeviceRemoved:  "DeviceRemoved",
+	DriverLoaded:   "DriverLoaded",
+	DriverUnloaded: "DriverUnloaded",
+}
+
+func (t HotplugEventType) String() string {
+	if name, ok := hotplugEventNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("HotplugEventType(%d)", int(t))
+}
+
+// HotplugEvent is a device or driver change reported by WatchDevices
+type HotplugEvent struct {
+	Type HotplugEventType
+	// Device is the accel class entry, e.g. accel0, empty for driver events
+	Device string
+	// BusID is the PCI bus id of Device, if it could be resolved
+	BusID string
+}
+
+// WatcherConfig configures WatchDevices
+type WatcherConfig struct {
+	// SysfsRoot is where sysfs is mounted, SysfsRoot() if empty
+	SysfsRoot string
+	// PollInterval is how often sysfs is scanned, 5 seconds if zero. Kernel
+	// uevents trigger a scan as soon as they arrive
+	PollInterval time.Duration
+	// NoUevents disables listening to kernel uevents
+	NoUevents bool
+}
+
+var accelDevice = regexp.MustCompile(`^accel[0-9]+$`)
+
+// WatchDevices reports accel devices appearing and disappearing under
+// class/accel, and the habanalabs module being loaded or unloaded, until
+// ctx is done. Devices and driver present when it is called are not
+// reported. The returned channel is closed when the watcher stops
+func WatchDevices(ctx context.Context, cfg WatcherConfig) (<-chan HotplugEvent, error) {
+	if cfg.SysfsRoot == "" {
+		cfg.SysfsRoot = SysfsRoot()
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = 5 * time.Second
+	}
+	if _, err := os.Stat(cfg.SysfsRoot); err != nil {
+		return nil, err
+	}
+
+	w := &watcher{root: cfg.SysfsRoot, devices: map[string]string{}}
+	w.scan(ctx, nil)
+
+	trigger := make(chan struct{}, 1)
+	if !cfg.NoUevents {
+		// without uevents, e.g. in a container, polling still catches up
+		if stop, err := listenUevents(trigger); err == nil {
+			go func() {
+				<-ctx.Done()
+				stop()
+			}()
+		}
+	}
+
+	out := make(chan HotplugEvent)
+	go w.run(ctx, cfg.PollInterval, trigger, out)
+	return out, nil
+}
+
+type watcher struct {
+	root    string
+	driver  bool
+	devices map[string]string
+}
+
+func (w *watcher) run(ctx context.Context, interval time.Duration, trigger <-chan struct{}, out chan<- HotplugEvent) {
+	defer close(out)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-trigger:
+		}
+		if !w.scan(ctx, out) {
+			return
+		}
+	}
+}
+
+// scan compares sysfs to the previous scan and sends the differences to
+// out, unless out is nil. It returns false if ctx is done
+func (w *watcher) scan(ctx context.Context, out chan<- HotplugEvent) bool {
+	var events []HotplugEvent
+
+	_, err := os.Stat(filepath.Join(w.root, "module", "habanalabs"))
+	driver := err == nil
+	if driver && !w.driver {
+		events = append(events, HotplugEvent{Type: DriverLoaded})
+	}
+
+	devices := map[string]string{}
+	entries, _ := os.ReadDir(filepath.Join(w.root, "class", "accel"))
+	for _, e := range entries {
+		if accelDevice.MatchString(e.Name()) {
+			devices[e.Name()] = w.busID(e.Name())
+		}
+	}
+
+	var added, removed []string
+	for name := range devices {
+		if _, ok := w.devices[name]; !ok {
+			added = append(added, name)
+		}
+	}
+	for name := range w.devices {
+		if _, ok := devices[name]; !ok {
+			removed = append(removed, name)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+	for _, name := range removed {
+		events = append(events, HotplugEvent{Type: DeviceRemoved, Device: name, BusID: w.devices[name]})
+	}
+	for _, name := range added {
+		events = append(events, HotplugEvent{Type: DeviceAdded, Device: name, BusID: devices[name]})
+	}
+
+	if !driver && w.driver {
+		events = append(events, HotplugEvent{Type: DriverUnloaded})
+	}
+	w.driver, w.devices = driver, devices
+
+	if out == nil {
+		return true
+	}
+	for _, e := range events {
+		select {
+		case out <- e:
+		case <-ctx.Done():
+			return false
+		}
+	}
+	return true
+}
+
+// busID resolves the PCI bus id of an accel device from its device link
+func (w *watcher) busID(name string) string {
+	target, err := filepath.EvalSymlinks(filepath.Join(w.root, "class", "accel", name, "device"))
+	if err != nil {
+		return ""
+	}
+	return filepath.Base(target)
+}
@@ -0,0 +1,74 @@
+//go:build linux
+
+/*
+ * Copyright (c) 2022, HabanaLabs Ltd.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the Lic
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gohlml
+
+import (
+	"bytes"
+	"os"
+	"syscall"
+)
+
+// listenUevents signals trigger whenever the kernel reports an accel device
+// or habanalabs module uevent. The returned function stops listening
+func listenUevents(trigger chan<- struct{}) (func(), error) {
+	fd, err := syscall.Socket(syscall.AF_NETLINK, syscall.SOCK_DGRAM|syscall.SOCK_CLOEXEC|syscall.SOCK_NONBLOCK,
+		syscall.NETLINK_KOBJECT_UEVENT)
+	if err != nil {
+		return nil, err
+	}
+	// group 1 carries the kernel uevents
+	if err := syscall.Bind(fd, &syscall.SockaddrNetlink{Family: syscall.AF_NETLINK, Groups: 1}); err != nil {
+		syscall.Close(fd)
+		return nil, err
+	}
+
+	// a non-blocking socket is served by the runtime poller, so Close
+	// interrupts a pending Read
+	f := os.NewFile(uintptr(fd), "uevent")
+	go func() {
+		buf := make([]byte, 16<<10)
+		for {
+			n, err := f.Read(buf)
+			if err != nil {
+				return
+			}
+			if !relevantUevent(buf[:n]) {
+				continue
+			}
+			select {
+			case trigger <- struct{}{}:
+			default:
+			}
+		}
+	}()
+	return func() { f.Close() }, nil
+}
+
+// relevantUevent reports whether msg, a NUL separated list of KEY=value
+// pairs after an action@devpath header, concerns the watched entries
+func relevantUevent(msg []byte) bool {
+	for _, field := range bytes.Split(msg, []byte{0}) {
+		switch {
+		case bytes.Equal(field, []byte("SUBSYSTEM=accel")),
+			bytes.Equal(field, []byte("DEVPATH=/module/habanalabs")):
+			return true
+		}
+	}
+	return false
+}
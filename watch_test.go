@@ -0,0 +1,73 @@
+/*
+ * Copyright (c) 2022, HabanaLabs Ltd.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the Lic
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package gohlml
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatchDevices(t *testing.T) {
+	root := t.TempDir()
+	accel := filepath.Join(root, "class", "accel")
+	module := filepath.Join(root, "module", "habanalabs")
+	pci := filepath.Join(root, "devices", "pci0000:00", "0000:19:00.0")
+	for _, dir := range []string{accel, pci, filepath.Join(accel, "accel_controlD0")} {
+		assert.Nil(t, os.MkdirAll(dir, 0755))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := WatchDevices(ctx, WatcherConfig{SysfsRoot: root, PollInterval: 10 * time.Millisecond})
+	assert.Nil(t, err, err)
+
+	next := func() HotplugEvent {
+		select {
+		case e := <-events:
+			return e
+		case <-time.After(5 * time.Second):
+			t.Fatal("no hotplug event")
+			return HotplugEvent{}
+		}
+	}
+
+	assert.Nil(t, os.MkdirAll(module, 0755))
+	assert.Equal(t, HotplugEvent{Type: DriverLoaded}, next())
+
+	assert.Nil(t, os.Mkdir(filepath.Join(accel, "accel0"), 0755))
+	assert.Nil(t, os.Symlink(pci, filepath.Join(accel, "accel0", "device")))
+	e := next()
+	assert.Equal(t, DeviceAdded, e.Type)
+	assert.Equal(t, "accel0", e.Device)
+
+	assert.Nil(t, os.RemoveAll(filepath.Join(accel, "accel0")))
+	assert.Nil(t, os.RemoveAll(module))
+	assert.Equal(t, DeviceRemoved, next().Type)
+	assert.Equal(t, HotplugEvent{Type: DriverUnloaded}, next())
+
+	cancel()
+	for range events {
+	}
+
+	_, err = WatchDevices(context.Background(), WatcherConfig{SysfsRoot: filepath.Join(root, "missing")})
+	assert.NotNil(t, err, "A missing sysfs root should fail")
+}
@@ -0,0 +1,292 @@
+/*
+ * Copyright (c) 2022, HabanaLabs Ltd.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the Lic
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gohlml
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Sample is a field value read by the Watches sampler
+type Sample struct {
+	Time  time.Time
+	Value any
+	Err   error
+}
+
+// Watches samples watched fields in the background and keeps their recent
+// values, so that any number of readers share the same HLML calls. A field
+// of a device watched by several groups is sampled at the shortest of
+// their intervals and kept for the longest of their keep ages
+type Watches struct {
+	mu      sync.Mutex
+	entries map[watchKey]*watchEntry
+	wake    chan struct{}
+}
+
+// watchKey identifies a field of a device by the device UUID, which unlike
+// a DeviceInterface value is always comparable
+type watchKey struct {
+	uuid string
+	id   string
+}
+
+type watchEntry struct {
+	// dev is the device the field is sampled from, the first registered
+	// for its UUID
+	dev     DeviceInterface
+	field   field
+	groups  map[*WatchGroup]struct{}
+	next    time.Time
+	history []Sample
+}
+
+// WatchGroup is a set of devices and fields watched at a common interval
+type WatchGroup struct {
+	w        *Watches
+	uuids    []string
+	ids      []string
+	interval time.Duration
+	keep     time.Duration
+}
+
+// NewWatches returns a Watches without any group. Run samples the fields
+func NewWatches() *Watches {
+	return &Watches{entries: map[watchKey]*watchEntry{}, wake: make(chan struct{}, 1)}
+}
+
+// Watch registers the fields with the given IDs of every device, to be
+// sampled every interval and kept for keep. Devices are identified by their
+// UUID, which readers pass to Latest and History, so groups of different
+// DeviceInterface values of a device share their samples
+func (w *Watches) Watch(devices []DeviceInterface, ids []string, interval, keep time.Duration) (*WatchGroup, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("watch interval %v: %w", interval, ErrInvalidArgument)
+	}
+	selected := make([]field, len(ids))
+	for i, id := range ids {
+		f, ok := fields[id]
+		if !ok {
+			return nil, fmt.Errorf("field %q: %w", id, ErrNotFound)
+		}
+		selected[i] = f
+	}
+	uuids := make([]string, len(devices))
+	for i, dev := range devices {
+		uuid, err := dev.UUID()
+		if err != nil {
+			return nil, fmt.Errorf("uuid of device %d: %w", i, err)
+		}
+		uuids[i] = uuid
+	}
+
+	g := &WatchGroup{
+		w:        w,
+		uuids:    uuids,
+		ids:      append([]string(nil), ids...),
+		interval: interval,
+		keep:     keep,
+	}
+
+	w.mu.Lock()
+	for d, dev := range devices {
+		for i, id := range ids {
+			key := watchKey{uuids[d], id}
+			e, ok := w.entries[key]
+			if !ok {
+				e = &watchEntry{dev: dev, field: selected[i], groups: map[*WatchGroup]struct{}{}}
+				w.entries[key] = e
+			}
+			e.groups[g] = struct{}{}
+			// sample new watches right away
+			e.next = time.Time{}
+		}
+	}
+	w.mu.Unlock()
+
+	w.poke()
+	return g, nil
+}
+
+// Remove stops watching the fields of the group. Fields still watched by
+// other groups keep being sampled
+func (g *WatchGroup) Remove() {
+	w := g.w
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, uuid := range g.uuids {
+		for _, id := range g.ids {
+			key := watchKey{uuid, id}
+			if e, ok := w.entries[key]; ok {
+				delete(e.groups, g)
+				if len(e.groups) == 0 {
+					delete(w.entries, key)
+				}
+			}
+		}
+	}
+}
+
+// Latest returns the latest sample of every field of the group, with a row
+// per device. Fields not sampled yet have a zero Sample
+func (g *WatchGroup) Latest() [][]Sample {
+	rows := make([][]Sample, len(g.uuids))
+	for i, uuid := range g.uuids {
+		rows[i] = make([]Sample, len(g.ids))
+		for j, id := range g.ids {
+			rows[i][j], _ = g.w.Latest(uuid, id)
+		}
+	}
+	return rows
+}
+
+// Latest returns the latest sample of a watched field of the device with the
+// given UUID, or false if the field is not watched or not sampled yet
+func (w *Watches) Latest(uuid, id string) (Sample, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	e, ok := w.entries[watchKey{uuid, id}]
+	if !ok || len(e.history) == 0 {
+		return Sample{}, false
+	}
+	return e.history[len(e.history)-1], true
+}
+
+// History returns the kept samples of a watched field, oldest first
+func (w *Watches) History(uuid, id string) []Sample {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	e, ok := w.entries[watchKey{uuid, id}]
+	if !ok {
+		return nil
+	}
+	return append([]Sample(nil), e.history...)
+}
+
+func (w *Watches) poke() {
+	select {
+	case w.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run samples the watched fields as they fall due, until ctx is done
+func (w *Watches) Run(ctx context.Context) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		case <-w.wake:
+			if !timer.Stop() {
+				select {
+				case <-timer.C:
+				default:
+				}
+			}
+		}
+
+		w.sample(ctx)
+
+		// with nothing watched, sleep until Watch pokes
+		wait := time.Hour
+		if next, ok := w.nextDue(); ok {
+			wait = time.Until(next)
+		}
+		timer.Reset(wait)
+	}
+}
+
+// sample reads every due field. HLML is called without holding the lock,
+// so readers are never blocked by a slow call
+func (w *Watches) sample(ctx context.Context) {
+	now := time.Now()
+	type due struct {
+		key watchKey
+		dev DeviceInterface
+		f   field
+	}
+	var todo []due
+
+	w.mu.Lock()
+	for key, e := range w.entries {
+		if !e.next.After(now) {
+			todo = append(todo, due{key, e.dev, e.field})
+		}
+	}
+	w.mu.Unlock()
+
+	for _, d := range todo {
+		v, err := d.f.get(ctx, d.dev)
+		if ctx.Err() != nil {
+			return
+		}
+		s := Sample{Time: time.Now(), Value: v, Err: err}
+		if err != nil {
+			s.Value = nil
+		}
+
+		w.mu.Lock()
+		// the group may have been removed meanwhile
+		if e, ok := w.entries[d.key]; ok {
+			interval, keep := e.settings()
+			e.next = now.Add(interval)
+			e.history = append(e.history, s)
+			cut := 0
+			for cut < len(e.history)-1 && s.Time.Sub(e.history[cut].Time) > keep {
+				cut++
+			}
+			e.history = append(e.history[:0], e.history[cut:]...)
+		}
+		w.mu.Unlock()
+	}
+}
+
+// settings returns the shortest interval and longest keep age among the
+// groups watching the entry
+func (e *watchEntry) settings() (interval, keep time.Duration) {
+	for g := range e.groups {
+		if interval == 0 || g.interval < interval {
+			interval = g.interval
+		}
+		if g.keep > keep {
+			keep = g.keep
+		}
+	}
+	return interval, keep
+}
+
+func (w *Watches) nextDue() (time.Time, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var next time.Time
+	for _, e := range w.entries {
+		if next.IsZero() || e.next.Before(next) {
+			next = e.next
+		}
+	}
+	return next, len(w.entries) > 0
+}
@@ -0,0 +1,128 @@
+/*
+ * Copyright (c) 2022, HabanaLabs Ltd.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the Lic
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package gohlml_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/HabanaAI/gohlml"
+	"github.com/HabanaAI/gohlml/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatches(t *testing.T) {
+	var power atomic.Uint32
+	dev := &mock.Device{
+		UUIDFunc: func() (string, error) { return "uuid0", nil },
+		PowerUsageContextFunc: func(context.Context) (uint, error) {
+			return uint(power.Add(1)), nil
+		},
+		TemperatureOnChipContextFunc: func(context.Context) (uint, error) {
+			return 0, gohlml.ErrAipIsLost
+		},
+	}
+	devices := []gohlml.DeviceInterface{dev}
+
+	w := gohlml.NewWatches()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go w.Run(ctx)
+
+	_, err := w.Watch(devices, []string{"no.such.field"}, time.Second, time.Second)
+	assert.ErrorIs(t, err, gohlml.ErrNotFound)
+
+	slow, err := w.Watch(devices, []string{"power.usage"}, time.Hour, time.Hour)
+	assert.Nil(t, err, err)
+	fast, err := w.Watch(devices, []string{"power.usage", "temperature.aip"}, 10*time.Millisecond, 50*time.Millisecond)
+	assert.Nil(t, err, err)
+
+	assert.Eventually(t, func() bool {
+		return len(w.History("uuid0", "power.usage")) >= 5
+	}, 5*time.Second, time.Millisecond, "The shortest interval should apply")
+
+	latest := fast.Latest()
+	assert.ErrorIs(t, latest[0][1].Err, gohlml.ErrAipIsLost)
+	s, ok := w.Latest("uuid0", "power.usage")
+	assert.True(t, ok)
+	assert.IsType(t, uint(0), s.Value)
+
+	fast.Remove()
+	calls := len(dev.PowerUsageContextCalls())
+	time.Sleep(100 * time.Millisecond)
+	assert.InDelta(t, calls, len(dev.PowerUsageContextCalls()), 1,
+		"The remaining group should sample at its own interval")
+	_, ok = w.Latest("uuid0", "temperature.aip")
+	assert.False(t, ok, "Fields no group watches should be dropped")
+
+	history := w.History("uuid0", "power.usage")
+	assert.NotEmpty(t, history)
+	slow.Remove()
+	assert.Empty(t, w.History("uuid0", "power.usage"))
+}
+
+func TestWatchesKeepAge(t *testing.T) {
+	dev := &mock.Device{UUIDFunc: func() (string, error) { return "uuid0", nil }}
+	devices := []gohlml.DeviceInterface{dev}
+
+	w := gohlml.NewWatches()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go w.Run(ctx)
+
+	_, err := w.Watch(devices, []string{"utilization.aip"}, 5*time.Millisecond, 30*time.Millisecond)
+	assert.Nil(t, err, err)
+
+	time.Sleep(200 * time.Millisecond)
+	history := w.History("uuid0", "utilization.aip")
+	if assert.NotEmpty(t, history) {
+		age := history[len(history)-1].Time.Sub(history[0].Time)
+		assert.LessOrEqual(t, age, 30*time.Millisecond, "Samples older than the keep age should be dropped")
+	}
+}
+
+// namedDevice is a DeviceInterface that cannot be used as a map key
+type namedDevice struct {
+	gohlml.DeviceInterface
+	names []string
+}
+
+func TestWatchesDeviceValues(t *testing.T) {
+	dev := &mock.Device{UUIDFunc: func() (string, error) { return "uuid0", nil }}
+	lost := &mock.Device{UUIDFunc: func() (string, error) { return "", gohlml.ErrAipIsLost }}
+
+	w := gohlml.NewWatches()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go w.Run(ctx)
+
+	_, err := w.Watch([]gohlml.DeviceInterface{dev, lost}, []string{"power.usage"}, time.Second, time.Second)
+	assert.ErrorIs(t, err, gohlml.ErrAipIsLost)
+
+	g, err := w.Watch([]gohlml.DeviceInterface{namedDevice{dev, []string{"a"}}}, []string{"power.usage"}, time.Hour, time.Hour)
+	assert.Nil(t, err, err)
+	_, err = w.Watch([]gohlml.DeviceInterface{dev}, []string{"power.usage"}, time.Hour, time.Hour)
+	assert.Nil(t, err, err)
+
+	assert.Eventually(t, func() bool {
+		_, ok := w.Latest("uuid0", "power.usage")
+		return ok
+	}, 5*time.Second, time.Millisecond)
+	assert.Len(t, dev.PowerUsageContextCalls(), 1, "Values of the same device should share samples")
+	assert.Len(t, g.Latest(), 1)
+}
@@ -0,0 +1,108 @@
+/*
+ * Copyright (c) 2022, HabanaLabs Ltd.  All rights reserved.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the Lic
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gohlml
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// WorkerState describes the worker thread that executes HLML calls
+type WorkerState struct {
+	// Op is the HLML function executing on the worker, empty when idle
+	Op string
+	// Since is when Op started executing
+	Since time.Time
+	// Abandoned counts the calls whose caller gave up, through its context,
+	// before the call completed
+	Abandoned uint64
+}
+
+type job struct {
+	op   string
+	fn   func()
+	done chan struct{}
+}
+
+// worker executes HLML calls one at a time on a goroutine locked to its OS
+// thread. A call stuck inside libhlml therefore pins a single thread, and
+// callers waiting behind it can give up through their context
+var worker struct {
+	start sync.Once
+	jobs  chan *job
+
+	mu    sync.Mutex
+	state WorkerState
+}
+
+// WorkerStatus reports the call executing on the HLML worker. A call whose
+// Since lies far in the past is likely stuck inside libhlml, e.g. on a
+// device whose AIP is lost
+func WorkerStatus() WorkerState {
+	worker.mu.Lock()
+	defer worker.mu.Unlock()
+
+	return worker.state
+}
+
+// runOnWorker runs fn on the worker and waits for it to complete. It returns
+// ctx.Err() if ctx is done first, leaving fn to complete in the background
+func runOnWorker(ctx context.Context, op string, fn func()) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	worker.start.Do(func() {
+		worker.jobs = make(chan *job)
+		go serveWorker()
+	})
+
+	j := &job{op: op, fn: fn, done: make(chan struct{})}
+	select {
+	case worker.jobs <- j:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	select {
+	case <-j.done:
+		return nil
+	case <-ctx.Done():
+		worker.mu.Lock()
+		worker.state.Abandoned++
+		worker.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+func serveWorker() {
+	runtime.LockOSThread()
+
+	for j := range worker.jobs {
+		worker.mu.Lock()
+		worker.state.Op, worker.state.Since = j.op, time.Now()
+		worker.mu.Unlock()
+
+		j.fn()
+
+		worker.mu.Lock()
+		worker.state.Op, worker.state.Since = "", time.Time{}
+		worker.mu.Unlock()
+		close(j.done)
+	}
+}